@@ -0,0 +1,10 @@
+// Package api содержит публичные контракты сервиса: OpenAPI спецификацию
+// HTTP API и protobuf описание gRPC API (reviewer/v1).
+package api
+
+import _ "embed"
+
+// OpenAPISpec OpenAPI 3 спецификация HTTP API в формате YAML.
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
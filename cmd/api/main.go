@@ -2,25 +2,55 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"github.com/T1mof/pr-reviewer-service/internal/chatops"
+	"github.com/T1mof/pr-reviewer-service/internal/codehost"
 	"github.com/T1mof/pr-reviewer-service/internal/config"
+	"github.com/T1mof/pr-reviewer-service/internal/events"
+	"github.com/T1mof/pr-reviewer-service/internal/grpcserver"
 	"github.com/T1mof/pr-reviewer-service/internal/handler"
+	"github.com/T1mof/pr-reviewer-service/internal/health"
+	"github.com/T1mof/pr-reviewer-service/internal/metrics"
+	"github.com/T1mof/pr-reviewer-service/internal/notify"
+	"github.com/T1mof/pr-reviewer-service/internal/openapi"
+	"github.com/T1mof/pr-reviewer-service/internal/privacy"
+	"github.com/T1mof/pr-reviewer-service/internal/ratelimit"
 	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/roster"
 	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
 )
 
 func main() {
 	config.SetupLogger()
 
-	if err := run(); err != nil {
+	// Подкоманды выполняются без запуска серверов.
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "migrate":
+		err = runMigrate(os.Args[2:], os.Stdout)
+	case len(os.Args) > 1 && os.Args[1] == "reconcile":
+		err = runReconcile(os.Args[2:], os.Stdout)
+	default:
+		err = run()
+	}
+
+	if err != nil {
 		slog.Error("Fatal error", "error", err)
 		os.Exit(1)
 	}
@@ -29,41 +59,437 @@ func main() {
 func run() error {
 	slog.Info("Starting PR Reviewer Service...")
 
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := metrics.New()
+
+	repo, db, closeStorage, err := openStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeStorage()
+
+	checker := health.NewChecker()
+	if db != nil {
+		if err := addStorageChecks(checker, cfg, db); err != nil {
+			return err
+		}
+	}
+
+	repo = withTeamCache(ctx, cfg, repo, checker)
+
+	if db != nil {
+		m.RegisterDB(db)
+	}
+	var orgs *tenant.Registry
+	var orgSource metrics.OrgSource
+	if cfg.OrganizationsEnabled {
+		orgs = newOrganizations(db, cfg.OrganizationsDefaultFallback)
+		orgSource = organizationIDs(orgs)
+	}
+	m.Register(metrics.NewReviewsCollector(repo.GetUserAssignmentStats, orgSource))
+
+	broker := newEventBroker(ctx, cfg, db, checker)
+
+	svcOpts := []service.Option{service.WithMetrics(m), service.WithEvents(broker)}
+
+	// Сопоставление логинов нужно приёму webhook'ов, отправке ревьюверов
+	// на хостинг и командам Slack, поэтому читается до создания сервиса.
+	webhooksEnabled := cfg.Webhook.GitHubSecret != "" || cfg.Webhook.GitLabToken != ""
+	slackEnabled := cfg.ChatOps.SlackSigningSecret != ""
+	var mapping *webhook.Mapping
+	var links codehost.Links = codehost.NewMemoryLinks()
+	if webhooksEnabled || slackEnabled {
+		if mapping, err = webhook.LoadMapping(cfg.Webhook.MappingFile); err != nil {
+			return err
+		}
+	}
+	if webhooksEnabled && db != nil {
+		links = codehost.NewSQLLinks(db)
+	}
+	if cfg.CodeHost.File != "" {
+		pusher, err := newCodeHostPusher(cfg, mapping, links)
+		if err != nil {
+			return err
+		}
+		runWorker(ctx, checker, "codehost_pusher", pusher.Run)
+		svcOpts = append(svcOpts, service.WithEvents(pusher))
+	}
+
+	// Настройки писем и отсутствия нужны и выгрузке персональных данных,
+	// поэтому хранилища создаются, даже если уведомления и Slack выключены.
+	prefs, absences := newUserStores(db)
+
+	var notifier *notify.Notifier
+	if cfg.Notify.SMTPAddr != "" {
+		if notifier, err = newNotifier(ctx, cfg, repo, prefs, checker); err != nil {
+			return err
+		}
+		svcOpts = append(svcOpts, service.WithEvents(notifier))
+	}
+
+	svc := service.NewReviewerService(repo, svcOpts...)
+
+	if cfg.Roster.File != "" {
+		beat := checker.Heartbeat("roster_sync", cfg.Roster.SyncInterval)
+		reconciler := roster.NewReconciler(repo, svc, cfg.Roster.OpenReviews, roster.WithHeartbeat(beat.Beat))
+		// Файл составов описывает организацию по умолчанию.
+		go reconciler.Watch(tenant.WithDefault(ctx), cfg.Roster.File, cfg.Roster.SyncInterval)
+	}
+
+	spec, err := openapi.NewValidator()
+	if err != nil {
+		return err
+	}
+
+	limits := ratelimit.NewDynamicPolicy(cfg.RateLimit.Policy)
+	go config.Watch(ctx, cfg, func(next *config.Config) {
+		limits.Set(next.RateLimit.Policy)
+	})
+
+	handlerOpts := []handler.Option{
+		handler.WithMetrics(m),
+		handler.WithOpenAPI(spec, cfg.OpenAPIValidation),
+		handler.WithRoster(roster.NewService(repo)),
+		handler.WithRequestTimeout(cfg.Server.RequestTimeout),
+		handler.WithHealth(checker),
+		handler.WithEvents(broker),
+		handler.WithUserRemoval(svc),
+		handler.WithSeniority(svc),
+		handler.WithPrivacy(privacy.NewService(repo,
+			privacy.WithEvents(broker),
+			privacy.WithNotifications(prefs),
+			privacy.WithAbsences(absences),
+			privacy.WithMapping(mapping),
+		)),
+	}
+	if cfg.RateLimit.Enabled {
+		handlerOpts = append(handlerOpts, handler.WithRateLimit(newRateLimiter(ctx, cfg, db), limits))
+	}
+	if webhooksEnabled {
+		handlerOpts = append(handlerOpts, newWebhooks(ctx, cfg, svc, db, mapping, links)...)
+	}
+	if slackEnabled {
+		handlerOpts = append(handlerOpts, handler.WithSlack(newSlack(ctx, cfg, svc, absences, mapping)))
+	}
+	if notifier != nil {
+		handlerOpts = append(handlerOpts, handler.WithNotifications(notifier))
+	}
+	grpcOpts := []grpcserver.Option{grpcserver.WithUserRemoval(svc)}
+	if orgs != nil {
+		handlerOpts = append(handlerOpts, handler.WithOrganizations(orgs))
+		grpcOpts = append(grpcOpts, grpcserver.WithOrganizations(orgs))
+	}
+	h := handler.NewHandler(svc, cfg.AdminToken, handlerOpts...)
+
+	srv := startServer(cfg.Port, cfg.Server, h.SetupRouter())
+	// Потоки событий не завершаются сами, Shutdown ждал бы их до таймаута.
+	srv.RegisterOnShutdown(broker.Close)
+	grpcSrv, err := startGRPCServer(cfg.GRPCPort, grpcserver.New(svc, cfg.AdminToken, grpcOpts...))
+	if err != nil {
+		return err
+	}
+
+	waitForShutdown(srv, grpcSrv, checker, cfg.Server)
+
+	return nil
+}
+
+// openStorage создаёт репозиторий согласно STORAGE и схеме DATABASE_URL.
+// Для in-memory хранилища возвращаемый *sql.DB равен nil.
+func openStorage(cfg *config.Config) (repository.RepositoryInterface, *sql.DB, func(), error) {
+	if cfg.Storage == config.StorageMemory {
+		slog.Warn("Using in-memory storage, data will be lost on restart")
+		return repository.NewMemoryRepository(), nil, func() {}, nil
+	}
 
 	db, err := cfg.ConnectDB()
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
-	defer func() {
+	closeDB := func() {
 		if err := db.Close(); err != nil {
 			slog.Error("Failed to close database connection", "error", err)
 		}
+	}
+
+	if cfg.AutoMigrate {
+		if err := cfg.RunMigrations(db.DB); err != nil {
+			closeDB()
+			return nil, nil, nil, fmt.Errorf("failed to run migrations: %w", err)
+		}
+	} else {
+		slog.Info("Auto-migration disabled, expecting schema to be migrated with \"migrate up\"")
+	}
+
+	if cfg.Storage == config.StorageSQLite {
+		return repository.NewSQLiteRepository(db.DB), db.DB, closeDB, nil
+	}
+	var opts []repository.RepositoryOption
+	if cfg.TeamCacheTTL > 0 {
+		// Кэши других реплик сбрасываются уведомлениями из транзакций записи.
+		opts = append(opts, repository.WithCacheNotify())
+	}
+	return repository.NewRepository(db.DB, opts...), db.DB, closeDB, nil
+}
+
+// addStorageChecks добавляет проверки готовности БД и версии схемы.
+func addStorageChecks(checker *health.Checker, cfg *config.Config, db *sql.DB) error {
+	expected, err := cfg.LatestMigration()
+	if err != nil {
+		return err
+	}
+
+	checker.Add("database", health.Database(db))
+	checker.Add("migrations", health.Migrations(db, expected))
+	return nil
+}
+
+// withTeamCache включает кэш составов команд. Для PostgreSQL реплика
+// слушает инвалидации, которые репозиторий отправляет через NOTIFY в
+// транзакциях записи; если checker не nil, остановка слушателя делает
+// сервис неготовым.
+func withTeamCache(ctx context.Context, cfg *config.Config, repo repository.RepositoryInterface, checker *health.Checker) repository.RepositoryInterface {
+	if cfg.TeamCacheTTL <= 0 || cfg.Storage == config.StorageMemory {
+		return repo
+	}
+
+	if cfg.Storage != config.StoragePostgres {
+		return repository.NewCachedRepository(repo, cfg.TeamCacheTTL)
+	}
+
+	bus := repository.NewPGInvalidationBus(cfg.DatabaseURL)
+	cached := repository.NewCachedRepository(repo, cfg.TeamCacheTTL)
+
+	var beat *health.Heartbeat
+	if checker != nil {
+		beat = checker.Heartbeat("cache_invalidation", 0)
+	}
+
+	go func() {
+		if err := bus.Listen(ctx, cached.Invalidate); err != nil {
+			slog.Error("Cache invalidation listener stopped", "error", err)
+			if beat != nil {
+				beat.Stop(err)
+			}
+		}
 	}()
 
-	if err := cfg.RunMigrations(db.DB); err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+	return cached
+}
+
+// newEventBroker создаёт брокер событий /users/reviewStream с журналом
+// в хранилище сервиса. Для PostgreSQL события других реплик приходят через
+// LISTEN/NOTIFY; остановка слушателя делает сервис неготовым.
+func newEventBroker(ctx context.Context, cfg *config.Config, db *sql.DB, checker *health.Checker) *events.Broker {
+	var store events.Store
+	switch cfg.Storage {
+	case config.StorageMemory:
+		store = events.NewMemoryStore()
+	case config.StorageSQLite:
+		store = events.NewSQLiteStore(db)
+	default:
+		store = events.NewPostgresStore(db)
 	}
 
-	repo := repository.NewRepository(db.DB)
-	svc := service.NewReviewerService(repo)
-	h := handler.NewHandler(svc, cfg.AdminToken)
+	broker := events.NewBroker(store)
+	go broker.Cleanup(ctx, cfg.EventsRetention)
 
-	srv := startServer(cfg.Port, h.SetupRouter())
+	if cfg.Storage == config.StoragePostgres {
+		beat := checker.Heartbeat("review_events", 0)
+		go func() {
+			if err := broker.ListenPostgres(ctx, cfg.DatabaseURL); err != nil {
+				slog.Error("Review events listener stopped", "error", err)
+				beat.Stop(err)
+			}
+		}()
+	}
 
-	waitForShutdown(srv)
+	return broker
+}
 
-	return nil
+// newWebhooks включает приём webhook'ов хостингов, для которых задан
+// секрет. Доставки отмечаются в хранилище сервиса, чтобы повтор отсекался
+// любой репликой.
+func newWebhooks(ctx context.Context, cfg *config.Config, svc service.ServiceInterface, db *sql.DB, mapping *webhook.Mapping, links codehost.Links) []handler.Option {
+	var deliveries webhook.Deliveries = webhook.NewMemoryDeliveries()
+	if db != nil {
+		deliveries = webhook.NewSQLDeliveries(db)
+	}
+	go webhook.CleanupDeliveries(ctx, deliveries, cfg.Webhook.DeliveryRetention)
+
+	var opts []handler.Option
+	if cfg.Webhook.GitHubSecret != "" {
+		slog.Info("GitHub webhook enabled",
+			"users", len(mapping.GitHub.Users),
+			"repositories", len(mapping.GitHub.Repositories),
+		)
+		opts = append(opts, handler.WithGitHub(webhook.NewGitHub(svc, cfg.Webhook.GitHubSecret, mapping, deliveries, webhook.WithLinks(links))))
+	}
+	if cfg.Webhook.GitLabToken != "" {
+		slog.Info("GitLab webhook enabled",
+			"users", len(mapping.GitLab.Users),
+			"projects", len(mapping.GitLab.Projects),
+		)
+		opts = append(opts, handler.WithGitLab(webhook.NewGitLab(svc, cfg.Webhook.GitLabToken, mapping, deliveries, webhook.WithLinks(links))))
+	}
+	return opts
+}
+
+// newUserStores создаёт хранилища настроек писем и отсутствий. Они
+// хранятся в базе, общей для реплик, чтобы пережить перезапуск.
+func newUserStores(db *sql.DB) (notify.Store, chatops.Absences) {
+	if db == nil {
+		return notify.NewMemoryStore(), chatops.NewMemoryAbsences()
+	}
+	return notify.NewSQLStore(db), chatops.NewSQLAbsences(db)
+}
+
+// newSlack включает slash-команды Slack. Проверку отсутствий выполняет
+// каждая реплика, повторная активация безвредна.
+func newSlack(ctx context.Context, cfg *config.Config, svc service.ServiceInterface, absences chatops.Absences, mapping *webhook.Mapping) *chatops.Slack {
+	go chatops.ReturnAbsent(ctx, absences, svc, cfg.ChatOps.AbsenceCheckInterval)
+
+	slog.Info("Slack commands enabled", "users", len(mapping.Slack.Users))
+	return chatops.NewSlack(svc, cfg.ChatOps.SlackSigningSecret, mapping.Accounts(webhook.ProviderSlack), absences)
+}
+
+// newNotifier включает email-уведомления о назначениях и ежедневную
+// сводку. Сводка, не разосланная три дня подряд, делает сервис неготовым.
+func newNotifier(ctx context.Context, cfg *config.Config, repo repository.RepositoryInterface, store notify.Store, checker *health.Checker) (*notify.Notifier, error) {
+	mailer, err := notify.NewSMTPMailer(notify.SMTPConfig{
+		Addr:     cfg.Notify.SMTPAddr,
+		Username: cfg.Notify.SMTPUsername,
+		Password: cfg.Notify.SMTPPassword,
+		From:     cfg.Notify.From,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	templates := notify.DefaultTemplates()
+	if cfg.Notify.TemplatesFile != "" {
+		if templates, err = notify.LoadTemplates(cfg.Notify.TemplatesFile); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []notify.Option{notify.WithTemplates(templates)}
+	if cfg.Notify.Digest {
+		beat := checker.Heartbeat("notify_digest", 24*time.Hour)
+		opts = append(opts, notify.WithDigestHeartbeat(beat.Beat))
+	}
+
+	notifier := notify.NewNotifier(repo, store, mailer, opts...)
+	runWorker(ctx, checker, "notify_queue", notifier.Run)
+	if cfg.Notify.Digest {
+		go notifier.RunDigest(ctx, cfg.Notify.DigestAt)
+	}
+
+	slog.Info("Email notifications enabled",
+		"smtp_addr", cfg.Notify.SMTPAddr,
+		"digest", cfg.Notify.Digest,
+		"digest_time", time.Time{}.Add(cfg.Notify.DigestAt).Format("15:04"),
+	)
+	return notifier, nil
+}
+
+// runWorker запускает фоновую очередь. Очередь простаивает между
+// событиями, поэтому проверка готовности падает, только если run
+// завершилась раньше отмены ctx.
+func runWorker(ctx context.Context, checker *health.Checker, name string, run func(context.Context)) {
+	beat := checker.Heartbeat(name, 0)
+	go func() {
+		run(ctx)
+		if ctx.Err() == nil {
+			slog.Error("Background worker stopped", "worker", name)
+			beat.Stop(nil)
+		}
+	}()
+}
+
+// codeHostTimeout таймаут одного запроса к API хостинга.
+const codeHostTimeout = 10 * time.Second
+
+// newCodeHostPusher настраивает отправку назначенных ревьюверов на
+// хостинги из CODE_HOST_FILE.
+func newCodeHostPusher(cfg *config.Config, mapping *webhook.Mapping, links codehost.Links) (*codehost.Pusher, error) {
+	hosts, err := codehost.LoadConfig(cfg.CodeHost.File)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: codeHostTimeout}
+	providers := make(map[string]codehost.Provider)
+	if hosts.GitHub != nil {
+		providers[webhook.ProviderGitHub] = codehost.Provider{
+			Client: codehost.NewGitHubClient(hosts.GitHub, httpClient),
+			Logins: mapping.Accounts(webhook.ProviderGitHub),
+		}
+		slog.Info("Pushing reviewers to GitHub enabled", "api_url", hosts.GitHub.APIURL, "repositories", len(hosts.GitHub.Repositories))
+	}
+	if hosts.GitLab != nil {
+		providers[webhook.ProviderGitLab] = codehost.Provider{
+			Client: codehost.NewGitLabClient(hosts.GitLab, httpClient),
+			Logins: mapping.Accounts(webhook.ProviderGitLab),
+		}
+		slog.Info("Pushing reviewers to GitLab enabled", "api_url", hosts.GitLab.APIURL, "projects", len(hosts.GitLab.Projects))
+	}
+
+	return codehost.NewPusher(links, providers, codehost.WithRetry(cfg.CodeHost.MaxAttempts, time.Second)), nil
+}
+
+// newRateLimiter создаёт хранилище лимитов согласно конфигурации.
+// Очистка bucket'ов в БД останавливается с ctx.
+func newRateLimiter(ctx context.Context, cfg *config.Config, db *sql.DB) ratelimit.Limiter {
+	if cfg.RateLimit.Backend == "postgres" && cfg.Storage != config.StoragePostgres {
+		slog.Warn("Postgres rate limit backend requires postgres storage, falling back to memory")
+		return ratelimit.NewMemoryLimiter()
+	}
+	if cfg.RateLimit.Backend == "postgres" {
+		limiter := ratelimit.NewPostgresLimiter(db)
+		go cleanupRateLimitBuckets(ctx, limiter)
+		return limiter
+	}
+	return ratelimit.NewMemoryLimiter()
+}
+
+// cleanupRateLimitBuckets периодически удаляет неиспользуемые bucket'ы из БД
+// до отмены ctx.
+func cleanupRateLimitBuckets(ctx context.Context, limiter *ratelimit.PostgresLimiter) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := limiter.Cleanup(ctx, time.Hour)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to cleanup rate limit buckets", "error", err)
+			continue
+		}
+		slog.DebugContext(ctx, "Rate limit buckets cleaned up", "deleted", deleted)
+	}
 }
 
 // startServer запускает HTTP сервер.
-func startServer(port string, handler http.Handler) *http.Server {
+func startServer(port string, timeouts config.ServerConfig, handler http.Handler) *http.Server {
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", port),
 		Handler:      handler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  timeouts.ReadTimeout,
+		WriteTimeout: timeouts.WriteTimeout,
+		IdleTimeout:  timeouts.IdleTimeout,
 	}
 
 	go func() {
@@ -77,20 +503,102 @@ func startServer(port string, handler http.Handler) *http.Server {
 	return srv
 }
 
-// waitForShutdown ожидает сигнал остановки и gracefully завершает сервер.
-func waitForShutdown(srv *http.Server) {
+// startGRPCServer запускает gRPC сервер на отдельном порту.
+func startGRPCServer(port string, srv *grpc.Server) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on gRPC port: %w", err)
+	}
+
+	go func() {
+		slog.Info("gRPC server is starting", "port", port)
+		if err := srv.Serve(lis); err != nil {
+			slog.Error("gRPC server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	return srv, nil
+}
+
+// waitForShutdown ожидает сигнал остановки и gracefully завершает HTTP и gRPC
+// серверы. Сначала /readyz переходит в "не готов" на DrainDelay, чтобы
+// балансировщик снял трафик, затем оба сервера делят один таймаут.
+func waitForShutdown(srv *http.Server, grpcSrv *grpc.Server, checker *health.Checker, cfg config.ServerConfig) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	<-quit
+	checker.Shutdown()
+	if cfg.DrainDelay > 0 {
+		slog.Info("Draining traffic before shutdown", "delay", cfg.DrainDelay)
+		time.Sleep(cfg.DrainDelay)
+	}
 	slog.Info("Shutting down server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		slog.Error("Server forced to shutdown", "error", err)
-	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		if err := srv.Shutdown(ctx); err != nil {
+			slog.Error("Server forced to shutdown", "error", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			slog.Error("gRPC server forced to shutdown", "error", ctx.Err())
+			grpcSrv.Stop()
+		}
+	}()
+
+	wg.Wait()
 
 	slog.Info("Server exited gracefully")
 }
+
+// newOrganizations создаёт реестр организаций в БД или, для STORAGE=memory,
+// в памяти процесса. defaultFallback относит запросы без токена к
+// организации default.
+func newOrganizations(db *sql.DB, defaultFallback bool) *tenant.Registry {
+	var store tenant.Store = tenant.NewMemoryStore()
+	if db != nil {
+		store = tenant.NewSQLStore(db)
+	}
+
+	var opts []tenant.RegistryOption
+	if defaultFallback {
+		opts = append(opts, tenant.WithDefaultFallback())
+	}
+
+	slog.Info("Organizations enabled", "default_fallback", defaultFallback)
+	return tenant.NewRegistry(store, opts...)
+}
+
+// organizationIDs источник организаций для метрик открытых ревью.
+func organizationIDs(orgs *tenant.Registry) metrics.OrgSource {
+	return func(ctx context.Context) ([]uuid.UUID, error) {
+		list, err := orgs.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]uuid.UUID, 0, len(list))
+		for _, org := range list {
+			ids = append(ids, org.OrgID)
+		}
+		return ids, nil
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+)
+
+const migrateUsage = `Usage: api migrate <command>
+
+Commands:
+  up         apply all pending migrations
+  down [N]   roll back N migrations (default 1)
+  version    print current schema version
+  force V    set schema version without running migrations (recovery after a failed migration)
+`
+
+// errMigrateUsage ошибка аргументов подкоманды migrate.
+var errMigrateUsage = errors.New("invalid migrate arguments")
+
+// runMigrate выполняет подкоманду "migrate" против DATABASE_URL.
+func runMigrate(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(out, migrateUsage)
+		return errMigrateUsage
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Storage == config.StorageMemory {
+		return errors.New("migrations require a database, STORAGE=memory is set")
+	}
+
+	db, err := cfg.ConnectDB()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	m, err := cfg.NewMigrate(db.DB)
+	if err != nil {
+		return err
+	}
+
+	if err := migrateCommand(m, args, out); err != nil {
+		if errors.Is(err, errMigrateUsage) {
+			fmt.Fprint(out, migrateUsage)
+		}
+		return err
+	}
+	return nil
+}
+
+// migrateCommand выполняет команду migrate над готовым экземпляром.
+func migrateCommand(m *migrate.Migrate, args []string, out io.Writer) error {
+	switch args[0] {
+	case "up":
+		if len(args) != 1 {
+			return errMigrateUsage
+		}
+		err := m.Up()
+		if errors.Is(err, migrate.ErrNoChange) {
+			slog.Info("No new migrations to apply")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+		slog.Info("Migrations applied successfully")
+
+	case "down":
+		steps := 1
+		switch len(args) {
+		case 1:
+		case 2:
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("%w: down expects a positive number of steps, got %q", errMigrateUsage, args[1])
+			}
+			steps = n
+		default:
+			return errMigrateUsage
+		}
+		err := m.Steps(-steps)
+		if errors.Is(err, migrate.ErrNoChange) {
+			slog.Info("No migrations to roll back")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to roll back migrations: %w", err)
+		}
+		slog.Info("Migrations rolled back", "steps", steps)
+
+	case "version":
+		if len(args) != 1 {
+			return errMigrateUsage
+		}
+		version, dirty, err := m.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			fmt.Fprintln(out, "no migrations applied")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read schema version: %w", err)
+		}
+		if dirty {
+			fmt.Fprintf(out, "%d (dirty)\n", version)
+		} else {
+			fmt.Fprintln(out, version)
+		}
+
+	case "force":
+		if len(args) != 2 {
+			return errMigrateUsage
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("%w: force expects a version number, got %q", errMigrateUsage, args[1])
+		}
+		if err := m.Force(version); err != nil {
+			return fmt.Errorf("failed to force schema version: %w", err)
+		}
+		slog.Info("Schema version forced", "version", version)
+
+	default:
+		return fmt.Errorf("%w: unknown command %q", errMigrateUsage, args[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+)
+
+func TestMigrateCommand_EmbeddedMigrations(t *testing.T) {
+	cfg := &config.Config{DatabaseURL: "sqlite://" + filepath.Join(t.TempDir(), "test.db")}
+
+	db, err := cfg.ConnectDB()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := cfg.NewMigrate(db.DB)
+	require.NoError(t, err)
+
+	version := func() string {
+		var out bytes.Buffer
+		require.NoError(t, migrateCommand(m, []string{"version"}, &out))
+		return out.String()
+	}
+
+	assert.Equal(t, "no migrations applied\n", version())
+
+	require.NoError(t, migrateCommand(m, []string{"up"}, &bytes.Buffer{}))
+	assert.Equal(t, "11\n", version())
+
+	var tables int
+	require.NoError(t, db.Get(&tables, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'pull_requests'`))
+	assert.Equal(t, 1, tables)
+
+	// Повторный up ничего не делает.
+	require.NoError(t, migrateCommand(m, []string{"up"}, &bytes.Buffer{}))
+
+	require.NoError(t, migrateCommand(m, []string{"down"}, &bytes.Buffer{}))
+	assert.Equal(t, "10\n", version())
+
+	require.NoError(t, migrateCommand(m, []string{"down", "10"}, &bytes.Buffer{}))
+	assert.Equal(t, "no migrations applied\n", version())
+
+	require.NoError(t, migrateCommand(m, []string{"force", "1"}, &bytes.Buffer{}))
+	assert.Equal(t, "1\n", version())
+}
+
+func TestMigrateCommand_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"sideways"},
+		{"up", "extra"},
+		{"down", "0"},
+		{"down", "x"},
+		{"force"},
+		{"force", "v1"},
+	} {
+		err := migrateCommand(nil, args, &bytes.Buffer{})
+		assert.ErrorIs(t, err, errMigrateUsage, "args %v", args)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os/signal"
+	"syscall"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+	"github.com/T1mof/pr-reviewer-service/internal/roster"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+const reconcileUsage = `Usage: api reconcile [flags] FILE
+
+Brings teams listed in FILE (YAML, JSON or CSV, same format as /admin/import)
+to the declared state. Active members missing from FILE are deactivated.
+
+Flags:
+  -dry-run         print the plan without applying it
+  -watch           keep running and reconcile every -interval
+  -interval        reconcile interval for -watch (default ROSTER_SYNC_INTERVAL)
+  -open-reviews    keep or reassign: what to do with removed members that still
+                   have open reviews (default ROSTER_OPEN_REVIEWS)
+`
+
+// runReconcile выполняет подкоманду "reconcile".
+func runReconcile(args []string, out io.Writer) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Storage == config.StorageMemory {
+		return errors.New("reconcile requires a database, STORAGE=memory is set")
+	}
+
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() { fmt.Fprint(out, reconcileUsage) }
+	dryRun := fs.Bool("dry-run", false, "print the plan without applying it")
+	watch := fs.Bool("watch", false, "keep running and reconcile on a timer")
+	interval := fs.Duration("interval", cfg.Roster.SyncInterval, "reconcile interval for -watch")
+	openReviews := fs.String("open-reviews", string(cfg.Roster.OpenReviews), "keep or reassign")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("reconcile expects exactly one FILE argument")
+	}
+	path := fs.Arg(0)
+
+	policy, err := roster.ParseOpenReviewsPolicy(*openReviews)
+	if err != nil {
+		return err
+	}
+	if *watch && *dryRun {
+		return errors.New("-watch and -dry-run cannot be combined")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	repo, _, closeStorage, err := openStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeStorage()
+
+	// Репозиторий отправляет инвалидации репликам сервиса в транзакциях записи.
+	repo = withTeamCache(ctx, cfg, repo, nil)
+	reconciler := roster.NewReconciler(repo, service.NewReviewerService(repo), policy)
+	// Файл составов описывает организацию по умолчанию.
+	ctx = tenant.WithDefault(ctx)
+
+	if *watch {
+		reconciler.Watch(ctx, path, *interval)
+		return nil
+	}
+
+	report, err := reconciler.ReconcileFile(ctx, path, *dryRun)
+	if err != nil {
+		var validationErr *roster.ValidationError
+		if errors.As(err, &validationErr) {
+			for _, row := range validationErr.Rows {
+				fmt.Fprintf(out, "%s: %s: %s\n", path, row.Location, row.Message)
+			}
+		}
+		return err
+	}
+
+	return report.WritePlan(out)
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/pkg/client"
+)
+
+// newFlagSet создаёт флаги команды. -o/--output можно указать и после команды.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	setOutput := func(v string) error {
+		switch v {
+		case "table":
+			a.json = false
+		case "json":
+			a.json = true
+		default:
+			return fmt.Errorf("unknown output format %q", v)
+		}
+		return nil
+	}
+	fs.Func("o", "output format", setOutput)
+	fs.Func("output", "output format", setOutput)
+	return fs
+}
+
+// parse разбирает флаги и проверяет число позиционных аргументов.
+func parse(fs *flag.FlagSet, args []string, positional int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	// Флаги могут идти после позиционных аргументов: "pr merge ID -o json".
+	var rest []string
+	for remaining := fs.Args(); len(remaining) > 0; remaining = fs.Args() {
+		rest = append(rest, remaining[0])
+		if err := fs.Parse(remaining[1:]); err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+	}
+
+	if len(rest) != positional {
+		return nil, fmt.Errorf("%w: expected %d argument(s), got %d", errUsage, positional, len(rest))
+	}
+	return rest, nil
+}
+
+func parseID(value, field string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid %s %q", errUsage, field, value)
+	}
+	return id, nil
+}
+
+// ========================================
+// Teams
+// ========================================
+
+// memberList флаг вида USER_ID=USERNAME, может повторяться.
+type memberList struct {
+	members  *[]client.TeamMember
+	isActive bool
+}
+
+func (m memberList) String() string { return "" }
+
+func (m memberList) Set(value string) error {
+	id, username, ok := strings.Cut(value, "=")
+	if !ok || username == "" {
+		return fmt.Errorf("member must be USER_ID=USERNAME, got %q", value)
+	}
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("invalid user_id %q", id)
+	}
+	*m.members = append(*m.members, client.TeamMember{UserID: userID, Username: username, IsActive: m.isActive})
+	return nil
+}
+
+func teamCreate(ctx context.Context, a *app, args []string) error {
+	team := &client.Team{}
+
+	fs := a.newFlagSet("team create")
+	fs.StringVar(&team.TeamName, "name", "", "team name")
+	fs.Var(memberList{members: &team.Members, isActive: true}, "member", "active member USER_ID=USERNAME")
+	fs.Var(memberList{members: &team.Members, isActive: false}, "inactive", "inactive member USER_ID=USERNAME")
+	file := fs.String("f", "", "team JSON file (- for stdin), same format as POST /team/add")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *file != "" {
+		if team.TeamName != "" || len(team.Members) > 0 {
+			return fmt.Errorf("%w: -f cannot be combined with --name/--member", errUsage)
+		}
+		if err := readJSONFile(*file, team); err != nil {
+			return err
+		}
+	}
+	if team.TeamName == "" || len(team.Members) == 0 {
+		return fmt.Errorf("%w: team name and at least one member are required", errUsage)
+	}
+
+	if err := a.client.CreateTeam(ctx, team); err != nil {
+		return err
+	}
+	return a.printTeam(team)
+}
+
+func teamGet(ctx context.Context, a *app, args []string) error {
+	rest, err := parse(a.newFlagSet("team get"), args, 1)
+	if err != nil {
+		return err
+	}
+
+	team, err := a.client.GetTeam(ctx, rest[0])
+	if err != nil {
+		return err
+	}
+	return a.printTeam(team)
+}
+
+func (a *app) printTeam(team *client.Team) error {
+	if a.json {
+		return a.printJSON(team)
+	}
+
+	fmt.Fprintf(a.out, "Team: %s\n", team.TeamName)
+	if rule := team.SeniorityRule; rule != nil {
+		fmt.Fprintf(a.out, "Seniority rule: at least %d %s reviewer(s) per PR\n", rule.Count, rule.Level)
+	}
+	fmt.Fprintln(a.out)
+	t := newTable(a.out, "USER_ID", "USERNAME", "ACTIVE", "SENIORITY")
+	for _, m := range team.Members {
+		t.row(m.UserID, m.Username, m.IsActive, m.Seniority)
+	}
+	return t.flush()
+}
+
+func teamRule(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("team rule")
+	level := fs.String("level", "", "minimal reviewer seniority: junior, middle or senior")
+	count := fs.Int("count", 1, "reviewers of the level required per PR")
+	remove := fs.Bool("clear", false, "remove the rule")
+
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if *remove == (*level != "") {
+		return fmt.Errorf("%w: exactly one of --level and --clear is required", errUsage)
+	}
+
+	var rule *client.SeniorityRule
+	if !*remove {
+		rule = &client.SeniorityRule{Level: *level, Count: *count}
+	}
+	team, err := a.client.SetSeniorityRule(ctx, rest[0], rule)
+	if err != nil {
+		return err
+	}
+	return a.printTeam(team)
+}
+
+// ========================================
+// Users
+// ========================================
+
+func userSetActive(isActive bool) command {
+	name := "user deactivate"
+	if isActive {
+		name = "user activate"
+	}
+
+	return func(ctx context.Context, a *app, args []string) error {
+		rest, err := parse(a.newFlagSet(name), args, 1)
+		if err != nil {
+			return err
+		}
+		userID, err := parseID(rest[0], "user_id")
+		if err != nil {
+			return err
+		}
+
+		user, err := a.client.SetUserActive(ctx, userID, isActive)
+		if err != nil {
+			return err
+		}
+
+		if a.json {
+			return a.printJSON(user)
+		}
+		t := newTable(a.out, "USER_ID", "USERNAME", "TEAM", "ACTIVE")
+		t.row(user.UserID, user.Username, user.TeamName, user.IsActive)
+		return t.flush()
+	}
+}
+
+func userSeniority(ctx context.Context, a *app, args []string) error {
+	rest, err := parse(a.newFlagSet("user seniority"), args, 2)
+	if err != nil {
+		return err
+	}
+	userID, err := parseID(rest[0], "user_id")
+	if err != nil {
+		return err
+	}
+
+	user, err := a.client.SetUserSeniority(ctx, userID, rest[1])
+	if err != nil {
+		return err
+	}
+
+	if a.json {
+		return a.printJSON(user)
+	}
+	t := newTable(a.out, "USER_ID", "USERNAME", "TEAM", "SENIORITY")
+	t.row(user.UserID, user.Username, user.TeamName, user.Seniority)
+	return t.flush()
+}
+
+func userReviews(ctx context.Context, a *app, args []string) error {
+	rest, err := parse(a.newFlagSet("user reviews"), args, 1)
+	if err != nil {
+		return err
+	}
+	userID, err := parseID(rest[0], "user_id")
+	if err != nil {
+		return err
+	}
+
+	prs, err := a.client.GetUserReviews(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if a.json {
+		if prs == nil {
+			prs = []client.PullRequestShort{}
+		}
+		return a.printJSON(prs)
+	}
+	t := newTable(a.out, "PR_ID", "NAME", "AUTHOR_ID", "STATUS")
+	for _, pr := range prs {
+		t.row(pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status)
+	}
+	return t.flush()
+}
+
+func userRemove(ctx context.Context, a *app, args []string) error {
+	rest, err := parse(a.newFlagSet("user remove"), args, 1)
+	if err != nil {
+		return err
+	}
+	userID, err := parseID(rest[0], "user_id")
+	if err != nil {
+		return err
+	}
+
+	removal, err := a.client.RemoveUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if a.json {
+		return a.printJSON(removal)
+	}
+	fmt.Fprintf(a.out, "User %s removed, %d open review(s) reassigned\n", removal.User.UserID, len(removal.Reassigned))
+	if len(removal.Reassigned) == 0 {
+		return nil
+	}
+	fmt.Fprintln(a.out)
+	t := newTable(a.out, "PR_ID", "REPLACED_BY")
+	for _, r := range removal.Reassigned {
+		t.row(r.PullRequestID, r.ReplacedBy)
+	}
+	return t.flush()
+}
+
+// ========================================
+// Pull Requests
+// ========================================
+
+func prCreate(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("pr create")
+	id := fs.String("id", "", "PR id (generated if empty)")
+	name := fs.String("name", "", "PR name")
+	author := fs.String("author", "", "author user_id")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *name == "" || *author == "" {
+		return fmt.Errorf("%w: --name and --author are required", errUsage)
+	}
+
+	prID := uuid.New()
+	if *id != "" {
+		var err error
+		if prID, err = parseID(*id, "PR id"); err != nil {
+			return err
+		}
+	}
+	authorID, err := parseID(*author, "author")
+	if err != nil {
+		return err
+	}
+
+	pr, err := a.client.CreatePR(ctx, prID, *name, authorID)
+	if err != nil {
+		return err
+	}
+	return a.printPR(pr)
+}
+
+func prMerge(ctx context.Context, a *app, args []string) error {
+	rest, err := parse(a.newFlagSet("pr merge"), args, 1)
+	if err != nil {
+		return err
+	}
+	prID, err := parseID(rest[0], "PR id")
+	if err != nil {
+		return err
+	}
+
+	pr, err := a.client.MergePR(ctx, prID)
+	if err != nil {
+		return err
+	}
+	return a.printPR(pr)
+}
+
+func prReassign(ctx context.Context, a *app, args []string) error {
+	rest, err := parse(a.newFlagSet("pr reassign"), args, 2)
+	if err != nil {
+		return err
+	}
+	prID, err := parseID(rest[0], "PR id")
+	if err != nil {
+		return err
+	}
+	oldUserID, err := parseID(rest[1], "reviewer id")
+	if err != nil {
+		return err
+	}
+
+	pr, replacedBy, err := a.client.ReassignReviewer(ctx, prID, oldUserID)
+	if err != nil {
+		return err
+	}
+
+	if a.json {
+		return a.printJSON(map[string]any{"pr": pr, "replaced_by": replacedBy})
+	}
+	fmt.Fprintf(a.out, "Reviewer %s replaced by %s\n\n", oldUserID, replacedBy)
+	return a.printPR(pr)
+}
+
+func (a *app) printPR(pr *client.PullRequestWithReviewers) error {
+	if a.json {
+		return a.printJSON(pr)
+	}
+
+	reviewers := make([]string, len(pr.AssignedReviewers))
+	for i, id := range pr.AssignedReviewers {
+		reviewers[i] = id.String()
+	}
+
+	t := newTable(a.out, "PR_ID", "NAME", "AUTHOR_ID", "STATUS", "REVIEWERS")
+	t.row(pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, strings.Join(reviewers, ","))
+	return t.flush()
+}
+
+// ========================================
+// Stats
+// ========================================
+
+func stats(ctx context.Context, a *app, args []string) error {
+	if _, err := parse(a.newFlagSet("stats"), args, 0); err != nil {
+		return err
+	}
+
+	s, err := a.client.GetStatistics(ctx)
+	if err != nil {
+		return err
+	}
+
+	if a.json {
+		return a.printJSON(s)
+	}
+
+	fmt.Fprintf(a.out, "PRs: %d total, %d open, %d merged, avg merge time %.1fh\n",
+		s.PRStats.TotalPRs, s.PRStats.TotalOpen, s.PRStats.TotalMerged, s.PRStats.AvgMergeTimeHours)
+	fmt.Fprintf(a.out, "Users: %d total, %d active; teams: %d\n\n", s.TotalUsers, s.ActiveUsers, s.TotalTeams)
+
+	t := newTable(a.out, "USER_ID", "USERNAME", "TEAM", "TOTAL", "OPEN", "MERGED")
+	for _, u := range s.UserStats {
+		t.row(u.UserID, u.Username, u.TeamName, u.TotalAssignments, u.OpenAssignments, u.MergedAssignments)
+	}
+	return t.flush()
+}
+
+// ========================================
+// Helpers
+// ========================================
+
+func (a *app) printJSON(v any) error {
+	enc := json.NewEncoder(a.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func readJSONFile(path string, v any) error {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// Переменные окружения prctl.
+const (
+	envServer = "PRCTL_SERVER"
+	envToken  = "PRCTL_TOKEN"
+	envConfig = "PRCTL_CONFIG"
+)
+
+// settings адрес сервиса и admin токен.
+// Приоритет: флаги, затем переменные окружения, затем файл конфигурации.
+type settings struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+}
+
+// defaultConfigPath возвращает ~/.config/prctl/config.yaml (с учётом XDG_CONFIG_HOME).
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "prctl", "config.yaml")
+}
+
+// loadSettings собирает настройки из файла, окружения и флагов.
+// Отсутствие файла по умолчанию не ошибка, явно указанного — ошибка.
+func loadSettings(flagServer, flagToken, flagConfig string, getenv func(string) string) (settings, error) {
+	var s settings
+
+	path, explicit := flagConfig, flagConfig != ""
+	if !explicit {
+		path = getenv(envConfig)
+		explicit = path != ""
+	}
+	if !explicit {
+		path = defaultConfigPath()
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, &s); err != nil {
+				return settings{}, fmt.Errorf("failed to parse config %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, fs.ErrNotExist):
+			return settings{}, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	if v := getenv(envServer); v != "" {
+		s.Server = v
+	}
+	if v := getenv(envToken); v != "" {
+		s.Token = v
+	}
+
+	if flagServer != "" {
+		s.Server = flagServer
+	}
+	if flagToken != "" {
+		s.Token = flagToken
+	}
+
+	if s.Server == "" {
+		s.Server = defaultServer
+	}
+	return s, nil
+}
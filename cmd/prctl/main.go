@@ -0,0 +1,162 @@
+// prctl — CLI для работы с PR Reviewer Service через HTTP API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/T1mof/pr-reviewer-service/pkg/client"
+)
+
+const usage = `Usage: prctl [global flags] <command> [flags] [args]
+
+Commands:
+  team create --name NAME --member USER_ID=USERNAME... [--inactive USER_ID=USERNAME...] | -f team.json
+  team get NAME
+  team rule NAME --level LEVEL --count N | --clear
+  user activate USER_ID
+  user deactivate USER_ID
+  user reviews USER_ID
+  user remove USER_ID
+  user seniority USER_ID junior|middle|senior
+  pr create --name NAME --author USER_ID [--id PR_ID]
+  pr merge PR_ID
+  pr reassign PR_ID OLD_REVIEWER_ID
+  stats
+
+Global flags:
+  --server URL     service URL (env PRCTL_SERVER, default http://localhost:8080)
+  --token TOKEN    admin token (env PRCTL_TOKEN)
+  --config PATH    config file (env PRCTL_CONFIG, default ~/.config/prctl/config.yaml)
+  -o, --output     table or json (default table)
+  --timeout        request timeout (default 30s)
+`
+
+// app общее состояние команд.
+type app struct {
+	client *client.Client
+	out    io.Writer
+	json   bool
+}
+
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]command{
+	"team create":     teamCreate,
+	"team get":        teamGet,
+	"team rule":       teamRule,
+	"user activate":   userSetActive(true),
+	"user deactivate": userSetActive(false),
+	"user reviews":    userReviews,
+	"user remove":     userRemove,
+	"user seniority":  userSeniority,
+	"pr create":       prCreate,
+	"pr merge":        prMerge,
+	"pr reassign":     prReassign,
+	"stats":           stats,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+// errUsage ошибка аргументов командной строки, код выхода 2.
+var errUsage = errors.New("usage error")
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	global := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() { fmt.Fprint(stderr, usage) }
+
+	server := global.String("server", "", "service URL")
+	token := global.String("token", "", "admin token")
+	configPath := global.String("config", "", "config file")
+	output := global.String("output", "table", "output format: table or json")
+	global.StringVar(output, "o", "table", "output format: table or json")
+	timeout := global.Duration("timeout", 30*time.Second, "request timeout")
+
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "Error: unknown output format %q\n", *output)
+		return 2
+	}
+
+	name, cmd, rest := lookupCommand(global.Args())
+	if cmd == nil {
+		global.Usage()
+		return 2
+	}
+
+	cfg, err := loadSettings(*server, *token, *configPath, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	c, err := client.New(cfg.Server,
+		client.WithAdminToken(cfg.Token),
+		client.WithUserAgent("prctl"),
+	)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	a := &app{client: c, out: stdout, json: *output == "json"}
+	if err := cmd(ctx, a, rest); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "Error: %v\n\nUsage: prctl %s\n", err, commandUsage(name))
+			return 2
+		}
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// lookupCommand находит команду из одного или двух слов.
+func lookupCommand(args []string) (string, command, []string) {
+	if len(args) >= 2 {
+		name := args[0] + " " + args[1]
+		if cmd, ok := commands[name]; ok {
+			return name, cmd, args[2:]
+		}
+	}
+	if len(args) >= 1 {
+		if cmd, ok := commands[args[0]]; ok {
+			return args[0], cmd, args[1:]
+		}
+	}
+	return "", nil, nil
+}
+
+// commandUsage возвращает строку usage для команды.
+func commandUsage(name string) string {
+	for _, line := range strings.Split(usage, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, name+" ") || line == name {
+			return line
+		}
+	}
+	return name
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/handler"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+func newTestServer(t *testing.T) string {
+	t.Helper()
+
+	svc := service.NewReviewerService(repository.NewMemoryRepository())
+	srv := httptest.NewServer(handler.NewHandler(svc, "test-token", handler.WithUserRemoval(svc), handler.WithSeniority(svc)).SetupRouter())
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// prctl запускает CLI с окружением env и возвращает код выхода, stdout и stderr.
+func prctl(t *testing.T, env map[string]string, args ...string) (int, string, string) {
+	t.Helper()
+
+	// Пустой конфиг вместо настоящего ~/.config/prctl/config.yaml.
+	emptyConfig := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(emptyConfig, nil, 0o600))
+
+	getenv := func(key string) string {
+		if key == envConfig && env[key] == "" {
+			return emptyConfig
+		}
+		return env[key]
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr, getenv)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestPrctl_Workflow(t *testing.T) {
+	env := map[string]string{envServer: newTestServer(t), envToken: "test-token"}
+	author, r1, r2 := uuid.New(), uuid.New(), uuid.New()
+
+	code, out, errOut := prctl(t, env, "team", "create", "--name", "backend",
+		"--member", author.String()+"=alice",
+		"--member", r1.String()+"=bob",
+		"--member", r2.String()+"=carol",
+	)
+	require.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, "Team: backend")
+	assert.Contains(t, out, "carol")
+
+	prID := uuid.New()
+	code, out, errOut = prctl(t, env, "-o", "json", "pr", "create", "--id", prID.String(), "--name", "Add feature", "--author", author.String())
+	require.Equal(t, 0, code, errOut)
+
+	var pr domain.PullRequestWithReviewers
+	require.NoError(t, json.Unmarshal([]byte(out), &pr))
+	assert.Equal(t, prID, pr.PullRequestID)
+	assert.ElementsMatch(t, []uuid.UUID{r1, r2}, pr.AssignedReviewers)
+
+	code, out, errOut = prctl(t, env, "user", "reviews", r1.String())
+	require.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, "Add feature")
+
+	code, _, errOut = prctl(t, env, "pr", "reassign", prID.String(), r1.String())
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errOut, "NO_CANDIDATE")
+
+	code, out, errOut = prctl(t, env, "user", "deactivate", r2.String(), "-o", "json")
+	require.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, `"is_active": false`)
+
+	code, out, errOut = prctl(t, env, "pr", "merge", prID.String())
+	require.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, "merged")
+
+	code, out, errOut = prctl(t, env, "stats")
+	require.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, "PRs: 1 total, 0 open, 1 merged")
+
+	code, out, errOut = prctl(t, env, "user", "remove", r2.String())
+	require.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, "0 open review(s) reassigned")
+
+	code, out, errOut = prctl(t, env, "team", "get", "backend")
+	require.Equal(t, 0, code, errOut)
+	assert.NotContains(t, out, "carol")
+}
+
+func TestPrctl_Seniority(t *testing.T) {
+	env := map[string]string{envServer: newTestServer(t), envToken: "test-token"}
+	author, r1, r2 := uuid.New(), uuid.New(), uuid.New()
+
+	code, _, errOut := prctl(t, env, "team", "create", "--name", "backend",
+		"--member", author.String()+"=alice",
+		"--member", r1.String()+"=bob",
+		"--member", r2.String()+"=carol",
+	)
+	require.Equal(t, 0, code, errOut)
+
+	code, out, errOut := prctl(t, env, "team", "rule", "backend", "--level", "senior")
+	require.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, "Seniority rule: at least 1 senior reviewer(s) per PR")
+
+	code, _, errOut = prctl(t, env, "pr", "create", "--name", "Add feature", "--author", author.String())
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errOut, "NO_SENIOR_CANDIDATE")
+
+	code, out, errOut = prctl(t, env, "user", "seniority", r1.String(), "senior")
+	require.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, "senior")
+
+	code, _, errOut = prctl(t, env, "pr", "create", "--name", "Add feature", "--author", author.String())
+	require.Equal(t, 0, code, errOut)
+
+	code, out, errOut = prctl(t, env, "team", "rule", "backend", "--clear")
+	require.Equal(t, 0, code, errOut)
+	assert.NotContains(t, out, "Seniority rule")
+
+	code, _, _ = prctl(t, env, "team", "rule", "backend")
+	assert.Equal(t, 2, code)
+}
+
+func TestPrctl_AdminTokenFromFlag(t *testing.T) {
+	server := newTestServer(t)
+	env := map[string]string{envServer: server, envToken: "wrong"}
+	userID := uuid.New()
+
+	code, _, errOut := prctl(t, env, "team", "create", "--name", "ops", "--member", userID.String()+"=dave")
+	require.Equal(t, 0, code, errOut)
+
+	code, _, errOut = prctl(t, env, "user", "deactivate", userID.String())
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errOut, "UNAUTHORIZED")
+
+	code, _, errOut = prctl(t, env, "--token", "test-token", "user", "deactivate", userID.String())
+	assert.Equal(t, 0, code, errOut)
+}
+
+func TestPrctl_UsageErrors(t *testing.T) {
+	env := map[string]string{envServer: "http://127.0.0.1:0"}
+
+	code, _, errOut := prctl(t, env, "unknown")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, errOut, "Usage: prctl")
+
+	code, _, errOut = prctl(t, env, "pr", "merge")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, errOut, "Usage: prctl pr merge PR_ID")
+
+	code, _, errOut = prctl(t, env, "pr", "merge", "not-a-uuid")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, errOut, "invalid PR id")
+}
+
+func TestUsageCoversAllCommands(t *testing.T) {
+	for name := range commands {
+		assert.True(t, strings.HasPrefix(commandUsage(name), name+" ") || name == "stats", "no usage line for %q", name)
+	}
+}
+
+func TestLoadSettings_Precedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server: http://from-file:8080\ntoken: file-token\n"), 0o600))
+
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	s, err := loadSettings("", "", path, getenv)
+	require.NoError(t, err)
+	assert.Equal(t, settings{Server: "http://from-file:8080", Token: "file-token"}, s)
+
+	env[envToken] = "env-token"
+	s, err = loadSettings("", "", path, getenv)
+	require.NoError(t, err)
+	assert.Equal(t, settings{Server: "http://from-file:8080", Token: "env-token"}, s)
+
+	s, err = loadSettings("http://from-flag:8080", "flag-token", path, getenv)
+	require.NoError(t, err)
+	assert.Equal(t, settings{Server: "http://from-flag:8080", Token: "flag-token"}, s)
+
+	_, err = loadSettings("", "", filepath.Join(t.TempDir(), "missing.yaml"), getenv)
+	assert.Error(t, err, "explicit config must exist")
+}
@@ -0,0 +1,235 @@
+package chatops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Absences хранит, до какого момента пользователь отсутствует. По
+// наступлении момента ReturnAbsent снова делает его активным. Get, Set и
+// Delete работают в организации из ctx.
+type Absences interface {
+	// Get возвращает время возвращения пользователя; false, если он не отсутствует.
+	Get(ctx context.Context, userID uuid.UUID) (time.Time, bool, error)
+	// Set записывает или переносит возвращение пользователя.
+	Set(ctx context.Context, userID uuid.UUID, until time.Time) error
+	// Delete удаляет запись, если она есть.
+	Delete(ctx context.Context, userID uuid.UUID) error
+	// Due возвращает отсутствия всех организаций, время возвращения
+	// которых наступило к now.
+	Due(ctx context.Context, now time.Time) ([]Absence, error)
+}
+
+// Absence пользователь организации, которого пора вернуть.
+type Absence struct {
+	OrgID  uuid.UUID
+	UserID uuid.UUID
+}
+
+// MemoryAbsences отсутствия в памяти процесса.
+type MemoryAbsences struct {
+	mu    sync.Mutex
+	until map[Absence]time.Time
+}
+
+func NewMemoryAbsences() *MemoryAbsences {
+	return &MemoryAbsences{until: make(map[Absence]time.Time)}
+}
+
+// Get реализует Absences.
+func (a *MemoryAbsences) Get(ctx context.Context, userID uuid.UUID) (time.Time, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	until, ok := a.until[Absence{OrgID: tenant.OrgID(ctx), UserID: userID}]
+	return until, ok, nil
+}
+
+// Set реализует Absences.
+func (a *MemoryAbsences) Set(ctx context.Context, userID uuid.UUID, until time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.until[Absence{OrgID: tenant.OrgID(ctx), UserID: userID}] = until
+	return nil
+}
+
+// Delete реализует Absences.
+func (a *MemoryAbsences) Delete(ctx context.Context, userID uuid.UUID) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.until, Absence{OrgID: tenant.OrgID(ctx), UserID: userID})
+	return nil
+}
+
+// Due реализует Absences.
+func (a *MemoryAbsences) Due(_ context.Context, now time.Time) ([]Absence, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var due []Absence
+	for absence, until := range a.until {
+		if !until.After(now) {
+			due = append(due, absence)
+		}
+	}
+	return due, nil
+}
+
+// SQLAbsences отсутствия в таблице user_absences (PostgreSQL или SQLite).
+type SQLAbsences struct {
+	db *sql.DB
+}
+
+func NewSQLAbsences(db *sql.DB) *SQLAbsences {
+	return &SQLAbsences{db: db}
+}
+
+// Get реализует Absences.
+func (a *SQLAbsences) Get(ctx context.Context, userID uuid.UUID) (time.Time, bool, error) {
+	var until time.Time
+	err := a.db.QueryRowContext(ctx, `
+		SELECT away_until FROM user_absences WHERE user_id = $1 AND org_id = $2
+	`, userID.String(), tenant.OrgID(ctx).String()).Scan(&until)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, fmt.Errorf("failed to get absence: %w", err)
+	}
+	return until, true, nil
+}
+
+// Set реализует Absences.
+func (a *SQLAbsences) Set(ctx context.Context, userID uuid.UUID, until time.Time) error {
+	_, err := a.db.ExecContext(ctx, `
+		INSERT INTO user_absences (user_id, away_until, org_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET away_until = excluded.away_until
+	`, userID.String(), until.UTC(), tenant.OrgID(ctx).String())
+	if err != nil {
+		return fmt.Errorf("failed to save absence: %w", err)
+	}
+	return nil
+}
+
+// Delete реализует Absences.
+func (a *SQLAbsences) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := a.db.ExecContext(ctx, `
+		DELETE FROM user_absences WHERE user_id = $1 AND org_id = $2
+	`, userID.String(), tenant.OrgID(ctx).String())
+	if err != nil {
+		return fmt.Errorf("failed to delete absence: %w", err)
+	}
+	return nil
+}
+
+// Due реализует Absences.
+func (a *SQLAbsences) Due(ctx context.Context, now time.Time) ([]Absence, error) {
+	rows, err := a.db.QueryContext(ctx, `SELECT org_id, user_id FROM user_absences WHERE away_until <= $1`, now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due absences: %w", err)
+	}
+	defer rows.Close()
+
+	var due []Absence
+	for rows.Next() {
+		var absence Absence
+		if err := rows.Scan(&absence.OrgID, &absence.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan absence: %w", err)
+		}
+		due = append(due, absence)
+	}
+	return due, rows.Err()
+}
+
+// ReturnAbsent раз в interval делает активными пользователей, время
+// возвращения которых наступило, пока не отменён ctx.
+func ReturnAbsent(ctx context.Context, absences Absences, svc service.ServiceInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		returnDue(ctx, absences, svc, time.Now())
+	}
+}
+
+func returnDue(ctx context.Context, absences Absences, svc service.ServiceInterface, now time.Time) {
+	due, err := absences.Due(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get due absences", "error", err)
+		return
+	}
+
+	for _, absence := range due {
+		orgCtx := tenant.WithOrg(ctx, absence.OrgID)
+		userID := absence.UserID
+		if _, err := svc.SetUserActive(orgCtx, userID, true); err != nil && errorCode(err) != "USER_NOT_FOUND" {
+			// Запись остаётся, попытка повторится в следующий раз.
+			slog.ErrorContext(orgCtx, "Failed to return user from absence", "user_id", userID, "error", err)
+			continue
+		}
+		if err := absences.Delete(orgCtx, userID); err != nil {
+			slog.ErrorContext(orgCtx, "Failed to delete absence", "user_id", userID, "error", err)
+			continue
+		}
+		slog.InfoContext(orgCtx, "User returned from absence", "user_id", userID)
+	}
+}
+
+// weekdays имена дней недели для "/review away until friday".
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+}
+
+// parseUntil возвращает начало дня, с которого пользователь снова
+// получает ревью: "tomorrow", день недели (ближайший после сегодняшнего)
+// или дата YYYY-MM-DD в будущем.
+func parseUntil(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if s == "tomorrow" {
+		return today.AddDate(0, 0, 1), nil
+	}
+	if day, ok := weekdays[s]; ok {
+		days := (int(day) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unknown day %q, use tomorrow, a weekday or YYYY-MM-DD", s)
+	}
+	if !date.After(today) {
+		return time.Time{}, fmt.Errorf("%s is not in the future", s)
+	}
+	return date, nil
+}
@@ -0,0 +1,133 @@
+package chatops
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+func TestParseUntil(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		in   string
+		want time.Time
+		err  string
+	}{
+		{in: "tomorrow", want: day(22)},
+		{in: "Friday", want: day(23)},
+		{in: "mon", want: day(26)},
+		// Сегодня среда: "until wednesday" — через неделю.
+		{in: "wednesday", want: day(28)},
+		{in: "2026-10-30", want: day(30)},
+		{in: "2026-10-21", err: "2026-10-21 is not in the future"},
+		{in: "next week", err: `unknown day "next week", use tomorrow, a weekday or YYYY-MM-DD`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseUntil(tt.in, now)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAbsences(t *testing.T) {
+	stores := map[string]func(t *testing.T) Absences{
+		"memory": func(*testing.T) Absences {
+			return NewMemoryAbsences()
+		},
+		"sqlite": func(t *testing.T) Absences {
+			cfg := &config.Config{DatabaseURL: "sqlite://" + filepath.Join(t.TempDir(), "test.db")}
+
+			db, err := cfg.ConnectDB()
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+
+			require.NoError(t, cfg.RunMigrations(db.DB))
+			return NewSQLAbsences(db.DB)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			a := newStore(t)
+			first, second := uuid.New(), uuid.New()
+
+			require.NoError(t, a.Set(ctx, first, now.Add(time.Hour)))
+			require.NoError(t, a.Set(ctx, second, now.Add(48*time.Hour)))
+
+			due, err := a.Due(ctx, now)
+			require.NoError(t, err)
+			assert.Empty(t, due)
+
+			due, err = a.Due(ctx, now.Add(time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, []Absence{{OrgID: tenant.DefaultOrgID, UserID: first}}, due)
+
+			// Перенос возвращения.
+			require.NoError(t, a.Set(ctx, first, now.Add(72*time.Hour)))
+			due, err = a.Due(ctx, now.Add(49*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, []Absence{{OrgID: tenant.DefaultOrgID, UserID: second}}, due)
+
+			until, ok, err := a.Get(ctx, first)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.True(t, until.Equal(now.Add(72*time.Hour)), until)
+
+			require.NoError(t, a.Delete(ctx, second))
+			_, ok, err = a.Get(ctx, second)
+			require.NoError(t, err)
+			assert.False(t, ok)
+			due, err = a.Due(ctx, now.Add(100*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, []Absence{{OrgID: tenant.DefaultOrgID, UserID: first}}, due)
+
+			// Тот же ID в другой организации — другой пользователь.
+			other := tenant.WithOrg(ctx, uuid.New())
+			_, ok, err = a.Get(other, first)
+			require.NoError(t, err)
+			assert.False(t, ok)
+			require.NoError(t, a.Set(other, first, now.Add(time.Hour)))
+			require.NoError(t, a.Delete(other, first))
+			_, ok, err = a.Get(ctx, first)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestReturnDue(t *testing.T) {
+	ctx := context.Background()
+	s, repo, absences := newTestSlack(t)
+
+	run(s, "UALICE", "away until tomorrow")
+	run(s, "UBOB", "away until friday")
+
+	returnDue(ctx, absences, s.service, now.Add(24*time.Hour))
+
+	user, err := repo.GetUserByID(ctx, alice)
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+	user, err = repo.GetUserByID(ctx, bob)
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	assert.NotContains(t, absences.until, Absence{OrgID: tenant.DefaultOrgID, UserID: alice})
+	assert.Contains(t, absences.until, Absence{OrgID: tenant.DefaultOrgID, UserID: bob})
+}
@@ -0,0 +1,222 @@
+package chatops
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+)
+
+// call выполнение одной команды от имени пользователя.
+type call struct {
+	slack   *Slack
+	ctx     context.Context
+	user    uuid.UUID
+	slackID string
+	command string
+}
+
+// mine: "/review mine" — открытые ревью пользователя.
+func (c *call) mine(args []string) *Response {
+	if len(args) != 0 {
+		return c.usageError()
+	}
+
+	reviews, err := c.slack.service.GetUserReviews(c.ctx, c.user)
+	if err != nil {
+		return c.fail("mine", err)
+	}
+
+	var lines []string
+	for _, pr := range reviews {
+		if pr.Status != domain.StatusOpen {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("• *%s* by %s — `%s`", escape(pr.PullRequestName), c.slack.mention(pr.AuthorID), pr.PullRequestID))
+	}
+	if len(lines) == 0 {
+		return ephemeral("You have no open reviews.")
+	}
+	return ephemeral(fmt.Sprintf("*Your open reviews (%d):*\n%s", len(lines), strings.Join(lines, "\n")))
+}
+
+// slackMention упоминание пользователя в тексте команды: <@U012AB3CD>
+// или <@U012AB3CD|name>.
+var slackMention = regexp.MustCompile(`^<@([A-Z0-9]+)(\|[^>]*)?>$`)
+
+// reassign: "/review reassign <pr> me" — передать своё ревью; вместо me
+// можно упомянуть другого ревьювера.
+func (c *call) reassign(args []string) *Response {
+	if len(args) != 2 {
+		return c.usageError()
+	}
+
+	prID, err := uuid.Parse(args[0])
+	if err != nil {
+		return ephemeral(fmt.Sprintf("`%s` is not a pull request ID.", escape(args[0])))
+	}
+
+	oldUser := c.user
+	if !strings.EqualFold(args[1], "me") {
+		m := slackMention.FindStringSubmatch(args[1])
+		if m == nil {
+			return c.usageError()
+		}
+		var ok bool
+		if oldUser, ok = c.slack.accounts.UserID(m[1]); !ok {
+			return ephemeral(fmt.Sprintf("<@%s> is not linked to the reviewer service.", m[1]))
+		}
+	}
+
+	pr, newUser, err := c.slack.service.ReassignReviewer(c.ctx, prID, oldUser)
+	if err != nil {
+		return c.fail("reassign", err)
+	}
+
+	if oldUser == c.user {
+		return &Response{ResponseType: ResponseInChannel, Text: fmt.Sprintf("<@%s> handed the review of *%s* over to %s.",
+			c.slackID, escape(pr.PullRequestName), c.slack.mention(newUser))}
+	}
+	return &Response{ResponseType: ResponseInChannel, Text: fmt.Sprintf("<@%s> reassigned the review of *%s* from %s to %s.",
+		c.slackID, escape(pr.PullRequestName), c.slack.mention(oldUser), c.slack.mention(newUser))}
+}
+
+// away: "/review away [until <день>]" — перестать получать новые ревью.
+// С until пользователь снова станет активным в начале указанного дня.
+func (c *call) away(args []string) *Response {
+	if len(args) == 1 || (len(args) > 1 && !strings.EqualFold(args[0], "until")) {
+		return c.usageError()
+	}
+
+	if len(args) == 0 {
+		if _, err := c.slack.service.SetUserActive(c.ctx, c.user, false); err != nil {
+			return c.fail("away", err)
+		}
+		if err := c.slack.absences.Delete(c.ctx, c.user); err != nil {
+			return c.fail("away", err)
+		}
+		return ephemeral(fmt.Sprintf("You are away. New reviews won't be assigned to you until you run `%s back`.", c.commandName()))
+	}
+
+	until, err := parseUntil(strings.Join(args[1:], " "), c.slack.now())
+	if err != nil {
+		return ephemeral(err.Error() + ".")
+	}
+
+	if _, err := c.slack.service.SetUserActive(c.ctx, c.user, false); err != nil {
+		return c.fail("away", err)
+	}
+	if err := c.slack.absences.Set(c.ctx, c.user, until); err != nil {
+		return c.fail("away", err)
+	}
+	return ephemeral(fmt.Sprintf("You are away until *%s*. New reviews won't be assigned to you; use `%s reassign` to hand over open ones.",
+		until.Format("Monday, Jan 2"), c.commandName()))
+}
+
+// back: "/review back" — снова получать ревью.
+func (c *call) back(args []string) *Response {
+	if len(args) != 0 {
+		return c.usageError()
+	}
+
+	if _, err := c.slack.service.SetUserActive(c.ctx, c.user, true); err != nil {
+		return c.fail("back", err)
+	}
+	if err := c.slack.absences.Delete(c.ctx, c.user); err != nil {
+		return c.fail("back", err)
+	}
+	return ephemeral("Welcome back! You will receive new reviews again.")
+}
+
+// stats: "/review stats <team>" — нагрузка участников команды.
+func (c *call) stats(args []string) *Response {
+	if len(args) != 1 {
+		return c.usageError()
+	}
+
+	team, err := c.slack.service.GetTeam(c.ctx, args[0])
+	if err != nil {
+		return c.fail("stats", err)
+	}
+	stats, err := c.slack.service.GetStatistics(c.ctx)
+	if err != nil {
+		return c.fail("stats", err)
+	}
+
+	byUser := make(map[uuid.UUID]domain.UserAssignmentStats, len(stats.UserStats))
+	for _, s := range stats.UserStats {
+		byUser[s.UserID] = s
+	}
+
+	// Состав может быть из кэша, сортируется копия.
+	members := slices.Clone(team.Members)
+	sort.SliceStable(members, func(i, j int) bool {
+		oi, oj := byUser[members[i].UserID].OpenAssignments, byUser[members[j].UserID].OpenAssignments
+		if oi != oj {
+			return oi > oj
+		}
+		return members[i].Username < members[j].Username
+	})
+
+	active, open := 0, 0
+	lines := make([]string, 0, len(members))
+	for _, m := range members {
+		s := byUser[m.UserID]
+		status := ""
+		if m.IsActive {
+			active++
+		} else {
+			status = " _(away)_"
+		}
+		open += s.OpenAssignments
+		lines = append(lines, fmt.Sprintf("• %s%s: %d open, %d total", escape(m.Username), status, s.OpenAssignments, s.TotalAssignments))
+	}
+
+	return ephemeral(fmt.Sprintf("*Team %s*: %d members, %d active, %d open reviews\n%s",
+		escape(team.TeamName), len(members), active, open, strings.Join(lines, "\n")))
+}
+
+func (c *call) commandName() string {
+	if c.command == "" {
+		return "/review"
+	}
+	return c.command
+}
+
+func (c *call) usageError() *Response {
+	return ephemeral("Invalid arguments.\n" + usage(c.command))
+}
+
+// errorMessages тексты ответов на ошибки сервиса.
+var errorMessages = map[string]string{
+	"NO_CANDIDATE":        "No active teammate is available to take over this review.",
+	"NO_SENIOR_CANDIDATE": "No active teammate of the seniority required by the team is available to take over this review.",
+	"NOT_ASSIGNED":        "This reviewer is not assigned to the pull request.",
+	"PR_MERGED":           "The pull request is already merged.",
+	"PR_NOT_FOUND":        "Pull request not found.",
+	"USER_NOT_FOUND":      "The user is not registered in the reviewer service.",
+	"TEAM_NOT_FOUND":      "Team not found.",
+}
+
+// fail переводит ошибку сервиса в ephemeral ответ. Неизвестные ошибки
+// пишутся в лог, пользователю показывается общее сообщение.
+func (c *call) fail(command string, err error) *Response {
+	if msg, ok := errorMessages[errorCode(err)]; ok {
+		return ephemeral(msg)
+	}
+
+	slog.ErrorContext(c.ctx, "Chat command failed", "command", command, "user_id", c.user, "error", err)
+	return ephemeral("Something went wrong, please try again later.")
+}
+
+// escape экранирует управляющие символы разметки Slack.
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,173 @@
+// Package chatops обрабатывает slash-команды чата (/review в Slack):
+// просмотр своих ревью, передачу ревью, отсутствие и статистику команды.
+package chatops
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Типы ответа Slack: ephemeral видит только автор команды, in_channel —
+// весь канал.
+const (
+	ResponseEphemeral = "ephemeral"
+	ResponseInChannel = "in_channel"
+)
+
+// Command slash-команда из формы запроса Slack.
+type Command struct {
+	// UserID ID пользователя Slack (U012AB3CD).
+	UserID string
+	// Command имя команды, например "/review".
+	Command string
+	// Text аргументы после имени команды.
+	Text string
+}
+
+// Response ответ на команду в формате Slack, текст в разметке mrkdwn.
+type Response struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// maxClockSkew допустимое расхождение X-Slack-Request-Timestamp с текущим
+// временем: более старые запросы отклоняются как возможный повтор.
+const maxClockSkew = 5 * time.Minute
+
+// Slack выполняет slash-команды Slack через методы сервиса.
+type Slack struct {
+	service  service.ServiceInterface
+	secret   []byte
+	accounts Accounts
+	absences Absences
+	now      func() time.Time
+}
+
+// Accounts сопоставление ID пользователей Slack (без учёта регистра)
+// пользователям сервиса; его реализует webhook.Accounts.
+type Accounts interface {
+	UserID(slackID string) (uuid.UUID, bool)
+	Login(userID uuid.UUID) (string, bool)
+}
+
+// NewSlack создаёт обработчик.
+func NewSlack(svc service.ServiceInterface, signingSecret string, accounts Accounts, absences Absences) *Slack {
+	return &Slack{
+		service:  svc,
+		secret:   []byte(signingSecret),
+		accounts: accounts,
+		absences: absences,
+		now:      time.Now,
+	}
+}
+
+// VerifySignature проверяет X-Slack-Signature — HMAC-SHA256 строки
+// "v0:<timestamp>:<тело>" с signing secret приложения — и свежесть
+// X-Slack-Request-Timestamp.
+func (s *Slack) VerifySignature(timestamp, signature string, body []byte) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("INVALID_SIGNATURE")
+	}
+	if skew := s.now().Sub(time.Unix(ts, 0)); skew > maxClockSkew || skew < -maxClockSkew {
+		return errors.New("INVALID_SIGNATURE")
+	}
+
+	sig, ok := strings.CutPrefix(signature, "v0=")
+	if !ok {
+		return errors.New("INVALID_SIGNATURE")
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return errors.New("INVALID_SIGNATURE")
+	}
+
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("INVALID_SIGNATURE")
+	}
+	return nil
+}
+
+// Handle выполняет команду с проверенной подписью. Ошибки возвращаются
+// ответом ephemeral, чтобы их видел только автор команды.
+func (s *Slack) Handle(ctx context.Context, cmd Command) *Response {
+	// Рабочее пространство Slack не связано с организацией: команды
+	// работают с организацией по умолчанию.
+	ctx = tenant.WithDefault(ctx)
+
+	args := strings.Fields(cmd.Text)
+	if len(args) == 0 || strings.EqualFold(args[0], "help") {
+		return ephemeral(usage(cmd.Command))
+	}
+
+	userID, ok := s.accounts.UserID(cmd.UserID)
+	if !ok {
+		return ephemeral("Your Slack account is not linked to the reviewer service. Ask an administrator to add it to the mapping file.")
+	}
+
+	c := &call{slack: s, ctx: ctx, user: userID, slackID: cmd.UserID, command: cmd.Command}
+	switch name, args := strings.ToLower(args[0]), args[1:]; name {
+	case "mine":
+		return c.mine(args)
+	case "reassign":
+		return c.reassign(args)
+	case "away":
+		return c.away(args)
+	case "back":
+		return c.back(args)
+	case "stats":
+		return c.stats(args)
+	default:
+		return ephemeral("Unknown command `" + name + "`.\n" + usage(cmd.Command))
+	}
+}
+
+func usage(command string) string {
+	if command == "" {
+		command = "/review"
+	}
+	return strings.Join([]string{
+		"*Usage:*",
+		"`" + command + " mine` — your open reviews",
+		"`" + command + " reassign <pull_request_id> me` — hand your review over to a teammate",
+		"`" + command + " away [until <tomorrow|weekday|YYYY-MM-DD>]` — stop receiving new reviews",
+		"`" + command + " back` — receive reviews again",
+		"`" + command + " stats <team>` — review load of a team",
+	}, "\n")
+}
+
+func ephemeral(text string) *Response {
+	return &Response{ResponseType: ResponseEphemeral, Text: text}
+}
+
+// mention упоминание пользователя: Slack, если он есть в сопоставлении,
+// иначе user_id.
+func (s *Slack) mention(userID uuid.UUID) string {
+	if slackID, ok := s.accounts.Login(userID); ok {
+		return "<@" + strings.ToUpper(slackID) + ">"
+	}
+	return "`" + userID.String() + "`"
+}
+
+// errorCode возвращает код ошибки сервиса ("NO_CANDIDATE"), в том числе
+// обёрнутой через fmt.Errorf.
+func errorCode(err error) string {
+	for errors.Unwrap(err) != nil {
+		err = errors.Unwrap(err)
+	}
+	return err.Error()
+}
@@ -0,0 +1,194 @@
+package chatops
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
+)
+
+var (
+	alice = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	bob   = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	carol = uuid.MustParse("550e8400-e29b-41d4-a716-446655440003")
+)
+
+// now среда, 21 октября 2026.
+var now = time.Date(2026, 10, 21, 15, 30, 0, 0, time.UTC)
+
+func newTestSlack(t *testing.T) (*Slack, repository.RepositoryInterface, *MemoryAbsences) {
+	t.Helper()
+
+	repo := repository.NewMemoryRepository()
+	svc := service.NewReviewerService(repo)
+	require.NoError(t, svc.CreateTeam(context.Background(), &domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: alice, Username: "alice", IsActive: true},
+			{UserID: bob, Username: "bob", IsActive: true},
+			{UserID: carol, Username: "carol", IsActive: true},
+		},
+	}))
+
+	absences := NewMemoryAbsences()
+	mapping := &webhook.Mapping{Slack: webhook.SlackMapping{Users: webhook.Users{"ualice": alice, "ubob": bob}}}
+	s := NewSlack(svc, "secret", mapping.Accounts(webhook.ProviderSlack), absences)
+	s.now = func() time.Time { return now }
+	return s, repo, absences
+}
+
+func run(s *Slack, slackID, text string) *Response {
+	return s.Handle(context.Background(), Command{UserID: slackID, Command: "/review", Text: text})
+}
+
+func TestSlack_VerifySignature(t *testing.T) {
+	s, _, _ := newTestSlack(t)
+	body := []byte("command=%2Freview&text=mine&user_id=UALICE")
+	sign := func(secret, timestamp string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte("v0:" + timestamp + ":"))
+		mac.Write(body)
+		return "v0=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	ts := strconv.FormatInt(now.Unix(), 10)
+	old := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+
+	assert.NoError(t, s.VerifySignature(ts, sign("secret", ts), body))
+	assert.EqualError(t, s.VerifySignature(ts, sign("other", ts), body), "INVALID_SIGNATURE")
+	assert.EqualError(t, s.VerifySignature(old, sign("secret", old), body), "INVALID_SIGNATURE", "replayed request")
+	assert.EqualError(t, s.VerifySignature("", sign("secret", ""), body), "INVALID_SIGNATURE")
+	assert.EqualError(t, s.VerifySignature(ts, "sha256=00", body), "INVALID_SIGNATURE")
+	assert.EqualError(t, s.VerifySignature(ts, sign("secret", ts), append(body, '&')), "INVALID_SIGNATURE")
+}
+
+func TestSlack_Mine(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestSlack(t)
+
+	assert.Equal(t, &Response{ResponseType: ResponseEphemeral, Text: "You have no open reviews."}, run(s, "UBOB", "mine"))
+
+	prID := uuid.New()
+	_, err := s.service.CreatePR(ctx, prID, "Fix <script> & co", carol)
+	require.NoError(t, err)
+
+	resp := run(s, "UBOB", "mine")
+	assert.Equal(t, ResponseEphemeral, resp.ResponseType)
+	assert.Equal(t, "*Your open reviews (1):*\n• *Fix &lt;script&gt; &amp; co* by `"+carol.String()+"` — `"+prID.String()+"`", resp.Text)
+}
+
+func TestSlack_Reassign(t *testing.T) {
+	ctx := context.Background()
+	s, repo, _ := newTestSlack(t)
+
+	// Автор carol, ревьюверы alice и bob: заменить некем.
+	prID := uuid.New()
+	_, err := s.service.CreatePR(ctx, prID, "Feature", carol)
+	require.NoError(t, err)
+
+	resp := run(s, "UALICE", "reassign "+prID.String()+" me")
+	assert.Equal(t, ephemeral("No active teammate is available to take over this review."), resp)
+
+	dave := uuid.New()
+	require.NoError(t, repo.UpsertUser(ctx, &domain.User{UserID: dave, Username: "dave", TeamName: "backend", IsActive: true}))
+
+	resp = run(s, "UALICE", "reassign "+prID.String()+" me")
+	assert.Equal(t, ResponseInChannel, resp.ResponseType)
+	assert.Equal(t, "<@UALICE> handed the review of *Feature* over to `"+dave.String()+"`.", resp.Text)
+
+	// alice больше не ревьювер.
+	resp = run(s, "UALICE", "reassign "+prID.String()+" me")
+	assert.Equal(t, ephemeral("This reviewer is not assigned to the pull request."), resp)
+
+	// Упоминание другого ревьювера: освободившаяся alice снова кандидат.
+	resp = run(s, "UALICE", "reassign "+prID.String()+" <@UBOB|bob>")
+	assert.Equal(t, &Response{ResponseType: ResponseInChannel,
+		Text: "<@UALICE> reassigned the review of *Feature* from <@UBOB> to <@UALICE>."}, resp)
+
+	_, err = s.service.MergePR(ctx, prID)
+	require.NoError(t, err)
+	resp = run(s, "UBOB", "reassign "+prID.String()+" me")
+	assert.Equal(t, ephemeral("The pull request is already merged."), resp)
+
+	resp = run(s, "UBOB", "reassign "+uuid.NewString()+" me")
+	assert.Equal(t, ephemeral("Pull request not found."), resp)
+
+	resp = run(s, "UBOB", "reassign 42 me")
+	assert.Equal(t, ephemeral("`42` is not a pull request ID."), resp)
+
+	resp = run(s, "UBOB", "reassign "+prID.String()+" <@UNKNOWN>")
+	assert.Equal(t, ephemeral("<@UNKNOWN> is not linked to the reviewer service."), resp)
+}
+
+func TestSlack_AwayAndBack(t *testing.T) {
+	ctx := context.Background()
+	s, repo, absences := newTestSlack(t)
+
+	resp := run(s, "UALICE", "away until friday")
+	assert.Equal(t, ephemeral("You are away until *Friday, Oct 23*. New reviews won't be assigned to you; use `/review reassign` to hand over open ones."), resp)
+
+	user, err := repo.GetUserByID(ctx, alice)
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+	assert.Equal(t, time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC), absences.until[Absence{OrgID: tenant.DefaultOrgID, UserID: alice}])
+
+	resp = run(s, "UALICE", "back")
+	assert.Equal(t, ephemeral("Welcome back! You will receive new reviews again."), resp)
+	user, err = repo.GetUserByID(ctx, alice)
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+	assert.Empty(t, absences.until)
+
+	resp = run(s, "UALICE", "away")
+	assert.Equal(t, ephemeral("You are away. New reviews won't be assigned to you until you run `/review back`."), resp)
+	assert.Empty(t, absences.until)
+
+	resp = run(s, "UALICE", "away until someday")
+	assert.Equal(t, ephemeral(`unknown day "someday", use tomorrow, a weekday or YYYY-MM-DD.`), resp)
+
+	resp = run(s, "UALICE", "away friday")
+	assert.Contains(t, resp.Text, "Invalid arguments.")
+}
+
+func TestSlack_Stats(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestSlack(t)
+
+	_, err := s.service.CreatePR(ctx, uuid.New(), "Feature", carol)
+	require.NoError(t, err)
+	_, err = s.service.SetUserActive(ctx, carol, false)
+	require.NoError(t, err)
+
+	resp := run(s, "UALICE", "stats backend")
+	assert.Equal(t, ephemeral("*Team backend*: 3 members, 2 active, 2 open reviews\n"+
+		"• alice: 1 open, 1 total\n"+
+		"• bob: 1 open, 1 total\n"+
+		"• carol _(away)_: 0 open, 0 total"), resp)
+
+	resp = run(s, "UALICE", "stats frontend")
+	assert.Equal(t, ephemeral("Team not found."), resp)
+}
+
+func TestSlack_UsageAndUnknownUsers(t *testing.T) {
+	s, _, _ := newTestSlack(t)
+
+	assert.Contains(t, run(s, "UALICE", "").Text, "*Usage:*")
+	assert.Contains(t, run(s, "UNOBODY", "help").Text, "*Usage:*")
+	assert.Contains(t, run(s, "UALICE", "dance").Text, "Unknown command `dance`.")
+	assert.Contains(t, run(s, "UALICE", "mine please").Text, "Invalid arguments.")
+	assert.Equal(t,
+		ephemeral("Your Slack account is not linked to the reviewer service. Ask an administrator to add it to the mapping file."),
+		run(s, "UNOBODY", "mine"))
+}
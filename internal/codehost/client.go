@@ -0,0 +1,106 @@
+package codehost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Client API хостинга для запроса ревьюверов.
+type Client interface {
+	// UpdateReviewers запрашивает ревью у add и снимает запрос с remove
+	// (логины на хостинге). Повторный вызов с теми же аргументами ничего
+	// не меняет, поэтому неудавшийся вызов можно повторять целиком.
+	UpdateReviewers(ctx context.Context, link Link, add, remove []string) error
+}
+
+// APIError ответ хостинга с кодом не 2xx.
+type APIError struct {
+	Method string
+	URL    string
+	Status int
+	Body   string
+	// RetryAfter значение заголовка Retry-After, 0 если его нет.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: status %d: %s", e.Method, e.URL, e.Status, e.Body)
+}
+
+// Temporary сообщает, имеет ли смысл повторить запрос: перегрузка
+// и ошибки сервера проходят сами, остальные ответы — нет.
+func (e *APIError) Temporary() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+// retryable сообщает, можно ли повторить вызов с ошибкой err. Сетевые
+// ошибки повторяются, ответы хостинга — если временные.
+func retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	return !errors.Is(err, errPermanent)
+}
+
+// errPermanent ошибка, которую повтор не исправит (нет токена, нет
+// пользователя на хостинге).
+var errPermanent = errors.New("permanent error")
+
+// maxErrorBody сколько байт тела ответа с ошибкой попадает в APIError.
+const maxErrorBody = 512
+
+// doJSON выполняет запрос с телом in (если не nil) и разбирает ответ в out
+// (если не nil).
+func doJSON(ctx context.Context, client *http.Client, method, url string, header http.Header, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	for key, values := range header {
+		req.Header[http.CanonicalHeaderKey(key)] = values
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		apiErr := &APIError{Method: method, URL: url, Status: resp.StatusCode, Body: string(data)}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			apiErr.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: failed to decode response: %w", method, url, err)
+	}
+	return nil
+}
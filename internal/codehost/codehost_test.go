@@ -0,0 +1,103 @@
+package codehost
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+)
+
+func TestLinks(t *testing.T) {
+	stores := map[string]func(t *testing.T) Links{
+		"memory": func(*testing.T) Links {
+			return NewMemoryLinks()
+		},
+		"sqlite": func(t *testing.T) Links {
+			cfg := &config.Config{DatabaseURL: "sqlite://" + filepath.Join(t.TempDir(), "test.db")}
+
+			db, err := cfg.ConnectDB()
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+
+			require.NoError(t, cfg.RunMigrations(db.DB))
+			return NewSQLLinks(db.DB)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			links := newStore(t)
+			prID := uuid.New()
+
+			_, err := links.Get(ctx, prID)
+			assert.EqualError(t, err, "LINK_NOT_FOUND")
+
+			require.NoError(t, links.Save(ctx, prID, Link{Provider: "github", Repository: "octocat/Hello-World", Number: 1347}))
+			link, err := links.Get(ctx, prID)
+			require.NoError(t, err)
+			assert.Equal(t, Link{Provider: "github", Repository: "octocat/Hello-World", Number: 1347}, *link)
+
+			// Переименование репозитория обновляет связь.
+			require.NoError(t, links.Save(ctx, prID, Link{Provider: "github", Repository: "octocat/hello", Number: 1347}))
+			link, err = links.Get(ctx, prID)
+			require.NoError(t, err)
+			assert.Equal(t, "octocat/hello", link.Repository)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "codehost.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	t.Setenv("TEST_GITHUB_TOKEN", "gh-default")
+	t.Setenv("TEST_GITLAB_TOKEN", "gl-billing")
+
+	cfg, err := LoadConfig(write(`
+github:
+  token: ${TEST_GITHUB_TOKEN}
+  repositories:
+    Octo-Org/Payments: literal-token
+gitlab:
+  api_url: https://gitlab.example.com/api/v4
+  projects:
+    platform/billing: ${TEST_GITLAB_TOKEN}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.github.com", cfg.GitHub.APIURL)
+	assert.Equal(t, "https://gitlab.example.com/api/v4", cfg.GitLab.APIURL)
+
+	tokens := NewTokens(cfg.GitHub.Token, cfg.GitHub.Repositories)
+	token, ok := tokens.For("octo-org/payments")
+	assert.True(t, ok)
+	assert.Equal(t, "literal-token", token)
+	token, ok = tokens.For("octocat/hello-world")
+	assert.True(t, ok)
+	assert.Equal(t, "gh-default", token)
+
+	tokens = NewTokens(cfg.GitLab.Token, cfg.GitLab.Projects)
+	token, ok = tokens.For("platform/billing")
+	assert.True(t, ok)
+	assert.Equal(t, "gl-billing", token)
+	_, ok = tokens.For("platform/other")
+	assert.False(t, ok)
+
+	_, err = LoadConfig(write("github:\n  token: ${TEST_UNSET_TOKEN}\n"))
+	assert.ErrorContains(t, err, "github.token: token is empty")
+
+	_, err = LoadConfig(write("gitlab:\n  api_url: https://gitlab.example.com\n"))
+	assert.ErrorContains(t, err, "gitlab: token or projects is required")
+
+	_, err = LoadConfig(write("github:\n  tokens: {}\n"))
+	assert.ErrorContains(t, err, "field tokens not found")
+}
@@ -0,0 +1,261 @@
+// Package codehosttest содержит поддельный сервер API GitHub и GitLab для
+// тестов отправки ревьюверов: он хранит запрошенных ревьюверов, записывает
+// запросы и умеет отвечать ошибками.
+package codehosttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Request запрос, полученный сервером.
+type Request struct {
+	Method string
+	Path   string
+	// Token токен из Authorization (GitHub) или PRIVATE-TOKEN (GitLab).
+	Token string
+}
+
+// Server поддельный хостинг. GitHub API доступен по GitHubURL, GitLab API —
+// по GitLabURL.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	requests  []Request
+	reviewers map[string][]string
+	users     map[string]int64
+	failures  []int
+}
+
+// NewServer запускает сервер, он останавливается по завершении теста.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		reviewers: make(map[string][]string),
+		users:     make(map[string]int64),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /github/repos/{owner}/{repo}/pulls/{number}/requested_reviewers", s.gitHubRequest)
+	mux.HandleFunc("DELETE /github/repos/{owner}/{repo}/pulls/{number}/requested_reviewers", s.gitHubRemove)
+	mux.HandleFunc("GET /gitlab/api/v4/users", s.gitLabUsers)
+	mux.HandleFunc("GET /gitlab/api/v4/projects/{project}/merge_requests/{iid}", s.gitLabGet)
+	mux.HandleFunc("PUT /gitlab/api/v4/projects/{project}/merge_requests/{iid}", s.gitLabUpdate)
+
+	s.Server = httptest.NewServer(s.record(mux))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// GitHubURL адрес для GitHubConfig.APIURL.
+func (s *Server) GitHubURL() string {
+	return s.URL + "/github"
+}
+
+// GitLabURL адрес для GitLabConfig.APIURL.
+func (s *Server) GitLabURL() string {
+	return s.URL + "/gitlab/api/v4"
+}
+
+// AddGitLabUser регистрирует пользователя GitLab.
+func (s *Server) AddGitLabUser(username string, id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[username] = id
+}
+
+// Fail отвечает статусом status на следующие n запросов.
+func (s *Server) Fail(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for range n {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// SetReviewers задаёт ревьюверов pull/merge request'а (логины на GitHub,
+// username на GitLab), например назначенных на хостинге вручную.
+func (s *Server) SetReviewers(provider, repo string, number int64, reviewers ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reviewers[key(provider, repo, number)] = reviewers
+}
+
+// Reviewers возвращает текущих ревьюверов pull/merge request'а.
+func (s *Server) Reviewers(provider, repo string, number int64) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.reviewers[key(provider, repo, number)])
+}
+
+// Requests возвращает полученные запросы.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func key(provider, repo string, number int64) string {
+	return fmt.Sprintf("%s:%s#%d", provider, strings.ToLower(repo), number)
+}
+
+// record записывает запрос и отвечает запланированной ошибкой или 401 без токена.
+func (s *Server) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("PRIVATE-TOKEN")
+		if t, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			token = t
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.EscapedPath(), Token: token})
+		status := 0
+		if len(s.failures) > 0 {
+			status, s.failures = s.failures[0], s.failures[1:]
+		}
+		s.mu.Unlock()
+
+		if status != 0 {
+			http.Error(w, `{"message":"injected failure"}`, status)
+			return
+		}
+		if token == "" {
+			http.Error(w, `{"message":"401 Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) gitHubRequest(w http.ResponseWriter, r *http.Request) {
+	s.gitHubUpdate(w, r, func(current []string, logins []string) []string {
+		for _, login := range logins {
+			if !slices.Contains(current, login) {
+				current = append(current, login)
+			}
+		}
+		return current
+	}, http.StatusCreated)
+}
+
+func (s *Server) gitHubRemove(w http.ResponseWriter, r *http.Request) {
+	s.gitHubUpdate(w, r, func(current []string, logins []string) []string {
+		return slices.DeleteFunc(current, func(login string) bool {
+			return slices.Contains(logins, login)
+		})
+	}, http.StatusOK)
+}
+
+func (s *Server) gitHubUpdate(w http.ResponseWriter, r *http.Request, update func(current, logins []string) []string, status int) {
+	number, err := strconv.ParseInt(r.PathValue("number"), 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var body struct {
+		Reviewers []string `json:"reviewers"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, `{"message":"Problems parsing JSON"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	k := key("github", r.PathValue("owner")+"/"+r.PathValue("repo"), number)
+	s.reviewers[k] = update(s.reviewers[k], body.Reviewers)
+	reviewers := slices.Clone(s.reviewers[k])
+	s.mu.Unlock()
+
+	users := make([]map[string]string, 0, len(reviewers))
+	for _, login := range reviewers {
+		users = append(users, map[string]string{"login": login})
+	}
+	writeJSON(w, status, map[string]any{"number": number, "requested_reviewers": users})
+}
+
+func (s *Server) gitLabUsers(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+
+	s.mu.Lock()
+	id, ok := s.users[username]
+	s.mu.Unlock()
+
+	users := []map[string]any{}
+	if ok {
+		users = append(users, map[string]any{"id": id, "username": username})
+	}
+	writeJSON(w, http.StatusOK, users)
+}
+
+func (s *Server) gitLabGet(w http.ResponseWriter, r *http.Request) {
+	k, ok := gitLabKey(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.gitLabMergeRequest(k))
+}
+
+func (s *Server) gitLabUpdate(w http.ResponseWriter, r *http.Request) {
+	k, ok := gitLabKey(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	var body struct {
+		ReviewerIDs []int64 `json:"reviewer_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, `{"message":"400 Bad request"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reviewers := []string{}
+	for _, id := range body.ReviewerIDs {
+		for username, userID := range s.users {
+			if userID == id {
+				reviewers = append(reviewers, username)
+			}
+		}
+	}
+	s.reviewers[k] = reviewers
+	writeJSON(w, http.StatusOK, s.gitLabMergeRequest(k))
+}
+
+// gitLabKey ключ merge request'а: путь проекта приходит закодированным
+// (platform%2Fbilling) и раскрывается PathValue.
+func gitLabKey(r *http.Request) (string, bool) {
+	iid, err := strconv.ParseInt(r.PathValue("iid"), 10, 64)
+	if err != nil {
+		return "", false
+	}
+	return key("gitlab", r.PathValue("project"), iid), true
+}
+
+// gitLabMergeRequest ответ с ревьюверами, вызывается под s.mu.
+func (s *Server) gitLabMergeRequest(k string) map[string]any {
+	reviewers := []map[string]any{}
+	for _, username := range s.reviewers[k] {
+		reviewers = append(reviewers, map[string]any{"id": s.users[username], "username": username})
+	}
+	return map[string]any{"reviewers": reviewers}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
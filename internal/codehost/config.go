@@ -0,0 +1,123 @@
+package codehost
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config адреса API и токены хостингов. В значениях токенов раскрываются
+// переменные окружения, чтобы секреты не хранились в файле. Пример:
+//
+//	github:
+//	  token: ${GITHUB_TOKEN}
+//	  repositories:
+//	    octo-org/payments: ${GITHUB_TOKEN_PAYMENTS}
+//	gitlab:
+//	  api_url: https://gitlab.example.com/api/v4
+//	  projects:
+//	    platform/billing: ${GITLAB_TOKEN_BILLING}
+type Config struct {
+	GitHub *GitHubConfig `yaml:"github"`
+	GitLab *GitLabConfig `yaml:"gitlab"`
+}
+
+// GitHubConfig доступ к GitHub или GitHub Enterprise.
+type GitHubConfig struct {
+	// APIURL по умолчанию https://api.github.com.
+	APIURL string `yaml:"api_url"`
+	// Token токен для репозиториев, которых нет в Repositories.
+	Token string `yaml:"token"`
+	// Repositories полное имя репозитория (owner/repo) → токен.
+	Repositories map[string]string `yaml:"repositories"`
+}
+
+// GitLabConfig доступ к GitLab.
+type GitLabConfig struct {
+	// APIURL по умолчанию https://gitlab.com/api/v4.
+	APIURL string `yaml:"api_url"`
+	// Token токен для проектов, которых нет в Projects.
+	Token string `yaml:"token"`
+	// Projects путь проекта с группой → токен.
+	Projects map[string]string `yaml:"projects"`
+}
+
+// LoadConfig читает файл настроек хостингов в YAML (или JSON).
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read code host file: %w", err)
+	}
+
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse code host file %s: %w", path, err)
+	}
+
+	if cfg.GitHub != nil {
+		if cfg.GitHub.APIURL == "" {
+			cfg.GitHub.APIURL = "https://api.github.com"
+		}
+		if err := expandTokens("github", &cfg.GitHub.Token, cfg.GitHub.Repositories, "repositories"); err != nil {
+			return nil, fmt.Errorf("invalid code host file %s: %w", path, err)
+		}
+	}
+	if cfg.GitLab != nil {
+		if cfg.GitLab.APIURL == "" {
+			cfg.GitLab.APIURL = "https://gitlab.com/api/v4"
+		}
+		if err := expandTokens("gitlab", &cfg.GitLab.Token, cfg.GitLab.Projects, "projects"); err != nil {
+			return nil, fmt.Errorf("invalid code host file %s: %w", path, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// expandTokens раскрывает переменные окружения. Пустой токен после
+// раскрытия — ошибка: скорее всего, не задана переменная.
+func expandTokens(provider string, token *string, repos map[string]string, field string) error {
+	if *token != "" {
+		if *token = os.ExpandEnv(*token); *token == "" {
+			return fmt.Errorf("%s.token: token is empty after expanding environment variables", provider)
+		}
+	}
+	for repo, t := range repos {
+		if repos[repo] = os.ExpandEnv(t); repos[repo] == "" {
+			return fmt.Errorf("%s.%s.%s: token is empty", provider, field, repo)
+		}
+	}
+	if *token == "" && len(repos) == 0 {
+		return fmt.Errorf("%s: token or %s is required", provider, field)
+	}
+	return nil
+}
+
+// Tokens токены доступа к репозиториям одного хостинга.
+type Tokens struct {
+	// Default токен для репозиториев без собственного.
+	Default string
+	// Repositories имя репозитория (без учёта регистра) → токен.
+	Repositories map[string]string
+}
+
+// NewTokens приводит имена репозиториев к нижнему регистру.
+func NewTokens(defaultToken string, repos map[string]string) Tokens {
+	t := Tokens{Default: defaultToken, Repositories: make(map[string]string, len(repos))}
+	for repo, token := range repos {
+		t.Repositories[strings.ToLower(repo)] = token
+	}
+	return t
+}
+
+// For возвращает токен репозитория.
+func (t Tokens) For(repo string) (string, bool) {
+	if token, ok := t.Repositories[strings.ToLower(repo)]; ok {
+		return token, true
+	}
+	return t.Default, t.Default != ""
+}
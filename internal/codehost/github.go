@@ -0,0 +1,51 @@
+package codehost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GitHubClient запрашивает ревьюверов через REST API GitHub.
+type GitHubClient struct {
+	apiURL string
+	tokens Tokens
+	http   *http.Client
+}
+
+func NewGitHubClient(cfg *GitHubConfig, httpClient *http.Client) *GitHubClient {
+	return &GitHubClient{
+		apiURL: strings.TrimSuffix(cfg.APIURL, "/"),
+		tokens: NewTokens(cfg.Token, cfg.Repositories),
+		http:   httpClient,
+	}
+}
+
+// UpdateReviewers реализует Client. Запрос ревью у уже запрошенного
+// и снятие не запрошенного ревьювера GitHub принимает без ошибки.
+func (c *GitHubClient) UpdateReviewers(ctx context.Context, link Link, add, remove []string) error {
+	token, ok := c.tokens.For(link.Repository)
+	if !ok {
+		return fmt.Errorf("no token for github repository %s: %w", link.Repository, errPermanent)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.apiURL, link.Repository, link.Number)
+	header := http.Header{
+		"Authorization":        {"Bearer " + token},
+		"Accept":               {"application/vnd.github+json"},
+		"X-Github-Api-Version": {"2022-11-28"},
+	}
+
+	if len(add) > 0 {
+		if err := doJSON(ctx, c.http, http.MethodPost, url, header, map[string][]string{"reviewers": add}, nil); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		if err := doJSON(ctx, c.http, http.MethodDelete, url, header, map[string][]string{"reviewers": remove}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package codehost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// GitLabClient назначает ревьюверов merge request'ов через REST API GitLab.
+type GitLabClient struct {
+	apiURL string
+	tokens Tokens
+	http   *http.Client
+
+	// userIDs кэш ID пользователей по username: API принимает только ID.
+	mu      sync.Mutex
+	userIDs map[string]int64
+}
+
+func NewGitLabClient(cfg *GitLabConfig, httpClient *http.Client) *GitLabClient {
+	return &GitLabClient{
+		apiURL:  strings.TrimSuffix(cfg.APIURL, "/"),
+		tokens:  NewTokens(cfg.Token, cfg.Projects),
+		http:    httpClient,
+		userIDs: make(map[string]int64),
+	}
+}
+
+type gitLabUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// UpdateReviewers реализует Client. В GitLab список ревьюверов задаётся
+// целиком, поэтому он читается и записывается с изменениями; ревьюверы,
+// добавленные на хостинге вручную, сохраняются.
+func (c *GitLabClient) UpdateReviewers(ctx context.Context, link Link, add, remove []string) error {
+	token, ok := c.tokens.For(link.Repository)
+	if !ok {
+		return fmt.Errorf("no token for gitlab project %s: %w", link.Repository, errPermanent)
+	}
+	header := http.Header{"Private-Token": {token}}
+
+	addIDs, err := c.resolve(ctx, header, add)
+	if err != nil {
+		return err
+	}
+	removeIDs, err := c.resolve(ctx, header, remove)
+	if err != nil {
+		return err
+	}
+
+	mrURL := fmt.Sprintf("%s/projects/%s/merge_requests/%d", c.apiURL, url.PathEscape(link.Repository), link.Number)
+	var mr struct {
+		Reviewers []gitLabUser `json:"reviewers"`
+	}
+	if err := doJSON(ctx, c.http, http.MethodGet, mrURL, header, nil, &mr); err != nil {
+		return err
+	}
+
+	removed := make(map[int64]bool, len(removeIDs))
+	for _, id := range removeIDs {
+		removed[id] = true
+	}
+	seen := make(map[int64]bool)
+	reviewerIDs := []int64{}
+	for _, r := range mr.Reviewers {
+		if !removed[r.ID] && !seen[r.ID] {
+			seen[r.ID] = true
+			reviewerIDs = append(reviewerIDs, r.ID)
+		}
+	}
+	changed := len(reviewerIDs) != len(mr.Reviewers)
+	for _, id := range addIDs {
+		if !seen[id] {
+			seen[id] = true
+			reviewerIDs = append(reviewerIDs, id)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	return doJSON(ctx, c.http, http.MethodPut, mrURL, header, map[string][]int64{"reviewer_ids": reviewerIDs}, nil)
+}
+
+// resolve возвращает ID пользователей GitLab по username.
+func (c *GitLabClient) resolve(ctx context.Context, header http.Header, usernames []string) ([]int64, error) {
+	ids := make([]int64, 0, len(usernames))
+	for _, username := range usernames {
+		key := strings.ToLower(username)
+
+		c.mu.Lock()
+		id, ok := c.userIDs[key]
+		c.mu.Unlock()
+
+		if !ok {
+			var users []gitLabUser
+			usersURL := fmt.Sprintf("%s/users?username=%s", c.apiURL, url.QueryEscape(username))
+			if err := doJSON(ctx, c.http, http.MethodGet, usersURL, header, nil, &users); err != nil {
+				return nil, err
+			}
+			if len(users) == 0 {
+				return nil, fmt.Errorf("gitlab user %s not found: %w", username, errPermanent)
+			}
+			id = users[0].ID
+
+			c.mu.Lock()
+			c.userIDs[key] = id
+			c.mu.Unlock()
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,114 @@
+// Package codehost отправляет назначения ревьюверов обратно на хостинг кода
+// (GitHub, GitLab): после CreatePR и ReassignReviewer ревьюверы
+// запрашиваются в pull/merge request'е, заменённый ревьювер снимается.
+package codehost
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Link pull/merge request на хостинге, из которого создан PR сервиса.
+type Link struct {
+	Provider string
+	// Repository полное имя репозитория GitHub (owner/repo) или путь
+	// проекта GitLab с группой.
+	Repository string
+	// Number номер pull request'а GitHub или IID merge request'а GitLab.
+	Number int64
+}
+
+// Links хранит связи PR сервиса с хостингом. Связь записывается при приёме
+// webhook'а до создания PR, поэтому к моменту назначения ревьюверов она
+// уже есть. Связи хранятся отдельно для каждой организации из ctx.
+type Links interface {
+	// Save записывает или обновляет связь.
+	Save(ctx context.Context, prID uuid.UUID, link Link) error
+	// Get возвращает связь или ошибку "LINK_NOT_FOUND", если PR создан
+	// не из webhook'а.
+	Get(ctx context.Context, prID uuid.UUID) (*Link, error)
+}
+
+// MemoryLinks связи в памяти процесса.
+type MemoryLinks struct {
+	mu    sync.RWMutex
+	links map[linkKey]Link
+}
+
+// linkKey ID PR уникален в пределах организации.
+type linkKey struct {
+	orgID uuid.UUID
+	prID  uuid.UUID
+}
+
+func NewMemoryLinks() *MemoryLinks {
+	return &MemoryLinks{links: make(map[linkKey]Link)}
+}
+
+// Save реализует Links.
+func (l *MemoryLinks) Save(ctx context.Context, prID uuid.UUID, link Link) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.links[linkKey{orgID: tenant.OrgID(ctx), prID: prID}] = link
+	return nil
+}
+
+// Get реализует Links.
+func (l *MemoryLinks) Get(ctx context.Context, prID uuid.UUID) (*Link, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	link, ok := l.links[linkKey{orgID: tenant.OrgID(ctx), prID: prID}]
+	if !ok {
+		return nil, errors.New("LINK_NOT_FOUND")
+	}
+	return &link, nil
+}
+
+// SQLLinks связи в таблице pull_request_links (PostgreSQL или SQLite).
+type SQLLinks struct {
+	db *sql.DB
+}
+
+func NewSQLLinks(db *sql.DB) *SQLLinks {
+	return &SQLLinks{db: db}
+}
+
+// Save реализует Links. Репозиторий GitHub можно переименовать, поэтому
+// существующая связь обновляется.
+func (l *SQLLinks) Save(ctx context.Context, prID uuid.UUID, link Link) error {
+	_, err := l.db.ExecContext(ctx, `
+		INSERT INTO pull_request_links (pull_request_id, provider, repository, number, created_at, org_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (org_id, pull_request_id) DO UPDATE
+		SET provider = excluded.provider, repository = excluded.repository, number = excluded.number
+	`, prID.String(), link.Provider, link.Repository, link.Number, time.Now().UTC(), tenant.OrgID(ctx).String())
+	if err != nil {
+		return fmt.Errorf("failed to save pull request link: %w", err)
+	}
+	return nil
+}
+
+// Get реализует Links.
+func (l *SQLLinks) Get(ctx context.Context, prID uuid.UUID) (*Link, error) {
+	var link Link
+	err := l.db.QueryRowContext(ctx, `
+		SELECT provider, repository, number FROM pull_request_links WHERE pull_request_id = $1 AND org_id = $2
+	`, prID.String(), tenant.OrgID(ctx).String()).Scan(&link.Provider, &link.Repository, &link.Number)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("LINK_NOT_FOUND")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request link: %w", err)
+	}
+	return &link, nil
+}
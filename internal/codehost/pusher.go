@@ -0,0 +1,234 @@
+package codehost
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Provider хостинг, на который отправляются назначения.
+type Provider struct {
+	Client Client
+	Logins Logins
+}
+
+// Logins логины пользователей на хостинге; его реализует webhook.Accounts.
+type Logins interface {
+	Login(userID uuid.UUID) (string, bool)
+}
+
+// Pusher отправляет изменения назначений на хостинг. Реализует
+// service.EventPublisher: события ставятся в очередь и отправляются в Run,
+// не задерживая ответ API. Неудавшийся вызов повторяется с растущей
+// задержкой. Очередь хранится в памяти, при остановке процесса
+// неотправленные изменения теряются.
+type Pusher struct {
+	links     Links
+	providers map[string]Provider
+	queue     chan job
+
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// Option настраивает Pusher.
+type Option func(*Pusher)
+
+// WithRetry задаёт число попыток и задержку перед первым повтором;
+// каждая следующая задержка вдвое больше.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(p *Pusher) {
+		p.maxAttempts = maxAttempts
+		p.baseDelay = baseDelay
+	}
+}
+
+// maxDelay ограничение задержки между попытками.
+const maxDelay = 5 * time.Minute
+
+// queueSize сколько изменений может ждать отправки.
+const queueSize = 1024
+
+func NewPusher(links Links, providers map[string]Provider, opts ...Option) *Pusher {
+	p := &Pusher{
+		links:       links,
+		providers:   providers,
+		queue:       make(chan job, queueSize),
+		maxAttempts: 5,
+		baseDelay:   time.Second,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// job изменение назначений одного PR.
+type job struct {
+	// orgID организация PR: Run работает вне запроса, связь ищется в ней.
+	orgID   uuid.UUID
+	prID    uuid.UUID
+	add     []uuid.UUID
+	remove  []uuid.UUID
+	attempt int
+}
+
+// Publish реализует service.EventPublisher. Назначения и снятия одной
+// операции отправляются одним изменением; merge на хостинг не передаётся.
+func (p *Pusher) Publish(ctx context.Context, events ...domain.ReviewEvent) {
+	jobs := make(map[uuid.UUID]*job)
+	var order []uuid.UUID
+	for _, e := range events {
+		if e.Type != domain.EventReviewAssigned && e.Type != domain.EventReviewUnassigned {
+			continue
+		}
+		j, ok := jobs[e.PullRequestID]
+		if !ok {
+			orgID := e.OrgID
+			if orgID == uuid.Nil {
+				orgID = tenant.OrgID(ctx)
+			}
+			j = &job{orgID: orgID, prID: e.PullRequestID}
+			jobs[e.PullRequestID] = j
+			order = append(order, e.PullRequestID)
+		}
+		if e.Type == domain.EventReviewAssigned {
+			j.add = append(j.add, e.UserID)
+		} else {
+			j.remove = append(j.remove, e.UserID)
+		}
+	}
+
+	for _, prID := range order {
+		p.enqueue(ctx, *jobs[prID])
+	}
+}
+
+func (p *Pusher) enqueue(ctx context.Context, j job) {
+	select {
+	case p.queue <- j:
+	default:
+		slog.ErrorContext(ctx, "Code host queue is full, reviewers change dropped", "pr_id", j.prID)
+	}
+}
+
+// Run отправляет изменения из очереди, пока не отменён ctx.
+func (p *Pusher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-p.queue:
+			p.push(ctx, j)
+		}
+	}
+}
+
+// push выполняет одну попытку и при временной ошибке планирует повтор.
+// Повтор не занимает очередь на время задержки.
+func (p *Pusher) push(ctx context.Context, j job) {
+	j.attempt++
+
+	err := p.send(ctx, j)
+	if err == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	if !retryable(err) || j.attempt >= p.maxAttempts {
+		slog.ErrorContext(ctx, "Failed to push reviewers to code host",
+			"pr_id", j.prID,
+			"attempt", j.attempt,
+			"error", err,
+		)
+		return
+	}
+
+	delay := p.delay(j.attempt, err)
+	slog.WarnContext(ctx, "Failed to push reviewers to code host, will retry",
+		"pr_id", j.prID,
+		"attempt", j.attempt,
+		"retry_in", delay,
+		"error", err,
+	)
+	time.AfterFunc(delay, func() {
+		if ctx.Err() == nil {
+			p.enqueue(ctx, j)
+		}
+	})
+}
+
+// delay задержка перед попыткой attempt+1. Retry-After хостинга
+// соблюдается, если он больше.
+func (p *Pusher) delay(attempt int, err error) time.Duration {
+	d := p.baseDelay << (attempt - 1)
+	if d > maxDelay || d <= 0 {
+		d = maxDelay
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > d {
+		d = apiErr.RetryAfter
+	}
+	return d
+}
+
+func (p *Pusher) send(ctx context.Context, j job) error {
+	link, err := p.links.Get(tenant.WithOrg(ctx, j.orgID), j.prID)
+	if err != nil {
+		if err.Error() == "LINK_NOT_FOUND" {
+			slog.DebugContext(ctx, "PR is not linked to a code host, reviewers not pushed", "pr_id", j.prID)
+			return nil
+		}
+		return err
+	}
+
+	provider, ok := p.providers[link.Provider]
+	if !ok {
+		slog.DebugContext(ctx, "Code host is not configured, reviewers not pushed", "pr_id", j.prID, "provider", link.Provider)
+		return nil
+	}
+
+	add := provider.logins(ctx, j.prID, j.add)
+	remove := provider.logins(ctx, j.prID, j.remove)
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	if err := provider.Client.UpdateReviewers(ctx, *link, add, remove); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Reviewers pushed to code host",
+		"pr_id", j.prID,
+		"provider", link.Provider,
+		"repository", link.Repository,
+		"number", link.Number,
+		"added", add,
+		"removed", remove,
+	)
+	return nil
+}
+
+// logins переводит user_id в логины хостинга. Пользователи без логина
+// пропускаются: на хостинге их нельзя назначить.
+func (p Provider) logins(ctx context.Context, prID uuid.UUID, userIDs []uuid.UUID) []string {
+	logins := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		login, ok := p.Logins.Login(userID)
+		if !ok {
+			slog.WarnContext(ctx, "Reviewer has no code host login, skipped", "pr_id", prID, "user_id", userID)
+			continue
+		}
+		logins = append(logins, login)
+	}
+	return logins
+}
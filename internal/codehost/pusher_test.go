@@ -0,0 +1,230 @@
+package codehost
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/codehost/codehosttest"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+var (
+	alice = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	bob   = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	carol = uuid.MustParse("550e8400-e29b-41d4-a716-446655440003")
+)
+
+var logins = loginMap{alice: "alice", bob: "bob", carol: "carol"}
+
+type loginMap map[uuid.UUID]string
+
+func (m loginMap) Login(userID uuid.UUID) (string, bool) {
+	login, ok := m[userID]
+	return login, ok
+}
+
+func assigned(prID uuid.UUID, userIDs ...uuid.UUID) []domain.ReviewEvent {
+	return reviewEvents(domain.EventReviewAssigned, prID, userIDs...)
+}
+
+func unassigned(prID uuid.UUID, userIDs ...uuid.UUID) []domain.ReviewEvent {
+	return reviewEvents(domain.EventReviewUnassigned, prID, userIDs...)
+}
+
+func reviewEvents(eventType string, prID uuid.UUID, userIDs ...uuid.UUID) []domain.ReviewEvent {
+	events := make([]domain.ReviewEvent, len(userIDs))
+	for i, userID := range userIDs {
+		events[i] = domain.ReviewEvent{Type: eventType, UserID: userID, PullRequestID: prID}
+	}
+	return events
+}
+
+// startPusher запускает Pusher с поддельным хостингом. Между попытками
+// 10ms, чтобы тесты повторов шли быстро.
+func startPusher(t *testing.T, srv *codehosttest.Server, links Links) *Pusher {
+	t.Helper()
+
+	github := NewGitHubClient(&GitHubConfig{
+		APIURL:       srv.GitHubURL(),
+		Token:        "gh-default",
+		Repositories: map[string]string{"octo-org/payments": "gh-payments"},
+	}, srv.Client())
+	gitlab := NewGitLabClient(&GitLabConfig{APIURL: srv.GitLabURL(), Token: "gl-default"}, srv.Client())
+
+	p := NewPusher(links, map[string]Provider{
+		"github": {Client: github, Logins: logins},
+		"gitlab": {Client: gitlab, Logins: logins},
+	}, WithRetry(3, 10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go p.Run(ctx)
+	return p
+}
+
+func eventuallyReviewers(t *testing.T, srv *codehosttest.Server, provider, repo string, number int64, want ...string) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(want, srv.Reviewers(provider, repo, number))
+	}, time.Second, 5*time.Millisecond, "reviewers %v, want %v", srv.Reviewers(provider, repo, number), want)
+}
+
+func TestPusher_GitHub(t *testing.T) {
+	ctx := context.Background()
+	srv := codehosttest.NewServer(t)
+	links := NewMemoryLinks()
+	p := startPusher(t, srv, links)
+
+	prID := uuid.New()
+	require.NoError(t, links.Save(ctx, prID, Link{Provider: "github", Repository: "octo-org/payments", Number: 42}))
+
+	p.Publish(ctx, assigned(prID, alice, bob)...)
+	eventuallyReviewers(t, srv, "github", "octo-org/payments", 42, "alice", "bob")
+
+	// Переназначение: bob снимается, carol запрашивается.
+	p.Publish(ctx, append(unassigned(prID, bob), assigned(prID, carol)...)...)
+	eventuallyReviewers(t, srv, "github", "octo-org/payments", 42, "alice", "carol")
+
+	requests := srv.Requests()
+	require.Len(t, requests, 3)
+	assert.Equal(t, codehosttest.Request{Method: http.MethodPost, Path: "/github/repos/octo-org/payments/pulls/42/requested_reviewers", Token: "gh-payments"}, requests[0])
+	assert.Equal(t, http.MethodPost, requests[1].Method)
+	assert.Equal(t, http.MethodDelete, requests[2].Method)
+}
+
+func TestPusher_GitLab(t *testing.T) {
+	ctx := context.Background()
+	srv := codehosttest.NewServer(t)
+	srv.AddGitLabUser("alice", 1)
+	srv.AddGitLabUser("bob", 2)
+	srv.AddGitLabUser("carol", 3)
+	srv.AddGitLabUser("maintainer", 4)
+	links := NewMemoryLinks()
+	p := startPusher(t, srv, links)
+
+	prID := uuid.New()
+	require.NoError(t, links.Save(ctx, prID, Link{Provider: "gitlab", Repository: "platform/billing", Number: 7}))
+	// Ревьювер, назначенный на хостинге вручную, сохраняется.
+	srv.SetReviewers("gitlab", "platform/billing", 7, "maintainer")
+
+	p.Publish(ctx, assigned(prID, alice, bob)...)
+	eventuallyReviewers(t, srv, "gitlab", "platform/billing", 7, "maintainer", "alice", "bob")
+
+	p.Publish(ctx, append(unassigned(prID, alice), assigned(prID, carol)...)...)
+	eventuallyReviewers(t, srv, "gitlab", "platform/billing", 7, "maintainer", "bob", "carol")
+
+	for _, r := range srv.Requests() {
+		assert.Equal(t, "gl-default", r.Token)
+		if r.Method != http.MethodGet || r.Path != "/gitlab/api/v4/users" {
+			assert.Contains(t, r.Path, "/projects/platform%2Fbilling/merge_requests/7")
+		}
+	}
+}
+
+func TestPusher_UsesEventOrganization(t *testing.T) {
+	srv := codehosttest.NewServer(t)
+	links := NewMemoryLinks()
+	p := startPusher(t, srv, links)
+
+	orgID := uuid.New()
+	prID := uuid.New()
+	require.NoError(t, links.Save(tenant.WithOrg(context.Background(), orgID), prID, Link{Provider: "github", Repository: "octo-org/payments", Number: 42}))
+
+	// Подписчик может вызываться вне запроса: организация берётся из события.
+	events := assigned(prID, alice)
+	events[0].OrgID = orgID
+	p.Publish(tenant.WithDefault(context.Background()), events...)
+	eventuallyReviewers(t, srv, "github", "octo-org/payments", 42, "alice")
+}
+
+func TestPusher_RetriesTemporaryErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := codehosttest.NewServer(t)
+	links := NewMemoryLinks()
+	p := startPusher(t, srv, links)
+
+	prID := uuid.New()
+	require.NoError(t, links.Save(ctx, prID, Link{Provider: "github", Repository: "octocat/hello-world", Number: 1}))
+	srv.Fail(2, http.StatusBadGateway)
+
+	p.Publish(ctx, assigned(prID, alice)...)
+	eventuallyReviewers(t, srv, "github", "octocat/hello-world", 1, "alice")
+	assert.Len(t, srv.Requests(), 3)
+}
+
+func TestPusher_GivesUp(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		failures int
+		requests int
+	}{
+		{name: "permanent error", status: http.StatusUnprocessableEntity, failures: 1, requests: 1},
+		{name: "attempts exhausted", status: http.StatusServiceUnavailable, failures: 5, requests: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := codehosttest.NewServer(t)
+			links := NewMemoryLinks()
+			p := startPusher(t, srv, links)
+
+			prID := uuid.New()
+			require.NoError(t, links.Save(ctx, prID, Link{Provider: "github", Repository: "octocat/hello-world", Number: 1}))
+			srv.Fail(tt.failures, tt.status)
+
+			p.Publish(ctx, assigned(prID, alice)...)
+			assert.Eventually(t, func() bool { return len(srv.Requests()) == tt.requests }, time.Second, 5*time.Millisecond)
+
+			// Больше попыток не будет.
+			time.Sleep(100 * time.Millisecond)
+			assert.Len(t, srv.Requests(), tt.requests)
+			assert.Empty(t, srv.Reviewers("github", "octocat/hello-world", 1))
+		})
+	}
+}
+
+func TestPusher_Skips(t *testing.T) {
+	ctx := context.Background()
+	srv := codehosttest.NewServer(t)
+	links := NewMemoryLinks()
+	p := NewPusher(links, map[string]Provider{
+		"github": {Client: NewGitHubClient(&GitHubConfig{APIURL: srv.GitHubURL(), Token: "gh"}, srv.Client()), Logins: logins},
+	})
+
+	unmapped := uuid.New()
+	linked := uuid.New()
+	gitlab := uuid.New()
+	require.NoError(t, links.Save(ctx, linked, Link{Provider: "github", Repository: "octocat/hello-world", Number: 1}))
+	require.NoError(t, links.Save(ctx, gitlab, Link{Provider: "gitlab", Repository: "platform/billing", Number: 1}))
+
+	// PR не из webhook'а, ревьювер без логина, хостинг не настроен, merge.
+	for _, j := range []job{
+		{prID: uuid.New(), add: []uuid.UUID{alice}},
+		{prID: linked, add: []uuid.UUID{unmapped}},
+		{prID: gitlab, add: []uuid.UUID{alice}},
+	} {
+		require.NoError(t, p.send(ctx, j))
+	}
+	p.Publish(ctx, domain.ReviewEvent{Type: domain.EventPRMerged, PullRequestID: linked, UserID: alice})
+
+	assert.Empty(t, srv.Requests())
+	assert.Empty(t, p.queue)
+}
+
+func TestPusher_Delay(t *testing.T) {
+	p := NewPusher(NewMemoryLinks(), nil, WithRetry(10, time.Second))
+
+	assert.Equal(t, time.Second, p.delay(1, &APIError{Status: 502}))
+	assert.Equal(t, 4*time.Second, p.delay(3, &APIError{Status: 502}))
+	assert.Equal(t, maxDelay, p.delay(20, &APIError{Status: 502}))
+	assert.Equal(t, time.Minute, p.delay(1, &APIError{Status: 429, RetryAfter: time.Minute}))
+}
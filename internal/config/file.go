@@ -0,0 +1,297 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// setting связывает ключ файла конфигурации с переменной окружения.
+type setting struct {
+	path string
+	env  string
+	// reloadable настройка применяется при перезагрузке без перезапуска.
+	reloadable bool
+}
+
+var settings = []setting{
+	{path: "storage", env: "STORAGE"},
+	{path: "admin_token", env: "ADMIN_TOKEN"},
+	{path: "log_level", env: "LOG_LEVEL", reloadable: true},
+	{path: "team_cache_ttl", env: "TEAM_CACHE_TTL"},
+	{path: "events_retention", env: "EVENTS_RETENTION"},
+	{path: "openapi_validation", env: "OPENAPI_VALIDATION"},
+	{path: "organizations_enabled", env: "ORGANIZATIONS_ENABLED"},
+
+	{path: "database.url", env: "DATABASE_URL"},
+	{path: "database.migrations_path", env: "MIGRATIONS_PATH"},
+	{path: "database.auto_migrate", env: "AUTO_MIGRATE"},
+	{path: "database.max_open_conns", env: "DB_MAX_OPEN_CONNS"},
+	{path: "database.max_idle_conns", env: "DB_MAX_IDLE_CONNS"},
+	{path: "database.conn_max_lifetime", env: "DB_CONN_MAX_LIFETIME"},
+	{path: "database.connect_retries", env: "DB_CONNECT_RETRIES"},
+	{path: "database.connect_retry_delay", env: "DB_CONNECT_RETRY_DELAY"},
+
+	{path: "server.port", env: "PORT"},
+	{path: "server.grpc_port", env: "GRPC_PORT"},
+	{path: "server.read_timeout", env: "HTTP_READ_TIMEOUT"},
+	{path: "server.write_timeout", env: "HTTP_WRITE_TIMEOUT"},
+	{path: "server.idle_timeout", env: "HTTP_IDLE_TIMEOUT"},
+	{path: "server.request_timeout", env: "REQUEST_TIMEOUT"},
+	{path: "server.shutdown_timeout", env: "SHUTDOWN_TIMEOUT"},
+	{path: "server.drain_delay", env: "SHUTDOWN_DRAIN_DELAY"},
+
+	{path: "rate_limit.enabled", env: "RATE_LIMIT_ENABLED"},
+	{path: "rate_limit.backend", env: "RATE_LIMIT_BACKEND"},
+	{path: "rate_limit.default", env: "RATE_LIMIT_DEFAULT", reloadable: true},
+	{path: "rate_limit.routes", env: "RATE_LIMIT_ROUTES", reloadable: true},
+	{path: "rate_limit.roles", env: "RATE_LIMIT_ROLES", reloadable: true},
+
+	{path: "roster.file", env: "ROSTER_FILE"},
+	{path: "roster.sync_interval", env: "ROSTER_SYNC_INTERVAL"},
+	{path: "roster.open_reviews", env: "ROSTER_OPEN_REVIEWS"},
+
+	{path: "webhook.github_secret", env: "GITHUB_WEBHOOK_SECRET"},
+	{path: "webhook.gitlab_token", env: "GITLAB_WEBHOOK_TOKEN"},
+	{path: "webhook.mapping_file", env: "WEBHOOK_MAPPING_FILE"},
+	{path: "webhook.delivery_retention", env: "WEBHOOK_DELIVERY_RETENTION"},
+
+	{path: "code_host.file", env: "CODE_HOST_FILE"},
+	{path: "code_host.max_attempts", env: "CODE_HOST_MAX_ATTEMPTS"},
+
+	{path: "chatops.slack_signing_secret", env: "SLACK_SIGNING_SECRET"},
+	{path: "chatops.absence_check_interval", env: "ABSENCE_CHECK_INTERVAL"},
+
+	{path: "notify.smtp_addr", env: "SMTP_ADDR"},
+	{path: "notify.smtp_username", env: "SMTP_USERNAME"},
+	{path: "notify.smtp_password", env: "SMTP_PASSWORD"},
+	{path: "notify.from", env: "SMTP_FROM"},
+	{path: "notify.templates_file", env: "NOTIFY_TEMPLATES_FILE"},
+	{path: "notify.digest_time", env: "NOTIFY_DIGEST_TIME"},
+}
+
+func settingByPath(path string) (setting, bool) {
+	for _, s := range settings {
+		if s.path == path {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func settingByEnv(env string) (setting, bool) {
+	for _, s := range settings {
+		if s.env == env {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// source значения настроек: переменные окружения поверх файла конфигурации,
+// файл поверх значений по умолчанию. Ошибки накапливаются, чтобы при старте
+// показать все проблемы сразу.
+type source struct {
+	path string
+	// file значения из файла по имени переменной окружения.
+	file map[string]string
+	// values итоговые значения, по ним определяются изменения при перезагрузке.
+	values map[string]string
+	errs   []error
+}
+
+// newSource читает файл конфигурации. Пустой path — только окружение.
+func newSource(path string) (*source, error) {
+	s := &source{
+		path:   path,
+		file:   make(map[string]string),
+		values: make(map[string]string),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	raw, err := decodeFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := flatten("", raw, s.file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// decodeFile разбирает YAML или TOML по расширению файла.
+func decodeFile(path string, data []byte) (map[string]any, error) {
+	raw := make(map[string]any)
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("%s: invalid YAML: %w", path, err)
+		}
+	case ".toml":
+		if err := toml.NewDecoder(bytes.NewReader(data)).Decode(&raw); err != nil {
+			return nil, fmt.Errorf("%s: invalid TOML: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: config file must be .yaml, .yml or .toml, got %q", path, ext)
+	}
+
+	return raw, nil
+}
+
+// flatten раскладывает вложенные секции файла в значения по имени
+// переменной окружения. Неизвестные ключи считаются ошибкой: опечатка
+// в имени не должна молча оставлять значение по умолчанию.
+func flatten(prefix string, raw map[string]any, out map[string]string) error {
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		if s, ok := settingByPath(path); ok {
+			value, err := formatValue(raw[k])
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			out[s.env] = value
+			continue
+		}
+
+		section, ok := raw[k].(map[string]any)
+		if !ok {
+			return fmt.Errorf("unknown key %q", path)
+		}
+		if err := flatten(path, section, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatValue приводит значение из файла к строке в формате переменной
+// окружения. Таблица правил ("METHOD /path" = "rps:burst") превращается
+// в список "key=rps:burst,...".
+func formatValue(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		rules := make([]string, 0, len(keys))
+		for _, k := range keys {
+			value, ok := v[k].(string)
+			if !ok {
+				return "", fmt.Errorf("rule %q must be a string \"rps:burst\"", k)
+			}
+			rules = append(rules, k+"="+value)
+		}
+		return strings.Join(rules, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", v)
+	}
+}
+
+// get возвращает значение настройки и запоминает его.
+func (s *source) get(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		value = s.file[key]
+	}
+	if value == "" {
+		value = defaultValue
+	}
+	s.values[key] = value
+	return value
+}
+
+// name имя настройки для сообщений об ошибках: для значения из файла
+// указывается и ключ в файле.
+func (s *source) name(key string) string {
+	if _, ok := s.file[key]; ok && os.Getenv(key) == "" {
+		if st, ok := settingByEnv(key); ok {
+			return fmt.Sprintf("%s (%s in %s)", key, st.path, s.path)
+		}
+	}
+	return key
+}
+
+func (s *source) fail(key string, err error) {
+	s.errs = append(s.errs, fmt.Errorf("%s: %w", s.name(key), err))
+}
+
+func (s *source) failf(key, format string, args ...any) {
+	s.fail(key, fmt.Errorf(format, args...))
+}
+
+// err объединяет накопленные ошибки.
+func (s *source) err() error {
+	if len(s.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration: %w", errors.Join(s.errs...))
+}
+
+func (s *source) bool(key, defaultValue string) bool {
+	v, err := strconv.ParseBool(s.get(key, defaultValue))
+	if err != nil {
+		s.failf(key, "must be true or false, got %q", s.values[key])
+	}
+	return v
+}
+
+func (s *source) positiveInt(key, defaultValue string) int {
+	v, err := strconv.Atoi(s.get(key, defaultValue))
+	if err != nil || v < 1 {
+		s.failf(key, "must be a positive integer, got %q", s.values[key])
+	}
+	return v
+}
+
+// duration разбирает длительность. positive запрещает нулевое значение,
+// отрицательные запрещены всегда.
+func (s *source) duration(key, defaultValue string, positive bool) time.Duration {
+	v, err := time.ParseDuration(s.get(key, defaultValue))
+	switch {
+	case err != nil:
+		s.fail(key, err)
+	case positive && v <= 0:
+		s.failf(key, "must be positive, got %s", v)
+	case v < 0:
+		s.failf(key, "must not be negative, got %s", v)
+	}
+	return v
+}
@@ -3,18 +3,26 @@ package config
 import (
 	"log/slog"
 	"os"
+
+	"github.com/T1mof/pr-reviewer-service/internal/tracing"
 )
 
+// logLevel текущий уровень логирования. Меняется при загрузке и
+// перезагрузке конфигурации без пересоздания логгера.
+var logLevel = new(slog.LevelVar)
+
 // SetupLogger инициализирует структурированное логирование.
+// Записи, сделанные через *Context-методы slog, дополняются request_id и trace_id.
+// До загрузки конфигурации уровень берётся из LOG_LEVEL, по умолчанию info.
 func SetupLogger() {
-	level := slog.LevelInfo
-	if os.Getenv("LOG_LEVEL") == "debug" {
-		level = slog.LevelDebug
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(os.Getenv("LOG_LEVEL"))); err == nil {
+		logLevel.Set(level)
 	}
 
 	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: level,
+		Level: logLevel,
 	})
 
-	slog.SetDefault(slog.New(handler))
+	slog.SetDefault(slog.New(tracing.NewLogHandler(handler)))
 }
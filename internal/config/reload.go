@@ -0,0 +1,107 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"log/slog"
+	"maps"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// fileCheckInterval как часто проверяется изменение файла конфигурации.
+const fileCheckInterval = 5 * time.Second
+
+// Watch перечитывает конфигурацию по SIGHUP и при изменении файла до отмены ctx.
+// Новая конфигурация проверяется целиком, при ошибке продолжает действовать
+// прежняя. Без перезапуска применяются уровень логирования и лимиты запросов:
+// уровень меняется здесь, лимиты применяет apply. Об изменении остальных
+// настроек пишется предупреждение.
+func Watch(ctx context.Context, current *Config, apply func(*Config)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(fileCheckInterval)
+	defer ticker.Stop()
+
+	lastSum := fileSum(current.File)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			slog.InfoContext(ctx, "SIGHUP received, reloading config")
+		case <-ticker.C:
+			if current.File == "" || fileSum(current.File) == lastSum {
+				continue
+			}
+			slog.InfoContext(ctx, "Config file changed, reloading", "path", current.File)
+		}
+		lastSum = fileSum(current.File)
+
+		next, err := read()
+		if err != nil {
+			slog.ErrorContext(ctx, "Config reload failed, keeping current config", "error", err)
+			continue
+		}
+		current = current.reload(ctx, next, apply)
+	}
+}
+
+// reload применяет изменившиеся настройки, не требующие перезапуска,
+// и возвращает действующую конфигурацию.
+func (c *Config) reload(ctx context.Context, next *Config, apply func(*Config)) *Config {
+	reloadable, restart := c.changes(next)
+	for _, key := range restart {
+		slog.WarnContext(ctx, "Config change requires restart, ignoring", "setting", key)
+	}
+	if len(reloadable) == 0 {
+		slog.InfoContext(ctx, "Config reloaded, nothing to apply")
+		return c
+	}
+
+	merged := *c
+	merged.values = maps.Clone(c.values)
+	for _, key := range reloadable {
+		merged.values[key] = next.values[key]
+	}
+	merged.LogLevel = next.LogLevel
+	merged.RateLimit.Policy = next.RateLimit.Policy
+
+	logLevel.Set(merged.LogLevel)
+	apply(&merged)
+
+	slog.InfoContext(ctx, "Config reloaded", "applied", reloadable)
+	return &merged
+}
+
+// changes возвращает имена изменившихся настроек: применимых на лету
+// и требующих перезапуска.
+func (c *Config) changes(next *Config) (reloadable, restart []string) {
+	for _, s := range settings {
+		if c.values[s.env] == next.values[s.env] {
+			continue
+		}
+		if s.reloadable {
+			reloadable = append(reloadable, s.env)
+		} else {
+			restart = append(restart, s.env)
+		}
+	}
+	return reloadable, restart
+}
+
+// fileSum контрольная сумма файла, нулевая при ошибке чтения.
+func fileSum(path string) [sha256.Size]byte {
+	if path == "" {
+		return [sha256.Size]byte{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(data)
+}
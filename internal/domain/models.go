@@ -12,18 +12,60 @@ type User struct {
 	TeamID   uuid.UUID `db:"team_id" json:"-"`
 	TeamName string    `db:"team_name" json:"team_name,omitempty"`
 	IsActive bool      `db:"is_active" json:"is_active"`
+	// Seniority уровень пользователя, пустая строка — не задан.
+	Seniority string `db:"seniority" json:"seniority,omitempty"`
 }
 
 type Team struct {
 	TeamID   uuid.UUID    `db:"team_id" json:"-"`
 	TeamName string       `db:"team_name" json:"team_name"`
 	Members  []TeamMember `json:"members"`
+	// SeniorityRule требование к уровню ревьюверов PR команды, nil — нет.
+	SeniorityRule *SeniorityRule `json:"seniority_rule,omitempty"`
 }
 
 type TeamMember struct {
 	UserID   uuid.UUID `json:"user_id"`
 	Username string    `json:"username"`
 	IsActive bool      `json:"is_active"`
+	// Seniority уровень участника. При добавлении в команду пустое значение
+	// не меняет уже заданный уровень.
+	Seniority string `json:"seniority,omitempty"`
+}
+
+// Уровни пользователей по возрастанию.
+const (
+	SeniorityJunior = "junior"
+	SeniorityMiddle = "middle"
+	SenioritySenior = "senior"
+)
+
+// SeniorityRank возвращает порядковый номер уровня: 0 для незаданного или
+// неизвестного, затем junior, middle, senior.
+func SeniorityRank(level string) int {
+	switch level {
+	case SeniorityJunior:
+		return 1
+	case SeniorityMiddle:
+		return 2
+	case SenioritySenior:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// SeniorityRule требование команды: среди ревьюверов PR не меньше Count
+// пользователей уровня Level или выше. Соблюдается и при создании PR, и
+// при переназначении.
+type SeniorityRule struct {
+	Level string `json:"level"`
+	Count int    `json:"count"`
+}
+
+// Satisfies сообщает, достаточно ли уровня level для правила.
+func (r *SeniorityRule) Satisfies(level string) bool {
+	return SeniorityRank(level) >= SeniorityRank(r.Level)
 }
 
 type PullRequest struct {
@@ -50,9 +92,62 @@ type PullRequestShort struct {
 	PullRequestName string    `json:"pull_request_name"`
 	AuthorID        uuid.UUID `json:"author_id"`
 	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// UserRemoval итог удаления пользователя: его открытые ревью и кому они
+// переданы.
+type UserRemoval struct {
+	User       User                  `json:"user"`
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+}
+
+// ReviewerReplacement открытое ревью, переданное другому ревьюверу.
+type ReviewerReplacement struct {
+	PullRequestID uuid.UUID `json:"pull_request_id"`
+	ReplacedBy    uuid.UUID `json:"replaced_by"`
+}
+
+// UserProfile учётная запись пользователя, в том числе удалённого.
+type UserProfile struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	Username  string     `json:"username"`
+	TeamName  string     `json:"team_name"`
+	IsActive  bool       `json:"is_active"`
+	Seniority string     `json:"seniority,omitempty"`
+	RemovedAt *time.Time `json:"removed_at,omitempty"`
+}
+
+// UserData всё, что хранилище знает о пользователе: профиль, PR, где он
+// автор, и PR, где он назначен ревьювером.
+type UserData struct {
+	Profile     UserProfile
+	AuthoredPRs []PullRequestShort
+	Reviews     []PullRequestShort
 }
 
 const (
 	StatusOpen   = "open"
 	StatusMerged = "merged"
 )
+
+// ReviewEvent изменение в ревью пользователя для потока /users/reviewStream.
+// ID монотонно растёт и используется для возобновления по Last-Event-ID.
+type ReviewEvent struct {
+	ID              int64     `json:"id"`
+	Type            string    `json:"type"`
+	UserID          uuid.UUID `json:"user_id"`
+	PullRequestID   uuid.UUID `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        uuid.UUID `json:"author_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	// OrgID организация PR; по ней фоновые подписчики восстанавливают контекст.
+	OrgID uuid.UUID `json:"-"`
+}
+
+// Типы ReviewEvent.
+const (
+	EventReviewAssigned   = "review_assigned"
+	EventReviewUnassigned = "review_unassigned"
+	EventPRMerged         = "pr_merged"
+)
@@ -8,6 +8,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrValidation помечает ошибки некорректных аргументов. Сервисы
+// оборачивают им ошибки валидатора, транспорты проверяют через errors.Is.
+var ErrValidation = errors.New("validation error")
+
 type Validator struct{}
 
 func NewValidator() *Validator {
@@ -63,6 +67,9 @@ func (v *Validator) ValidateTeam(team *Team) error {
 		seen[member.UserID] = true
 	}
 
+	if team.SeniorityRule != nil {
+		return v.ValidateSeniorityRule(team.SeniorityRule)
+	}
 	return nil
 }
 
@@ -77,6 +84,29 @@ func (v *Validator) ValidateTeamMember(member *TeamMember) error {
 	if len(member.Username) > 255 {
 		return errors.New("username too long (max 255 characters)")
 	}
+	if member.Seniority != "" {
+		return v.ValidateSeniority(member.Seniority)
+	}
+	return nil
+}
+
+// ValidateSeniority проверяет уровень пользователя.
+func (v *Validator) ValidateSeniority(level string) error {
+	if SeniorityRank(level) == 0 {
+		return fmt.Errorf("invalid seniority: %q, must be junior, middle or senior", level)
+	}
+	return nil
+}
+
+// ValidateSeniorityRule проверяет правило команды. Count не больше числа
+// ревьюверов PR, иначе правило нельзя выполнить.
+func (v *Validator) ValidateSeniorityRule(rule *SeniorityRule) error {
+	if err := v.ValidateSeniority(rule.Level); err != nil {
+		return err
+	}
+	if rule.Count < 1 || rule.Count > MaxReviewers {
+		return fmt.Errorf("seniority rule count must be between 1 and %d", MaxReviewers)
+	}
 	return nil
 }
 
@@ -98,11 +128,14 @@ func (v *Validator) ValidatePullRequest(pr *PullRequest) error {
 	return v.ValidatePRStatus(pr.Status)
 }
 
+// MaxReviewers сколько ревьюверов назначается на PR.
+const MaxReviewers = 2
+
 func (v *Validator) ValidateReviewersCount(reviewers []uuid.UUID) error {
-	if len(reviewers) < 2 {
+	if len(reviewers) < MaxReviewers {
 		return errors.New("not enough reviewers: minimum 2 required")
 	}
-	if len(reviewers) > 2 {
+	if len(reviewers) > MaxReviewers {
 		return errors.New("cannot assign more than 2 reviewers")
 	}
 	return nil
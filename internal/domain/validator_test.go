@@ -91,6 +91,40 @@ func TestValidateTeam_MemberWithNilUUID(t *testing.T) {
 	assert.Contains(t, err.Error(), "user_id cannot be nil")
 }
 
+func TestValidateTeam_InvalidSeniority(t *testing.T) {
+	validator := NewValidator()
+
+	team := &Team{
+		TeamName: "backend",
+		Members: []TeamMember{
+			{UserID: uuid.New(), Username: "Alice", IsActive: true, Seniority: "principal"},
+		},
+	}
+
+	err := validator.ValidateTeam(team)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid seniority")
+}
+
+func TestValidateSeniorityRule(t *testing.T) {
+	validator := NewValidator()
+
+	assert.NoError(t, validator.ValidateSeniorityRule(&SeniorityRule{Level: SenioritySenior, Count: 1}))
+	assert.NoError(t, validator.ValidateSeniorityRule(&SeniorityRule{Level: SeniorityMiddle, Count: MaxReviewers}))
+	assert.Error(t, validator.ValidateSeniorityRule(&SeniorityRule{Level: SenioritySenior, Count: 0}))
+	assert.Error(t, validator.ValidateSeniorityRule(&SeniorityRule{Level: SenioritySenior, Count: MaxReviewers + 1}))
+	assert.Error(t, validator.ValidateSeniorityRule(&SeniorityRule{Level: "", Count: 1}))
+}
+
+func TestSeniorityRule_Satisfies(t *testing.T) {
+	rule := &SeniorityRule{Level: SeniorityMiddle, Count: 1}
+
+	assert.True(t, rule.Satisfies(SenioritySenior))
+	assert.True(t, rule.Satisfies(SeniorityMiddle))
+	assert.False(t, rule.Satisfies(SeniorityJunior))
+	assert.False(t, rule.Satisfies(""))
+}
+
 func TestValidatePullRequest_Success(t *testing.T) {
 	validator := NewValidator()
 
@@ -0,0 +1,220 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+)
+
+// Broker публикует события в журнал и будит подписчиков. Подписчик
+// не получает события из уведомления, а дочитывает журнал после своего
+// последнего ID: так порядок и возобновление по Last-Event-ID одинаковы
+// для локальных событий, событий других реплик и пропущенных уведомлений.
+type Broker struct {
+	store Store
+
+	mu     sync.Mutex
+	subs   map[uuid.UUID]map[*Subscription]struct{}
+	done   chan struct{}
+	closed bool
+}
+
+func NewBroker(store Store) *Broker {
+	return &Broker{
+		store: store,
+		subs:  make(map[uuid.UUID]map[*Subscription]struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// Subscription подписка на события одного пользователя.
+type Subscription struct {
+	broker *Broker
+	userID uuid.UUID
+	wake   chan struct{}
+}
+
+// Wake сигнализирует, что в журнале могли появиться новые события.
+// Несколько событий подряд сливаются в один сигнал.
+func (s *Subscription) Wake() <-chan struct{} {
+	return s.wake
+}
+
+// Close отписывает подписчика.
+func (s *Subscription) Close() {
+	b := s.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs[s.userID], s)
+	if len(b.subs[s.userID]) == 0 {
+		delete(b.subs, s.userID)
+	}
+}
+
+// Subscribe подписывает на события пользователя.
+func (b *Broker) Subscribe(userID uuid.UUID) *Subscription {
+	sub := &Subscription{broker: b, userID: userID, wake: make(chan struct{}, 1)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subs[userID] == nil {
+		b.subs[userID] = make(map[*Subscription]struct{})
+	}
+	b.subs[userID][sub] = struct{}{}
+	return sub
+}
+
+// Done закрывается при остановке брокера: потоки должны завершиться,
+// чтобы graceful shutdown HTTP сервера не ждал их до таймаута.
+func (b *Broker) Done() <-chan struct{} {
+	return b.done
+}
+
+// Close завершает все потоки. Повторный вызов ничего не делает.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.closed {
+		b.closed = true
+		close(b.done)
+	}
+}
+
+// Publish реализует service.EventPublisher.
+func (b *Broker) Publish(ctx context.Context, events ...domain.ReviewEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	stored, err := b.store.Append(ctx, events)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to store review events", "count", len(events), "error", err)
+		return
+	}
+
+	// Для PostgreSQL подписчиков разбудит и уведомление; лишний сигнал
+	// обойдётся пустым чтением журнала.
+	for _, e := range stored {
+		b.wake(e.UserID)
+	}
+}
+
+// After возвращает события пользователя после afterID.
+func (b *Broker) After(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.ReviewEvent, error) {
+	return b.store.After(ctx, userID, afterID, limit)
+}
+
+// ReplaceUser заменяет пользователя на newID в журнале.
+func (b *Broker) ReplaceUser(ctx context.Context, userID, newID uuid.UUID) error {
+	return b.store.ReplaceUser(ctx, userID, newID)
+}
+
+// LastID возвращает ID последнего события в журнале.
+func (b *Broker) LastID(ctx context.Context) (int64, error) {
+	return b.store.LastID(ctx)
+}
+
+func (b *Broker) wake(userID uuid.UUID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs[userID] {
+		select {
+		case sub.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *Broker) wakeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subs := range b.subs {
+		for sub := range subs {
+			select {
+			case sub.wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// ListenPostgres будит подписчиков по уведомлениям других реплик, пока не
+// отменён ctx. После переподключения уведомления могли потеряться, поэтому
+// будятся все подписчики.
+func (b *Broker) ListenPostgres(ctx context.Context, dsn string) error {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Warn("Review events listener event", "event", ev, "error", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(NotifyChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	slog.Info("Listening for review events", "channel", NotifyChannel)
+
+	ping := time.NewTicker(90 * time.Second)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case n := <-listener.Notify:
+			if n == nil {
+				slog.Warn("Review events listener reconnected, waking all subscribers")
+				b.wakeAll()
+				continue
+			}
+
+			userID, err := uuid.Parse(n.Extra)
+			if err != nil {
+				slog.Error("Invalid review event notification", "payload", n.Extra, "error", err)
+				continue
+			}
+			b.wake(userID)
+
+		case <-ping.C:
+			go func() {
+				if err := listener.Ping(); err != nil {
+					slog.Warn("Review events listener ping failed", "error", err)
+				}
+			}()
+		}
+	}
+}
+
+// Cleanup периодически удаляет события старше retention, пока не отменён ctx.
+func (b *Broker) Cleanup(ctx context.Context, retention time.Duration) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := b.store.Cleanup(ctx, retention)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to cleanup review events", "error", err)
+			continue
+		}
+		slog.DebugContext(ctx, "Review events cleaned up", "deleted", deleted)
+	}
+}
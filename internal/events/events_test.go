@@ -0,0 +1,273 @@
+package events_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/events"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+func newSQLiteStore(t *testing.T) *events.SQLStore {
+	t.Helper()
+
+	cfg := &config.Config{DatabaseURL: "sqlite://" + filepath.Join(t.TempDir(), "test.db")}
+	db, err := cfg.ConnectDB()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	require.NoError(t, cfg.RunMigrations(db.DB))
+	return events.NewSQLiteStore(db.DB)
+}
+
+// newPostgresStore требует TEST_DATABASE_URL, как контрактные тесты репозитория.
+func newPostgresStore(t *testing.T) *events.SQLStore {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	cfg := &config.Config{DatabaseURL: dsn}
+	require.NoError(t, cfg.RunMigrations(db))
+	_, err = db.Exec(`TRUNCATE review_events`)
+	require.NoError(t, err)
+	return events.NewPostgresStore(db)
+}
+
+// TestSQLStore_OutOfOrderCommits проверяет, что читатель с курсором не
+// пропускает событие, добавление которого закоммитилось позже следующего.
+func TestSQLStore_OutOfOrderCommits(t *testing.T) {
+	stores := map[string]func(t *testing.T) *events.SQLStore{
+		"sqlite":   newSQLiteStore,
+		"postgres": newPostgresStore,
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore(t)
+			alice := uuid.New()
+			appendEvent := func(done chan<- error) {
+				_, err := store.Append(ctx, []domain.ReviewEvent{
+					{Type: domain.EventReviewAssigned, UserID: alice, PullRequestID: uuid.New()},
+				})
+				done <- err
+			}
+
+			// Первое добавление останавливается перед коммитом.
+			inserted, release := make(chan struct{}), make(chan struct{})
+			var once sync.Once
+			events.SetBeforeCommit(store, func() {
+				once.Do(func() {
+					close(inserted)
+					<-release
+				})
+			})
+			first, second := make(chan error, 1), make(chan error, 1)
+			go appendEvent(first)
+			<-inserted
+			go appendEvent(second)
+
+			// Без упорядочивания второе добавление закоммитилось бы сразу.
+			var secondErr error
+			secondDone := false
+			select {
+			case secondErr = <-second:
+				secondDone = true
+			case <-time.After(200 * time.Millisecond):
+			}
+
+			// Читатель приходит, пока первое добавление не закоммичено, и
+			// без упорядочивания сдвинул бы курсор за него. У SQLite одно
+			// соединение, и читатель ждёт коммита.
+			type result struct {
+				events []domain.ReviewEvent
+				err    error
+			}
+			read := make(chan result, 1)
+			go func() {
+				got, err := store.After(ctx, alice, 0, 10)
+				read <- result{got, err}
+			}()
+			var seen *result
+			select {
+			case r := <-read:
+				seen = &r
+			case <-time.After(200 * time.Millisecond):
+			}
+
+			close(release)
+			require.NoError(t, <-first)
+			if !secondDone {
+				secondErr = <-second
+			}
+			require.NoError(t, secondErr)
+			if seen == nil {
+				r := <-read
+				seen = &r
+			}
+			require.NoError(t, seen.err)
+
+			var cursor int64
+			if len(seen.events) > 0 {
+				cursor = seen.events[len(seen.events)-1].ID
+			}
+			rest, err := store.After(ctx, alice, cursor, 10)
+			require.NoError(t, err)
+			assert.Len(t, append(seen.events, rest...), 2)
+		})
+	}
+}
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) events.Store{
+		"memory": func(*testing.T) events.Store {
+			return events.NewMemoryStore()
+		},
+		"sqlite": func(t *testing.T) events.Store {
+			return newSQLiteStore(t)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore(t)
+
+			last, err := store.LastID(ctx)
+			require.NoError(t, err)
+			assert.Zero(t, last)
+
+			alice, bob := uuid.New(), uuid.New()
+			pr := uuid.New()
+			stored, err := store.Append(ctx, []domain.ReviewEvent{
+				{Type: domain.EventReviewAssigned, UserID: alice, PullRequestID: pr, PullRequestName: "Add search", AuthorID: bob},
+				{Type: domain.EventReviewAssigned, UserID: bob, PullRequestID: pr},
+				{Type: domain.EventPRMerged, UserID: alice, PullRequestID: pr},
+			})
+			require.NoError(t, err)
+			require.Len(t, stored, 3)
+			assert.Less(t, stored[0].ID, stored[1].ID)
+			assert.Less(t, stored[1].ID, stored[2].ID)
+			assert.False(t, stored[0].CreatedAt.IsZero())
+
+			got, err := store.After(ctx, alice, 0, 10)
+			require.NoError(t, err)
+			require.Len(t, got, 2)
+			assert.Equal(t, stored[0].ID, got[0].ID)
+			assert.Equal(t, "Add search", got[0].PullRequestName)
+			assert.Equal(t, bob, got[0].AuthorID)
+			assert.Equal(t, domain.EventPRMerged, got[1].Type)
+
+			got, err = store.After(ctx, alice, stored[0].ID, 10)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.Equal(t, stored[2].ID, got[0].ID)
+
+			got, err = store.After(ctx, alice, 0, 1)
+			require.NoError(t, err)
+			assert.Len(t, got, 1)
+
+			// События видны только в организации, где они произошли.
+			assert.Equal(t, tenant.DefaultOrgID, got[0].OrgID)
+			got, err = store.After(tenant.WithOrg(ctx, uuid.New()), alice, 0, 10)
+			require.NoError(t, err)
+			assert.Empty(t, got)
+
+			// Обезличивание bob затрагивает и его события, и его авторство.
+			anon := uuid.New()
+			require.NoError(t, store.ReplaceUser(tenant.WithOrg(ctx, uuid.New()), bob, anon))
+			got, err = store.After(ctx, bob, 0, 10)
+			require.NoError(t, err)
+			assert.Len(t, got, 1)
+
+			require.NoError(t, store.ReplaceUser(ctx, bob, anon))
+			got, err = store.After(ctx, bob, 0, 10)
+			require.NoError(t, err)
+			assert.Empty(t, got)
+			got, err = store.After(ctx, anon, 0, 10)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.Equal(t, stored[1].ID, got[0].ID)
+			got, err = store.After(ctx, alice, 0, 1)
+			require.NoError(t, err)
+			assert.Equal(t, anon, got[0].AuthorID)
+
+			last, err = store.LastID(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, stored[2].ID, last)
+
+			deleted, err := store.Cleanup(ctx, time.Hour)
+			require.NoError(t, err)
+			assert.Zero(t, deleted)
+
+			time.Sleep(10 * time.Millisecond)
+			deleted, err = store.Cleanup(ctx, time.Millisecond)
+			require.NoError(t, err)
+			assert.EqualValues(t, 3, deleted)
+		})
+	}
+}
+
+func TestBroker_WakesSubscribers(t *testing.T) {
+	ctx := context.Background()
+	broker := events.NewBroker(events.NewMemoryStore())
+
+	alice, bob := uuid.New(), uuid.New()
+	subA := broker.Subscribe(alice)
+	defer subA.Close()
+	subB := broker.Subscribe(bob)
+	defer subB.Close()
+
+	// Два события подряд сливаются в один сигнал.
+	broker.Publish(ctx,
+		domain.ReviewEvent{Type: domain.EventReviewAssigned, UserID: alice},
+		domain.ReviewEvent{Type: domain.EventReviewUnassigned, UserID: alice},
+	)
+
+	select {
+	case <-subA.Wake():
+	default:
+		t.Fatal("subscriber not woken")
+	}
+	select {
+	case <-subA.Wake():
+		t.Fatal("wake signals not coalesced")
+	case <-subB.Wake():
+		t.Fatal("other user's subscriber woken")
+	default:
+	}
+
+	got, err := broker.After(ctx, alice, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+}
+
+func TestBroker_Close(t *testing.T) {
+	broker := events.NewBroker(events.NewMemoryStore())
+
+	broker.Close()
+	broker.Close()
+
+	select {
+	case <-broker.Done():
+	default:
+		t.Fatal("Done not closed")
+	}
+}
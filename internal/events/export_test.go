@@ -0,0 +1,6 @@
+package events
+
+// SetBeforeCommit задаёт функцию, которую Append вызывает перед коммитом.
+func SetBeforeCommit(s *SQLStore, hook func()) {
+	s.beforeCommit = hook
+}
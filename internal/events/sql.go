@@ -0,0 +1,158 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// NotifyChannel канал LISTEN/NOTIFY, в который PostgreSQL-журнал
+// сообщает user_id получателя каждого нового события.
+const NotifyChannel = "pr_service_review_events"
+
+// appendLockKey ключ транзакционной advisory-блокировки, которой
+// PostgreSQL-журнал упорядочивает добавления.
+const appendLockKey int64 = 0x5052_4576 // "PREv"
+
+// SQLStore журнал в таблице review_events (PostgreSQL или SQLite).
+type SQLStore struct {
+	db *sql.DB
+	// notify отправлять pg_notify в транзакции добавления: уведомления
+	// уходят репликам только после коммита.
+	notify bool
+	// serialize брать appendLockKey до вставки. BIGSERIAL выдаёт ID до
+	// коммита, и без блокировки транзакция с меньшим ID может
+	// закоммититься позже: читатель с курсором id > N её пропустит.
+	// SQLite и так допускает одного писателя.
+	serialize bool
+	// beforeCommit вызывается перед коммитом добавления; только для тестов.
+	beforeCommit func()
+}
+
+// NewPostgresStore создаёт журнал в PostgreSQL с уведомлением реплик.
+func NewPostgresStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db, notify: true, serialize: true}
+}
+
+// NewSQLiteStore создаёт журнал в SQLite. Реплик у SQLite нет,
+// поэтому события доставляются только локально.
+func NewSQLiteStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+// Append реализует Store.
+func (s *SQLStore) Append(ctx context.Context, events []domain.ReviewEvent) ([]domain.ReviewEvent, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			slog.ErrorContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	if s.serialize {
+		// Блокировка держится до коммита, поэтому ID растут в порядке коммитов.
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, appendLockKey); err != nil {
+			return nil, fmt.Errorf("failed to lock review events: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	stored := make([]domain.ReviewEvent, len(events))
+	for i, e := range events {
+		if e.OrgID == uuid.Nil {
+			e.OrgID = tenant.OrgID(ctx)
+		}
+		e.CreatedAt = now
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO review_events (event_type, user_id, pull_request_id, pull_request_name, author_id, created_at, org_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, e.Type, e.UserID, e.PullRequestID, e.PullRequestName, e.AuthorID, e.CreatedAt, e.OrgID.String()).Scan(&e.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert review event: %w", err)
+		}
+
+		if s.notify {
+			if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, NotifyChannel, e.UserID.String()); err != nil {
+				return nil, fmt.Errorf("failed to notify: %w", err)
+			}
+		}
+		stored[i] = e
+	}
+
+	if s.beforeCommit != nil {
+		s.beforeCommit()
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return stored, nil
+}
+
+// After реализует Store.
+func (s *SQLStore) After(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.ReviewEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, event_type, user_id, pull_request_id, pull_request_name, author_id, created_at, org_id
+		FROM review_events
+		WHERE user_id = $1 AND id > $2 AND org_id = $3
+		ORDER BY id
+		LIMIT $4
+	`, userID, afterID, tenant.OrgID(ctx).String(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.ReviewEvent
+	for rows.Next() {
+		var e domain.ReviewEvent
+		if err := rows.Scan(&e.ID, &e.Type, &e.UserID, &e.PullRequestID, &e.PullRequestName, &e.AuthorID, &e.CreatedAt, &e.OrgID); err != nil {
+			return nil, fmt.Errorf("failed to scan review event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// LastID реализует Store.
+func (s *SQLStore) LastID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM review_events`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get last review event: %w", err)
+	}
+	return id, nil
+}
+
+// Cleanup реализует Store.
+func (s *SQLStore) Cleanup(ctx context.Context, olderThan time.Duration) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM review_events WHERE created_at < $1`, time.Now().UTC().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup review events: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// ReplaceUser реализует Store.
+func (s *SQLStore) ReplaceUser(ctx context.Context, userID, newID uuid.UUID) error {
+	orgID := tenant.OrgID(ctx).String()
+	for _, query := range []string{
+		`UPDATE review_events SET user_id = $1 WHERE user_id = $2 AND org_id = $3`,
+		`UPDATE review_events SET author_id = $1 WHERE author_id = $2 AND org_id = $3`,
+	} {
+		if _, err := s.db.ExecContext(ctx, query, newID, userID, orgID); err != nil {
+			return fmt.Errorf("failed to replace user in review events: %w", err)
+		}
+	}
+	return nil
+}
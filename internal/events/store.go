@@ -0,0 +1,124 @@
+// Package events хранит события о назначениях ревьюверов и доставляет их
+// подписчикам потока /users/reviewStream, в том числе между репликами.
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Store журнал событий. ID присваиваются при добавлении и монотонно растут.
+type Store interface {
+	// Append сохраняет события и возвращает их с присвоенными ID.
+	Append(ctx context.Context, events []domain.ReviewEvent) ([]domain.ReviewEvent, error)
+	// After возвращает до limit событий пользователя в организации из ctx с ID больше afterID по возрастанию ID.
+	After(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.ReviewEvent, error)
+	// LastID возвращает ID последнего события, 0 если журнал пуст.
+	LastID(ctx context.Context) (int64, error)
+	// Cleanup удаляет события старше olderThan.
+	Cleanup(ctx context.Context, olderThan time.Duration) (int64, error)
+	// ReplaceUser заменяет пользователя на newID в событиях организации из
+	// ctx — и как получателя, и как автора PR.
+	ReplaceUser(ctx context.Context, userID, newID uuid.UUID) error
+}
+
+// MemoryStore журнал в памяти процесса. Подходит для одной реплики
+// и STORAGE=memory; после рестарта возобновление невозможно.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	events []domain.ReviewEvent
+	lastID int64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Append реализует Store.
+func (s *MemoryStore) Append(ctx context.Context, events []domain.ReviewEvent) ([]domain.ReviewEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	stored := make([]domain.ReviewEvent, len(events))
+	for i, e := range events {
+		if e.OrgID == uuid.Nil {
+			e.OrgID = tenant.OrgID(ctx)
+		}
+		s.lastID++
+		e.ID = s.lastID
+		e.CreatedAt = now
+		stored[i] = e
+	}
+	s.events = append(s.events, stored...)
+	return stored, nil
+}
+
+// After реализует Store.
+func (s *MemoryStore) After(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.ReviewEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	var result []domain.ReviewEvent
+	for _, e := range s.events {
+		if e.ID > afterID && e.UserID == userID && e.OrgID == orgID {
+			result = append(result, e)
+			if len(result) == limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// LastID реализует Store.
+func (s *MemoryStore) LastID(context.Context) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastID, nil
+}
+
+// Cleanup реализует Store.
+func (s *MemoryStore) Cleanup(_ context.Context, olderThan time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	kept := s.events[:0]
+	for _, e := range s.events {
+		if e.CreatedAt.After(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	deleted := int64(len(s.events) - len(kept))
+	s.events = kept
+	return deleted, nil
+}
+
+// ReplaceUser реализует Store.
+func (s *MemoryStore) ReplaceUser(ctx context.Context, userID, newID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orgID := tenant.OrgID(ctx)
+	for i := range s.events {
+		e := &s.events[i]
+		if e.OrgID != orgID {
+			continue
+		}
+		if e.UserID == userID {
+			e.UserID = newID
+		}
+		if e.AuthorID == userID {
+			e.AuthorID = newID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package grpcserver
+
+import (
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	reviewerv1 "github.com/T1mof/pr-reviewer-service/api/reviewer/v1"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+)
+
+// parseUUID разбирает идентификатор из запроса, ошибка — InvalidArgument.
+func parseUUID(value, field string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "%s is required", field)
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s UUID: %s", field, value)
+	}
+	return id, nil
+}
+
+func teamFromProto(t *reviewerv1.Team) (*domain.Team, error) {
+	team := &domain.Team{
+		TeamName: t.GetTeamName(),
+		Members:  make([]domain.TeamMember, len(t.GetMembers())),
+	}
+	if r := t.GetSeniorityRule(); r != nil {
+		team.SeniorityRule = &domain.SeniorityRule{Level: r.GetLevel(), Count: int(r.GetCount())}
+	}
+
+	for i, m := range t.GetMembers() {
+		userID, err := parseUUID(m.GetUserId(), "user_id")
+		if err != nil {
+			return nil, err
+		}
+
+		team.Members[i] = domain.TeamMember{
+			UserID:    userID,
+			Username:  m.GetUsername(),
+			IsActive:  m.GetIsActive(),
+			Seniority: m.GetSeniority(),
+		}
+	}
+
+	return team, nil
+}
+
+func teamToProto(team *domain.Team) *reviewerv1.Team {
+	t := &reviewerv1.Team{
+		TeamName: team.TeamName,
+		Members:  make([]*reviewerv1.TeamMember, len(team.Members)),
+	}
+	if team.SeniorityRule != nil {
+		t.SeniorityRule = &reviewerv1.SeniorityRule{
+			Level: team.SeniorityRule.Level,
+			Count: int32(team.SeniorityRule.Count),
+		}
+	}
+	for i, m := range team.Members {
+		t.Members[i] = &reviewerv1.TeamMember{
+			UserId:    m.UserID.String(),
+			Username:  m.Username,
+			IsActive:  m.IsActive,
+			Seniority: m.Seniority,
+		}
+	}
+	return t
+}
+
+func userToProto(user *domain.User) *reviewerv1.User {
+	return &reviewerv1.User{
+		UserId:    user.UserID.String(),
+		Username:  user.Username,
+		TeamName:  user.TeamName,
+		IsActive:  user.IsActive,
+		Seniority: user.Seniority,
+	}
+}
+
+func removalToProto(removal *domain.UserRemoval) *reviewerv1.RemoveUserResponse {
+	resp := &reviewerv1.RemoveUserResponse{
+		User:       userToProto(&removal.User),
+		Reassigned: make([]*reviewerv1.ReviewerReplacement, len(removal.Reassigned)),
+	}
+	for i, r := range removal.Reassigned {
+		resp.Reassigned[i] = &reviewerv1.ReviewerReplacement{
+			PullRequestId: r.PullRequestID.String(),
+			ReplacedBy:    r.ReplacedBy.String(),
+		}
+	}
+	return resp
+}
+
+func statusToProto(s string) reviewerv1.PullRequestStatus {
+	switch s {
+	case domain.StatusOpen:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case domain.StatusMerged:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	default:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
+
+func prToProto(pr *domain.PullRequestWithReviewers) *reviewerv1.PullRequest {
+	p := &reviewerv1.PullRequest{
+		PullRequestId:     pr.PullRequestID.String(),
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorID.String(),
+		Status:            statusToProto(pr.Status),
+		AssignedReviewers: make([]string, len(pr.AssignedReviewers)),
+		CreatedAt:         timestamppb.New(pr.CreatedAt),
+	}
+	for i, id := range pr.AssignedReviewers {
+		p.AssignedReviewers[i] = id.String()
+	}
+	if pr.MergedAt != nil {
+		p.MergedAt = timestamppb.New(*pr.MergedAt)
+	}
+	return p
+}
+
+func prShortToProto(pr *domain.PullRequestShort) *reviewerv1.PullRequestShort {
+	return &reviewerv1.PullRequestShort{
+		PullRequestId:   pr.PullRequestID.String(),
+		PullRequestName: pr.PullRequestName,
+		AuthorId:        pr.AuthorID.String(),
+		Status:          statusToProto(pr.Status),
+	}
+}
+
+func statsToProto(stats *domain.Statistics) *reviewerv1.GetStatisticsResponse {
+	resp := &reviewerv1.GetStatisticsResponse{
+		PrStats: &reviewerv1.PRStats{
+			TotalOpen:         int64(stats.PRStats.TotalOpen),
+			TotalMerged:       int64(stats.PRStats.TotalMerged),
+			TotalPrs:          int64(stats.PRStats.TotalPRs),
+			AvgMergeTimeHours: stats.PRStats.AvgMergeTimeHours,
+		},
+		UserStats:   make([]*reviewerv1.UserAssignmentStats, len(stats.UserStats)),
+		TotalUsers:  int64(stats.TotalUsers),
+		TotalTeams:  int64(stats.TotalTeams),
+		ActiveUsers: int64(stats.ActiveUsers),
+	}
+	for i, u := range stats.UserStats {
+		resp.UserStats[i] = &reviewerv1.UserAssignmentStats{
+			UserId:            u.UserID.String(),
+			Username:          u.Username,
+			TeamName:          u.TeamName,
+			TotalAssignments:  int64(u.TotalAssignments),
+			OpenAssignments:   int64(u.OpenAssignments),
+			MergedAssignments: int64(u.MergedAssignments),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,53 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+)
+
+// errorStatuses сопоставляет коды ошибок сервиса с gRPC кодами.
+// Сообщение статуса начинается с кода ошибки, как поле code в HTTP API.
+var errorStatuses = map[string]struct {
+	code    codes.Code
+	message string
+}{
+	"TEAM_EXISTS":           {codes.AlreadyExists, "team_name already exists"},
+	"PR_EXISTS":             {codes.AlreadyExists, "PR id already exists"},
+	"USER_EXISTS":           {codes.AlreadyExists, "user_id is already taken"},
+	"TEAM_NOT_FOUND":        {codes.NotFound, "team not found"},
+	"USER_NOT_FOUND":        {codes.NotFound, "user not found"},
+	"PR_NOT_FOUND":          {codes.NotFound, "PR not found"},
+	"REVIEWER_NOT_FOUND":    {codes.NotFound, "reviewer not found"},
+	"USER_REMOVED":          {codes.FailedPrecondition, "user was removed"},
+	"PR_MERGED":             {codes.FailedPrecondition, "cannot reassign on merged PR"},
+	"NOT_ASSIGNED":          {codes.FailedPrecondition, "reviewer is not assigned to this PR"},
+	"NO_CANDIDATE":          {codes.FailedPrecondition, "no active replacement candidate in team"},
+	"NO_SENIOR_CANDIDATE":   {codes.FailedPrecondition, "no candidate of required seniority in team"},
+	"USER_HAS_OPEN_REVIEWS": {codes.Aborted, "user got new reviews during removal, retry"},
+}
+
+// toStatus переводит ошибку сервиса в gRPC статус. Сервис оборачивает
+// ошибки репозитория через %w, поэтому код ищется по всей цепочке.
+func toStatus(err error) error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if st, ok := errorStatuses[e.Error()]; ok {
+			return status.Error(st.code, e.Error()+": "+st.message)
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, domain.ErrValidation):
+		return status.Error(codes.InvalidArgument, "INVALID_REQUEST: "+err.Error())
+	default:
+		return status.Error(codes.Internal, "INTERNAL_ERROR: "+err.Error())
+	}
+}
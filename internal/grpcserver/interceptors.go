@@ -0,0 +1,173 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	reviewerv1 "github.com/T1mof/pr-reviewer-service/api/reviewer/v1"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+	"github.com/T1mof/pr-reviewer-service/internal/tracing"
+)
+
+// Ключи metadata совпадают с HTTP заголовками в нижнем регистре.
+const (
+	RequestIDKey   = "x-request-id"
+	TraceparentKey = "traceparent"
+	AdminTokenKey  = "x-admin-token"
+	// AuthorizationKey несёт токен организации: "Bearer org_...".
+	AuthorizationKey = "authorization"
+
+	maxRequestIDLength = 128
+)
+
+// adminMethods методы, требующие admin токен (как /users/setIsActive и
+// /users/remove в HTTP API).
+var adminMethods = map[string]bool{
+	reviewerv1.ReviewerService_SetUserActive_FullMethodName: true,
+	reviewerv1.ReviewerService_RemoveUser_FullMethodName:    true,
+}
+
+// recoveryInterceptor превращает панику обработчика в codes.Internal.
+func recoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(ctx, "gRPC handler panic", "method", info.FullMethod, "panic", r, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "INTERNAL_ERROR: internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// requestContextInterceptor аналог middleware.RequestContext для gRPC:
+// принимает или генерирует x-request-id, разбирает traceparent и
+// возвращает оба значения в заголовках ответа.
+func requestContextInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		requestID := firstValue(md, RequestIDKey)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = tracing.NewRequestID()
+		}
+
+		tc, ok := tracing.ParseTraceparent(firstValue(md, TraceparentKey))
+		if ok {
+			tc = tc.Child()
+		} else {
+			tc = tracing.NewTraceContext()
+		}
+
+		ctx = tracing.WithRequestID(ctx, requestID)
+		ctx = tracing.WithTraceContext(ctx, tc)
+
+		if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID, TraceparentKey, tc.Traceparent())); err != nil {
+			slog.WarnContext(ctx, "Failed to set gRPC response header", "error", err)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// accessLogInterceptor пишет одну структурированную запись на каждый вызов.
+func accessLogInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK, codes.Canceled:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+
+		attrs := []any{
+			"method", info.FullMethod,
+			"code", code.String(),
+			"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+		}
+		if err != nil {
+			attrs = append(attrs, "error", status.Convert(err).Message())
+		}
+		slog.Log(ctx, level, "gRPC request", attrs...)
+
+		return resp, err
+	}
+}
+
+// adminAuthInterceptor проверяет x-admin-token для методов из adminMethods.
+func adminAuthInterceptor(adminToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !adminMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		token := firstValue(md, AdminTokenKey)
+
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "UNAUTHORIZED: x-admin-token metadata required")
+		}
+		if token != adminToken {
+			return nil, status.Error(codes.Unauthenticated, "UNAUTHORIZED: invalid admin token")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// tenantInterceptor определяет организацию по токену из metadata
+// authorization. Вызовы без токена относятся к организации по умолчанию,
+// только если реестр создан с tenant.WithDefaultFallback.
+func tenantInterceptor(registry *tenant.Registry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		auth := firstValue(md, AuthorizationKey)
+		if !strings.HasPrefix(auth, "Bearer ") {
+			if registry.DefaultFallback() {
+				return handler(tenant.WithDefault(ctx), req)
+			}
+			return nil, status.Error(codes.Unauthenticated, "UNAUTHORIZED: organization token required")
+		}
+
+		org, err := registry.Authenticate(ctx, strings.TrimPrefix(auth, "Bearer "))
+		if err != nil {
+			if err.Error() == "ORG_NOT_FOUND" {
+				return nil, status.Error(codes.Unauthenticated, "UNAUTHORIZED: invalid organization token")
+			}
+			slog.ErrorContext(ctx, "Failed to authenticate organization", "error", err)
+			return nil, status.Error(codes.Internal, "INTERNAL_ERROR: failed to authenticate organization")
+		}
+
+		return handler(tenant.WithOrg(ctx, org.OrgID), req)
+	}
+}
+
+// defaultTenantInterceptor относит все вызовы к организации по умолчанию,
+// когда организации выключены.
+func defaultTenantInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(tenant.WithDefault(ctx), req)
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,249 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	reviewerv1 "github.com/T1mof/pr-reviewer-service/api/reviewer/v1"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Server реализует reviewerv1.ReviewerServiceServer поверх ServiceInterface.
+type Server struct {
+	reviewerv1.UnimplementedReviewerServiceServer
+
+	service service.ServiceInterface
+	remover service.UserRemover
+}
+
+func NewServer(svc service.ServiceInterface) *Server {
+	return &Server{service: svc}
+}
+
+// Option настраивает необязательные возможности gRPC сервера.
+type Option func(*options)
+
+type options struct {
+	orgs    *tenant.Registry
+	remover service.UserRemover
+}
+
+// WithOrganizations разделяет данные по организациям: вызовы с metadata
+// authorization: Bearer <токен организации> работают только с её данными.
+func WithOrganizations(r *tenant.Registry) Option {
+	return func(o *options) {
+		o.orgs = r
+	}
+}
+
+// WithUserRemoval включает RemoveUser: удаление пользователя с передачей
+// его открытых ревью другим участникам команды. Без опции метод отвечает
+// Unimplemented.
+func WithUserRemoval(r service.UserRemover) Option {
+	return func(o *options) {
+		o.remover = r
+	}
+}
+
+// New создаёт gRPC сервер с зарегистрированным ReviewerService.
+// Методы из adminMethods требуют metadata x-admin-token.
+func New(svc service.ServiceInterface, adminToken string, opts ...Option) *grpc.Server {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	interceptors := []grpc.UnaryServerInterceptor{
+		recoveryInterceptor(),
+		requestContextInterceptor(),
+		accessLogInterceptor(),
+		adminAuthInterceptor(adminToken),
+	}
+	if o.orgs != nil {
+		interceptors = append(interceptors, tenantInterceptor(o.orgs))
+	} else {
+		interceptors = append(interceptors, defaultTenantInterceptor())
+	}
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	server := NewServer(svc)
+	server.remover = o.remover
+	reviewerv1.RegisterReviewerServiceServer(srv, server)
+	return srv
+}
+
+// ========================================
+// Team Methods
+// ========================================
+
+func (s *Server) CreateTeam(ctx context.Context, req *reviewerv1.CreateTeamRequest) (*reviewerv1.CreateTeamResponse, error) {
+	if req.GetTeam() == nil {
+		return nil, status.Error(codes.InvalidArgument, "team is required")
+	}
+
+	team, err := teamFromProto(req.GetTeam())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.CreateTeam(ctx, team); err != nil {
+		return nil, toStatus(err)
+	}
+
+	slog.InfoContext(ctx, "Team created successfully", "team_name", team.TeamName, "members_count", len(team.Members))
+	return &reviewerv1.CreateTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.GetTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "team_name is required")
+	}
+
+	team, err := s.service.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.GetTeamResponse{Team: teamToProto(team)}, nil
+}
+
+// ========================================
+// User Methods
+// ========================================
+
+func (s *Server) SetUserActive(ctx context.Context, req *reviewerv1.SetUserActiveRequest) (*reviewerv1.SetUserActiveResponse, error) {
+	userID, err := parseUUID(req.GetUserId(), "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.service.SetUserActive(ctx, userID, req.GetIsActive())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.SetUserActiveResponse{User: userToProto(user)}, nil
+}
+
+func (s *Server) GetUserReviews(ctx context.Context, req *reviewerv1.GetUserReviewsRequest) (*reviewerv1.GetUserReviewsResponse, error) {
+	userID, err := parseUUID(req.GetUserId(), "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	prs, err := s.service.GetUserReviews(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &reviewerv1.GetUserReviewsResponse{
+		UserId:       userID.String(),
+		PullRequests: make([]*reviewerv1.PullRequestShort, len(prs)),
+	}
+	for i := range prs {
+		resp.PullRequests[i] = prShortToProto(&prs[i])
+	}
+	return resp, nil
+}
+
+func (s *Server) RemoveUser(ctx context.Context, req *reviewerv1.RemoveUserRequest) (*reviewerv1.RemoveUserResponse, error) {
+	if s.remover == nil {
+		return nil, status.Error(codes.Unimplemented, "user removal is not enabled")
+	}
+
+	userID, err := parseUUID(req.GetUserId(), "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	removal, err := s.remover.RemoveUser(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	slog.InfoContext(ctx, "User removed", "user_id", userID, "reassigned_reviews", len(removal.Reassigned))
+	return removalToProto(removal), nil
+}
+
+// ========================================
+// PullRequest Methods
+// ========================================
+
+func (s *Server) CreatePullRequest(ctx context.Context, req *reviewerv1.CreatePullRequestRequest) (*reviewerv1.CreatePullRequestResponse, error) {
+	prID, err := parseUUID(req.GetPullRequestId(), "pull_request_id")
+	if err != nil {
+		return nil, err
+	}
+	authorID, err := parseUUID(req.GetAuthorId(), "author_id")
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := s.service.CreatePR(ctx, prID, req.GetPullRequestName(), authorID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	slog.InfoContext(ctx, "PR created successfully", "pr_id", prID, "reviewers_count", len(pr.AssignedReviewers))
+	return &reviewerv1.CreatePullRequestResponse{Pr: prToProto(pr)}, nil
+}
+
+func (s *Server) MergePullRequest(ctx context.Context, req *reviewerv1.MergePullRequestRequest) (*reviewerv1.MergePullRequestResponse, error) {
+	prID, err := parseUUID(req.GetPullRequestId(), "pull_request_id")
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := s.service.MergePR(ctx, prID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.MergePullRequestResponse{Pr: prToProto(pr)}, nil
+}
+
+func (s *Server) ReassignReviewer(ctx context.Context, req *reviewerv1.ReassignReviewerRequest) (*reviewerv1.ReassignReviewerResponse, error) {
+	prID, err := parseUUID(req.GetPullRequestId(), "pull_request_id")
+	if err != nil {
+		return nil, err
+	}
+	oldUserID, err := parseUUID(req.GetOldUserId(), "old_user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	pr, newReviewerID, err := s.service.ReassignReviewer(ctx, prID, oldUserID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	slog.InfoContext(ctx, "Reviewer reassigned", "pr_id", prID, "old_reviewer", oldUserID, "new_reviewer", newReviewerID)
+	return &reviewerv1.ReassignReviewerResponse{
+		Pr:         prToProto(pr),
+		ReplacedBy: newReviewerID.String(),
+	}, nil
+}
+
+// ========================================
+// Stats Methods
+// ========================================
+
+func (s *Server) GetStatistics(ctx context.Context, _ *reviewerv1.GetStatisticsRequest) (*reviewerv1.GetStatisticsResponse, error) {
+	stats, err := s.service.GetStatistics(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return statsToProto(stats), nil
+}
+
+// ========================================
+// Compile-time interface check
+// ========================================
+
+var _ reviewerv1.ReviewerServiceServer = (*Server)(nil)
@@ -0,0 +1,359 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	reviewerv1 "github.com/T1mof/pr-reviewer-service/api/reviewer/v1"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// ==================== Mock Service ====================
+
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) CreateTeam(ctx context.Context, team *domain.Team) error {
+	args := m.Called(ctx, team)
+	return args.Error(0)
+}
+
+func (m *MockService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockService) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool) (*domain.User, error) {
+	args := m.Called(ctx, userID, isActive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockService) RemoveUser(ctx context.Context, userID uuid.UUID) (*domain.UserRemoval, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserRemoval), args.Error(1)
+}
+
+func (m *MockService) CreatePR(ctx context.Context, prID uuid.UUID, prName string, authorID uuid.UUID) (*domain.PullRequestWithReviewers, error) {
+	args := m.Called(ctx, prID, prName, authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequestWithReviewers), args.Error(1)
+}
+
+func (m *MockService) MergePR(ctx context.Context, prID uuid.UUID) (*domain.PullRequestWithReviewers, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequestWithReviewers), args.Error(1)
+}
+
+func (m *MockService) ReassignReviewer(ctx context.Context, prID, oldUserID uuid.UUID) (*domain.PullRequestWithReviewers, uuid.UUID, error) {
+	args := m.Called(ctx, prID, oldUserID)
+	if args.Get(0) == nil {
+		return nil, uuid.Nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.PullRequestWithReviewers), args.Get(1).(uuid.UUID), args.Error(2)
+}
+
+func (m *MockService) GetUserReviews(ctx context.Context, userID uuid.UUID) ([]domain.PullRequestShort, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PullRequestShort), args.Error(1)
+}
+
+func (m *MockService) GetStatistics(ctx context.Context) (*domain.Statistics, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Statistics), args.Error(1)
+}
+
+// ==================== Helpers ====================
+
+func newTestClient(t *testing.T, svc *MockService, opts ...Option) reviewerv1.ReviewerServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := New(svc, "test-token", opts...)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return reviewerv1.NewReviewerServiceClient(conn)
+}
+
+// ==================== Tests ====================
+
+func TestCreatePullRequest_Success(t *testing.T) {
+	mockService := new(MockService)
+	client := newTestClient(t, mockService)
+
+	prID, authorID := uuid.New(), uuid.New()
+	reviewers := []uuid.UUID{uuid.New(), uuid.New()}
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mockService.On("CreatePR", mock.Anything, prID, "Add feature", authorID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID:     prID,
+		PullRequestName:   "Add feature",
+		AuthorID:          authorID,
+		Status:            domain.StatusOpen,
+		AssignedReviewers: reviewers,
+		CreatedAt:         createdAt,
+	}, nil)
+
+	resp, err := client.CreatePullRequest(context.Background(), &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   prID.String(),
+		PullRequestName: "Add feature",
+		AuthorId:        authorID.String(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, prID.String(), resp.GetPr().GetPullRequestId())
+	assert.Equal(t, reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, resp.GetPr().GetStatus())
+	assert.Equal(t, []string{reviewers[0].String(), reviewers[1].String()}, resp.GetPr().GetAssignedReviewers())
+	assert.True(t, createdAt.Equal(resp.GetPr().GetCreatedAt().AsTime()))
+	assert.Nil(t, resp.GetPr().GetMergedAt())
+	mockService.AssertExpectations(t)
+}
+
+func TestCreatePullRequest_InvalidUUID(t *testing.T) {
+	mockService := new(MockService)
+	client := newTestClient(t, mockService)
+
+	_, err := client.CreatePullRequest(context.Background(), &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "not-a-uuid",
+		PullRequestName: "Add feature",
+		AuthorId:        uuid.NewString(),
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockService.AssertNotCalled(t, "CreatePR")
+}
+
+func TestCreateTeam_Seniority(t *testing.T) {
+	mockService := new(MockService)
+	client := newTestClient(t, mockService)
+
+	userID := uuid.New()
+	want := &domain.Team{
+		TeamName:      "backend",
+		Members:       []domain.TeamMember{{UserID: userID, Username: "alice", IsActive: true, Seniority: domain.SenioritySenior}},
+		SeniorityRule: &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 1},
+	}
+	mockService.On("CreateTeam", mock.Anything, want).Return(nil)
+
+	resp, err := client.CreateTeam(context.Background(), &reviewerv1.CreateTeamRequest{Team: &reviewerv1.Team{
+		TeamName:      "backend",
+		Members:       []*reviewerv1.TeamMember{{UserId: userID.String(), Username: "alice", IsActive: true, Seniority: "senior"}},
+		SeniorityRule: &reviewerv1.SeniorityRule{Level: "senior", Count: 1},
+	}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "senior", resp.GetTeam().GetMembers()[0].GetSeniority())
+	assert.Equal(t, "senior", resp.GetTeam().GetSeniorityRule().GetLevel())
+	assert.Equal(t, int32(1), resp.GetTeam().GetSeniorityRule().GetCount())
+	mockService.AssertExpectations(t)
+}
+
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{errors.New("PR_EXISTS"), codes.AlreadyExists},
+		{errors.New("TEAM_EXISTS"), codes.AlreadyExists},
+		{errors.New("USER_EXISTS"), codes.AlreadyExists},
+		{errors.New("PR_NOT_FOUND"), codes.NotFound},
+		{fmt.Errorf("failed to set user active: %w", errors.New("USER_NOT_FOUND")), codes.NotFound},
+		{errors.New("PR_MERGED"), codes.FailedPrecondition},
+		{errors.New("NOT_ASSIGNED"), codes.FailedPrecondition},
+		{errors.New("NO_CANDIDATE"), codes.FailedPrecondition},
+		{errors.New("NO_SENIOR_CANDIDATE"), codes.FailedPrecondition},
+		{fmt.Errorf("%w: %w", domain.ErrValidation, errors.New("pull_request_name cannot be empty")), codes.InvalidArgument},
+		{fmt.Errorf("%w: user_id cannot be nil UUID", domain.ErrValidation), codes.InvalidArgument},
+		{errors.New("column cannot be scanned"), codes.Internal},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{errors.New("connection refused"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			st := status.Convert(toStatus(tt.err))
+			assert.Equal(t, tt.code, st.Code())
+		})
+	}
+}
+
+func TestReassignReviewer_NoCandidate(t *testing.T) {
+	mockService := new(MockService)
+	client := newTestClient(t, mockService)
+
+	prID, oldUserID := uuid.New(), uuid.New()
+	mockService.On("ReassignReviewer", mock.Anything, prID, oldUserID).Return(nil, uuid.Nil, errors.New("NO_CANDIDATE"))
+
+	_, err := client.ReassignReviewer(context.Background(), &reviewerv1.ReassignReviewerRequest{
+		PullRequestId: prID.String(),
+		OldUserId:     oldUserID.String(),
+	})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Contains(t, st.Message(), "NO_CANDIDATE")
+}
+
+func TestSetUserActive_RequiresAdminToken(t *testing.T) {
+	mockService := new(MockService)
+	client := newTestClient(t, mockService)
+
+	userID := uuid.New()
+	req := &reviewerv1.SetUserActiveRequest{UserId: userID.String(), IsActive: false}
+
+	_, err := client.SetUserActive(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), AdminTokenKey, "wrong")
+	_, err = client.SetUserActive(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	mockService.On("SetUserActive", mock.Anything, userID, false).Return(&domain.User{
+		UserID: userID, Username: "alice", TeamName: "backend", IsActive: false,
+	}, nil)
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), AdminTokenKey, "test-token")
+	resp, err := client.SetUserActive(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "backend", resp.GetUser().GetTeamName())
+	assert.False(t, resp.GetUser().GetIsActive())
+}
+
+func TestRemoveUser(t *testing.T) {
+	mockService := new(MockService)
+	client := newTestClient(t, mockService, WithUserRemoval(mockService))
+
+	userID, prID, replacement := uuid.New(), uuid.New(), uuid.New()
+	req := &reviewerv1.RemoveUserRequest{UserId: userID.String()}
+
+	_, err := client.RemoveUser(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	mockService.On("RemoveUser", mock.Anything, userID).Return(&domain.UserRemoval{
+		User:       domain.User{UserID: userID, Username: "alice", TeamName: "backend"},
+		Reassigned: []domain.ReviewerReplacement{{PullRequestID: prID, ReplacedBy: replacement}},
+	}, nil)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), AdminTokenKey, "test-token")
+	resp, err := client.RemoveUser(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, userID.String(), resp.GetUser().GetUserId())
+	assert.False(t, resp.GetUser().GetIsActive())
+	require.Len(t, resp.GetReassigned(), 1)
+	assert.Equal(t, prID.String(), resp.GetReassigned()[0].GetPullRequestId())
+	assert.Equal(t, replacement.String(), resp.GetReassigned()[0].GetReplacedBy())
+
+	missing := uuid.New()
+	mockService.On("RemoveUser", mock.Anything, missing).Return(nil, errors.New("USER_NOT_FOUND"))
+	_, err = client.RemoveUser(ctx, &reviewerv1.RemoveUserRequest{UserId: missing.String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRemoveUser_NotEnabled(t *testing.T) {
+	client := newTestClient(t, new(MockService))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), AdminTokenKey, "test-token")
+	_, err := client.RemoveUser(ctx, &reviewerv1.RemoveUserRequest{UserId: uuid.NewString()})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestRequestID_Propagated(t *testing.T) {
+	mockService := new(MockService)
+	client := newTestClient(t, mockService)
+
+	mockService.On("GetStatistics", mock.Anything).Return(&domain.Statistics{TotalUsers: 3}, nil)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "req-123")
+	resp, err := client.GetStatistics(ctx, &reviewerv1.GetStatisticsRequest{}, grpc.Header(&header))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.GetTotalUsers())
+	assert.Equal(t, []string{"req-123"}, header.Get(RequestIDKey))
+	assert.Len(t, header.Get(TraceparentKey), 1)
+}
+
+func TestOrganizationToken(t *testing.T) {
+	mockService := new(MockService)
+	registry := tenant.NewRegistry(tenant.NewMemoryStore())
+	client := newTestClient(t, mockService, WithOrganizations(registry))
+
+	org, token, err := registry.Create(context.Background(), "acme")
+	require.NoError(t, err)
+
+	inOrg := func(orgID uuid.UUID) any {
+		return mock.MatchedBy(func(ctx context.Context) bool { return tenant.OrgID(ctx) == orgID })
+	}
+	mockService.On("GetTeam", inOrg(org.OrgID), "backend").Return(&domain.Team{TeamName: "backend"}, nil).Once()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), AuthorizationKey, "Bearer "+token)
+	_, err = client.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	require.NoError(t, err)
+
+	// Без токена и без WithDefaultFallback вызов отклоняется.
+	_, err = client.GetTeam(context.Background(), &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), AuthorizationKey, "Bearer org_unknown")
+	_, err = client.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	mockService.AssertExpectations(t)
+}
+
+func TestOrganizationToken_DefaultFallback(t *testing.T) {
+	mockService := new(MockService)
+	registry := tenant.NewRegistry(tenant.NewMemoryStore(), tenant.WithDefaultFallback())
+	client := newTestClient(t, mockService, WithOrganizations(registry))
+
+	mockService.On("GetTeam", mock.MatchedBy(func(ctx context.Context) bool {
+		return tenant.OrgID(ctx) == tenant.DefaultOrgID
+	}), "backend").Return(nil, errors.New("TEAM_NOT_FOUND")).Once()
+
+	_, err := client.GetTeam(context.Background(), &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockService.AssertExpectations(t)
+}
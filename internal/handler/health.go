@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/health"
+)
+
+// WithHealth подключает проверки готовности для /readyz.
+// Без них /readyz проверяет только, что сервис не останавливается.
+func WithHealth(checker *health.Checker) Option {
+	return func(h *Handler) {
+		h.health = checker
+	}
+}
+
+// Liveness обрабатывает GET /livez: процесс жив и обслуживает запросы.
+// Зависимости не проверяются, чтобы сбой БД не приводил к перезапуску.
+func (h *Handler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// Readiness обрабатывает GET /readyz: готов ли сервис принимать трафик.
+// Возвращает 503 и результаты проверок, если хотя бы одна не прошла.
+func (h *Handler) Readiness(c *gin.Context) {
+	report := h.health.Check(c.Request.Context())
+	if !report.OK() {
+		slog.WarnContext(c.Request.Context(), "Readiness check failed", "checks", report.Checks)
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"time"
@@ -9,21 +10,91 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/T1mof/pr-reviewer-service/internal/chatops"
 	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/events"
+	"github.com/T1mof/pr-reviewer-service/internal/health"
+	"github.com/T1mof/pr-reviewer-service/internal/metrics"
 	"github.com/T1mof/pr-reviewer-service/internal/middleware"
+	"github.com/T1mof/pr-reviewer-service/internal/notify"
+	"github.com/T1mof/pr-reviewer-service/internal/openapi"
+	"github.com/T1mof/pr-reviewer-service/internal/privacy"
+	"github.com/T1mof/pr-reviewer-service/internal/ratelimit"
+	"github.com/T1mof/pr-reviewer-service/internal/roster"
 	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
 )
 
 type Handler struct {
 	service    service.ServiceInterface
 	adminToken string
+	metrics    *metrics.Metrics
+	limiter    ratelimit.Limiter
+	limits     ratelimit.Resolver
+	spec       *openapi.Validator
+	validation openapi.ValidationMode
+	roster     *roster.Service
+	health     *health.Checker
+	events     *events.Broker
+	github     *webhook.GitHub
+	gitlab     *webhook.GitLab
+	slack      *chatops.Slack
+	notifier   *notify.Notifier
+	orgs       *tenant.Registry
+	remover    service.UserRemover
+	privacy    *privacy.Service
+	seniority  service.SeniorityManager
+	// requestTimeout дедлайн контекста обработки запроса.
+	requestTimeout time.Duration
 }
 
-func NewHandler(svc service.ServiceInterface, adminToken string) *Handler {
-	return &Handler{
-		service:    svc,
-		adminToken: adminToken,
+// Option настраивает необязательные зависимости Handler.
+type Option func(*Handler)
+
+// WithMetrics включает сбор HTTP метрик и endpoint /metrics.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(h *Handler) {
+		h.metrics = m
+	}
+}
+
+// WithRateLimit включает ограничение частоты запросов к API.
+// Для замены лимитов без перезапуска передайте *ratelimit.DynamicPolicy.
+func WithRateLimit(limiter ratelimit.Limiter, policy ratelimit.Resolver) Option {
+	return func(h *Handler) {
+		h.limiter = limiter
+		h.limits = policy
+	}
+}
+
+// WithOpenAPI публикует спецификацию на /openapi.json и /docs и включает
+// проверку трафика по ней согласно mode.
+func WithOpenAPI(v *openapi.Validator, mode openapi.ValidationMode) Option {
+	return func(h *Handler) {
+		h.spec = v
+		h.validation = mode
+	}
+}
+
+// WithRequestTimeout задаёт дедлайн обработки запроса, по умолчанию 10 секунд.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(h *Handler) {
+		h.requestTimeout = d
+	}
+}
+
+func NewHandler(svc service.ServiceInterface, adminToken string, opts ...Option) *Handler {
+	h := &Handler{
+		service:        svc,
+		adminToken:     adminToken,
+		health:         health.NewChecker(),
+		requestTimeout: 10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // ErrorResponse структура ответа с ошибкой согласно OpenAPI спецификации.
@@ -36,7 +107,7 @@ type ErrorResponse struct {
 
 // sendError отправляет структурированную ошибку клиенту и логирует её.
 func (h *Handler) sendError(c *gin.Context, statusCode int, code, message string) {
-	slog.Error("Request error",
+	slog.ErrorContext(c.Request.Context(), "Request error",
 		"path", c.Request.URL.Path,
 		"method", c.Request.Method,
 		"status", statusCode,
@@ -55,10 +126,12 @@ func (h *Handler) CreateTeam(c *gin.Context) {
 	var req struct {
 		TeamName string `json:"team_name" binding:"required"`
 		Members  []struct {
-			UserID   string `json:"user_id" binding:"required"`
-			Username string `json:"username" binding:"required"`
-			IsActive bool   `json:"is_active"`
+			UserID    string `json:"user_id" binding:"required"`
+			Username  string `json:"username" binding:"required"`
+			IsActive  bool   `json:"is_active"`
+			Seniority string `json:"seniority"`
 		} `json:"members" binding:"required,min=1"`
+		SeniorityRule *domain.SeniorityRule `json:"seniority_rule"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -67,8 +140,9 @@ func (h *Handler) CreateTeam(c *gin.Context) {
 	}
 
 	team := &domain.Team{
-		TeamName: req.TeamName,
-		Members:  make([]domain.TeamMember, len(req.Members)),
+		TeamName:      req.TeamName,
+		Members:       make([]domain.TeamMember, len(req.Members)),
+		SeniorityRule: req.SeniorityRule,
 	}
 
 	for i, m := range req.Members {
@@ -80,9 +154,10 @@ func (h *Handler) CreateTeam(c *gin.Context) {
 		}
 
 		team.Members[i] = domain.TeamMember{
-			UserID:   userID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserID:    userID,
+			Username:  m.Username,
+			IsActive:  m.IsActive,
+			Seniority: m.Seniority,
 		}
 	}
 
@@ -92,11 +167,19 @@ func (h *Handler) CreateTeam(c *gin.Context) {
 			h.sendError(c, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists")
 			return
 		}
+		if err.Error() == "USER_REMOVED" {
+			h.sendError(c, http.StatusBadRequest, "USER_REMOVED", "user was removed")
+			return
+		}
+		if errors.Is(err, domain.ErrValidation) {
+			h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
 		h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		return
 	}
 
-	slog.Info("Team created successfully", "team_name", req.TeamName, "members_count", len(req.Members))
+	slog.InfoContext(c.Request.Context(), "Team created successfully", "team_name", req.TeamName, "members_count", len(req.Members))
 	c.JSON(http.StatusCreated, gin.H{"team": team})
 }
 
@@ -149,7 +232,7 @@ func (h *Handler) SetUserActive(c *gin.Context) {
 		return
 	}
 
-	slog.Info("User activity changed", "user_id", userID, "is_active", req.IsActive)
+	slog.InfoContext(c.Request.Context(), "User activity changed", "user_id", userID, "is_active", req.IsActive)
 	c.JSON(http.StatusOK, gin.H{"user": user})
 }
 
@@ -188,11 +271,15 @@ func (h *Handler) CreatePR(c *gin.Context) {
 			h.sendError(c, http.StatusNotFound, "NOT_FOUND", "author or team not found")
 			return
 		}
+		if err.Error() == "NO_SENIOR_CANDIDATE" {
+			h.sendError(c, http.StatusConflict, "NO_SENIOR_CANDIDATE", "not enough active reviewers of required seniority in team")
+			return
+		}
 		h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		return
 	}
 
-	slog.Info("PR created", "pr_id", prID, "author_id", authorID, "reviewers_count", len(pr.AssignedReviewers))
+	slog.InfoContext(c.Request.Context(), "PR created", "pr_id", prID, "author_id", authorID, "reviewers_count", len(pr.AssignedReviewers))
 	c.JSON(http.StatusCreated, gin.H{"pr": pr})
 }
 
@@ -223,7 +310,7 @@ func (h *Handler) MergePR(c *gin.Context) {
 		return
 	}
 
-	slog.Info("PR merged", "pr_id", prID)
+	slog.InfoContext(c.Request.Context(), "PR merged", "pr_id", prID)
 	c.JSON(http.StatusOK, gin.H{"pr": pr})
 }
 
@@ -260,6 +347,8 @@ func (h *Handler) ReassignReviewer(c *gin.Context) {
 			h.sendError(c, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
 		case "NO_CANDIDATE":
 			h.sendError(c, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team")
+		case "NO_SENIOR_CANDIDATE":
+			h.sendError(c, http.StatusConflict, "NO_SENIOR_CANDIDATE", "no active replacement candidate of required seniority in team")
 		case "PR_NOT_FOUND":
 			h.sendError(c, http.StatusNotFound, "NOT_FOUND", "PR not found")
 		default:
@@ -268,7 +357,7 @@ func (h *Handler) ReassignReviewer(c *gin.Context) {
 		return
 	}
 
-	slog.Info("Reviewer reassigned", "pr_id", prID, "old_reviewer", oldUserID, "new_reviewer", newReviewerID)
+	slog.InfoContext(c.Request.Context(), "Reviewer reassigned", "pr_id", prID, "old_reviewer", oldUserID, "new_reviewer", newReviewerID)
 	c.JSON(http.StatusOK, gin.H{
 		"pr":          pr,
 		"replaced_by": newReviewerID,
@@ -314,36 +403,116 @@ func (h *Handler) GetStatistics(c *gin.Context) {
 
 // SetupRouter настраивает маршруты для Gin роутера.
 func (h *Handler) SetupRouter() *gin.Engine {
-	r := gin.Default()
+	r := gin.New()
+	r.Use(gin.Recovery(), middleware.RequestContext(), middleware.AccessLog())
+
+	if h.metrics != nil {
+		r.Use(middleware.Metrics(h.metrics))
+		r.GET("/metrics", gin.WrapH(h.metrics.Handler()))
+	}
 
 	r.Use(func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		// Поток событий открыт, пока его не закроет клиент или сервер.
+		if c.FullPath() == reviewStreamPath {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), h.requestTimeout)
 		defer cancel()
 
 		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	})
 
+	if h.spec != nil {
+		r.GET("/openapi.json", func(c *gin.Context) {
+			c.Data(http.StatusOK, "application/json", h.spec.JSON())
+		})
+		r.GET("/docs", func(c *gin.Context) {
+			c.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+		})
+
+		if h.validation != openapi.ValidationOff {
+			r.Use(middleware.OpenAPIValidation(h.spec, h.validation == openapi.ValidationAll))
+		}
+	}
+
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
+	r.GET("/livez", h.Liveness)
+	r.GET("/readyz", h.Readiness)
+
+	// Webhook'и и команды чата аутентифицируются подписью и приходят
+	// с общих адресов хостинга, поэтому не ограничиваются rate limit'ом по клиенту.
+	if h.github != nil {
+		r.POST("/integrations/github/webhook", h.GitHubWebhook)
+	}
+	if h.gitlab != nil {
+		r.POST("/integrations/gitlab/webhook", h.GitLabWebhook)
+	}
+	if h.slack != nil {
+		r.POST("/integrations/slack/command", h.SlackCommand)
+	}
+
+	api := r.Group("")
+	if h.limiter != nil {
+		api.Use(middleware.RateLimit(h.limiter, h.limits, h.adminToken, h.verifyOrgToken()))
+	}
+	if h.orgs != nil {
+		// Организации создаются до появления их токенов, поэтому управление
+		// ими не проходит через Tenant.
+		api.POST("/admin/organizations", middleware.AdminAuth(h.adminToken), h.CreateOrganization)
+		api.GET("/admin/organizations", middleware.AdminAuth(h.adminToken), h.ListOrganizations)
+		api.Use(middleware.Tenant(h.orgs))
+	} else {
+		api.Use(middleware.DefaultTenant())
+	}
 
 	// Stats
-	r.GET("/stats", h.GetStatistics)
+	api.GET("/stats", h.GetStatistics)
 
 	// Teams
-	r.POST("/team/add", h.CreateTeam)
-	r.GET("/team/get", h.GetTeam)
+	api.POST("/team/add", h.CreateTeam)
+	api.GET("/team/get", h.GetTeam)
+	if h.seniority != nil {
+		api.POST("/team/setSeniorityRule", middleware.AdminAuth(h.adminToken), h.SetSeniorityRule)
+	}
 
 	// Users
-	r.POST("/users/setIsActive", middleware.AdminAuth(h.adminToken), h.SetUserActive)
-	r.GET("/users/getReview", h.GetUserReviews)
+	api.POST("/users/setIsActive", middleware.AdminAuth(h.adminToken), h.SetUserActive)
+	api.GET("/users/getReview", h.GetUserReviews)
+	if h.remover != nil {
+		api.POST("/users/remove", middleware.AdminAuth(h.adminToken), h.RemoveUser)
+	}
+	if h.seniority != nil {
+		api.POST("/users/setSeniority", middleware.AdminAuth(h.adminToken), h.SetUserSeniority)
+	}
+	if h.events != nil {
+		api.GET(reviewStreamPath, h.ReviewStream)
+	}
+	if h.notifier != nil {
+		api.GET("/users/notifications", middleware.AdminAuth(h.adminToken), h.GetNotificationPreferences)
+		api.POST("/users/notifications", middleware.AdminAuth(h.adminToken), h.SetNotificationPreferences)
+	}
 
 	// Pull Requests
-	r.POST("/pullRequest/create", h.CreatePR)
-	r.POST("/pullRequest/merge", h.MergePR)
-	r.POST("/pullRequest/reassign", h.ReassignReviewer)
+	api.POST("/pullRequest/create", h.CreatePR)
+	api.POST("/pullRequest/merge", h.MergePR)
+	api.POST("/pullRequest/reassign", h.ReassignReviewer)
+
+	// Admin
+	if h.roster != nil {
+		admin := api.Group("/admin", middleware.AdminAuth(h.adminToken))
+		admin.POST("/import", h.ImportRoster)
+		admin.GET("/export", h.ExportRoster)
+	}
+	if h.privacy != nil {
+		api.GET("/admin/users/export", middleware.AdminAuth(h.adminToken), h.ExportUserData)
+		api.POST("/admin/users/anonymize", middleware.AdminAuth(h.adminToken), h.AnonymizeUser)
+	}
 
 	return r
 }
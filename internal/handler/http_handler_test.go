@@ -12,8 +12,18 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/health"
+	"github.com/T1mof/pr-reviewer-service/internal/metrics"
+	"github.com/T1mof/pr-reviewer-service/internal/openapi"
+	"github.com/T1mof/pr-reviewer-service/internal/privacy"
+	"github.com/T1mof/pr-reviewer-service/internal/ratelimit"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/roster"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
 )
 
 // ==================== Mock Service ====================
@@ -80,6 +90,29 @@ func (m *MockService) DeactivateTeam(ctx context.Context, teamName string) (int,
 	return args.Int(0), args.Error(1)
 }
 
+// ==================== In-memory Router ====================
+
+// newMemoryRouter собирает роутер с настоящим сервисом поверх in-memory
+// репозитория, проверкой по OpenAPI и всеми функциями, которым нужен
+// только репозиторий.
+func newMemoryRouter(t *testing.T) http.Handler {
+	t.Helper()
+
+	spec, err := openapi.NewValidator()
+	require.NoError(t, err)
+
+	repo := repository.NewMemoryRepository()
+	svc := service.NewReviewerService(repo)
+	return NewHandler(svc, "test-token",
+		WithOpenAPI(spec, openapi.ValidationAll),
+		WithRoster(roster.NewService(repo)),
+		WithOrganizations(tenant.NewRegistry(tenant.NewMemoryStore(), tenant.WithDefaultFallback())),
+		WithUserRemoval(svc),
+		WithPrivacy(privacy.NewService(repo)),
+		WithSeniority(svc),
+	).SetupRouter()
+}
+
 // ==================== Tests ====================
 
 func TestHealthCheck(t *testing.T) {
@@ -99,6 +132,112 @@ func TestHealthCheck(t *testing.T) {
 	assert.Equal(t, "ok", response["status"])
 }
 
+func TestMetricsEndpoint(t *testing.T) {
+	mockService := new(MockService)
+	handler := NewHandler(mockService, "test-token", WithMetrics(metrics.New()))
+	router := handler.SetupRouter()
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health", http.NoBody))
+
+	req := httptest.NewRequest("GET", "/metrics", http.NoBody)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `pr_service_http_request_duration_seconds_count{method="GET",route="/health",status="200"} 1`)
+}
+
+func TestRequestID_Propagated(t *testing.T) {
+	mockService := new(MockService)
+	handler := NewHandler(mockService, "test-token")
+	router := handler.SetupRouter()
+
+	req := httptest.NewRequest("GET", "/health", http.NoBody)
+	req.Header.Set("X-Request-ID", "abc-123")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "abc-123", w.Header().Get("X-Request-ID"))
+	assert.Contains(t, w.Header().Get("traceparent"), "00-4bf92f3577b34da6a3ce929d0e0e4736-")
+	assert.NotContains(t, w.Header().Get("traceparent"), "00f067aa0ba902b7")
+}
+
+func TestRequestID_Generated(t *testing.T) {
+	mockService := new(MockService)
+	handler := NewHandler(mockService, "test-token")
+	router := handler.SetupRouter()
+
+	req := httptest.NewRequest("GET", "/health", http.NoBody)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
+	assert.NotEmpty(t, w.Header().Get("traceparent"))
+}
+
+func TestRateLimit_Exceeded(t *testing.T) {
+	mockService := new(MockService)
+	policy := &ratelimit.Policy{Default: ratelimit.Limit{RPS: 0.01, Burst: 1}}
+	handler := NewHandler(mockService, "test-token", WithRateLimit(ratelimit.NewMemoryLimiter(), policy))
+	router := handler.SetupRouter()
+
+	mockService.On("GetStatistics", mock.Anything).Return(&domain.Statistics{}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/stats", http.NoBody))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/stats", http.NoBody))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "RATE_LIMITED")
+
+	// Health check не ограничивается
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/health", http.NoBody))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimit_UnverifiedTokensShareIPBucket(t *testing.T) {
+	mockService := new(MockService)
+	policy := &ratelimit.Policy{Default: ratelimit.Limit{RPS: 0.01, Burst: 1}}
+	registry := tenant.NewRegistry(tenant.NewMemoryStore(), tenant.WithDefaultFallback())
+	_, orgToken, err := registry.Create(context.Background(), "acme")
+	require.NoError(t, err)
+	router := NewHandler(mockService, "test-token",
+		WithRateLimit(ratelimit.NewMemoryLimiter(), policy),
+		WithOrganizations(registry),
+	).SetupRouter()
+
+	mockService.On("GetStatistics", mock.Anything).Return(&domain.Statistics{}, nil)
+
+	stats := func(header, value string) int {
+		req := httptest.NewRequest("GET", "/stats", http.NoBody)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, stats("", ""))
+	// Новый непроверенный токен не даёт нового bucket'а.
+	assert.Equal(t, http.StatusTooManyRequests, stats("X-Admin-Token", "guess-1"))
+	assert.Equal(t, http.StatusTooManyRequests, stats("Authorization", "Bearer org_guess-2"))
+
+	// Проверенные токены считаются отдельно от IP.
+	assert.Equal(t, http.StatusOK, stats("X-Admin-Token", "test-token"))
+	assert.Equal(t, http.StatusOK, stats("Authorization", "Bearer "+orgToken))
+}
+
 // ==================== Team Tests ====================
 
 func TestCreateTeam_Success(t *testing.T) {
@@ -557,3 +696,31 @@ func (m *MockService) GetStatistics(ctx context.Context) (*domain.Statistics, er
 	}
 	return args.Get(0).(*domain.Statistics), args.Error(1)
 }
+
+func TestProbes(t *testing.T) {
+	checker := health.NewChecker()
+	router := NewHandler(new(MockService), "test-token", WithHealth(checker)).SetupRouter()
+
+	probe := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, http.NoBody))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, probe("/livez").Code)
+	assert.Equal(t, http.StatusOK, probe("/readyz").Code)
+
+	checker.Add("database", func(context.Context) (string, error) {
+		return "", errors.New("connection refused")
+	})
+
+	w := probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var report health.Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, health.StatusFail, report.Status)
+	assert.Equal(t, "connection refused", report.Checks["database"].Error)
+
+	assert.Equal(t, http.StatusOK, probe("/livez").Code, "liveness ignores dependencies")
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/notify"
+)
+
+// WithNotifications включает endpoints настроек email-уведомлений
+// /users/notifications.
+func WithNotifications(n *notify.Notifier) Option {
+	return func(h *Handler) {
+		h.notifier = n
+	}
+}
+
+// GetNotificationPreferences обрабатывает GET /users/notifications?user_id=...
+func (h *Handler) GetNotificationPreferences(c *gin.Context) {
+	userIDStr := c.Query("user_id")
+	if userIDStr == "" {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid user_id UUID")
+		return
+	}
+
+	prefs, err := h.notifier.Preferences(c.Request.Context(), userID)
+	if err != nil {
+		h.sendPreferencesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+// SetNotificationPreferences обрабатывает POST /users/notifications.
+// Незаданные поля не меняются.
+func (h *Handler) SetNotificationPreferences(c *gin.Context) {
+	var req struct {
+		UserID      string  `json:"user_id" binding:"required"`
+		Email       *string `json:"email"`
+		Assignments *bool   `json:"assignments"`
+		Digest      *bool   `json:"digest"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid user_id UUID")
+		return
+	}
+
+	prefs, err := h.notifier.UpdatePreferences(c.Request.Context(), userID, notify.PreferencesUpdate{
+		Email:       req.Email,
+		Assignments: req.Assignments,
+		Digest:      req.Digest,
+	})
+	if err != nil {
+		h.sendPreferencesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+func (h *Handler) sendPreferencesError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "USER_NOT_FOUND":
+		h.sendError(c, http.StatusNotFound, "NOT_FOUND", "user not found")
+	case "INVALID_EMAIL":
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "email must be a plain address like name@example.com")
+	default:
+		h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/notify"
+	"github.com/T1mof/pr-reviewer-service/internal/openapi"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	userID := uuid.New()
+	require.NoError(t, repo.CreateTeam(context.Background(), &domain.Team{
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: userID, Username: "bob", IsActive: true}},
+	}))
+
+	spec, err := openapi.NewValidator()
+	require.NoError(t, err)
+
+	router := NewHandler(new(MockService), "test-token",
+		WithOpenAPI(spec, openapi.ValidationAll),
+		WithNotifications(notify.NewNotifier(repo, notify.NewMemoryStore(), nil)),
+	).SetupRouter()
+
+	do := func(method, target, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if token != "" {
+			req.Header.Set("X-Admin-Token", token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) notify.Preferences {
+		var resp struct {
+			Preferences notify.Preferences `json:"preferences"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Preferences
+	}
+
+	w := do(http.MethodGet, "/users/notifications?user_id="+userID.String(), "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = do(http.MethodGet, "/users/notifications?user_id="+userID.String(), "", "test-token")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, notify.DefaultPreferences(userID), decode(w))
+
+	w = do(http.MethodPost, "/users/notifications", `{"user_id":"`+userID.String()+`","email":"bob@example.com","digest":false}`, "test-token")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, notify.Preferences{UserID: userID, Email: "bob@example.com", Assignments: true}, decode(w))
+
+	w = do(http.MethodGet, "/users/notifications?user_id="+userID.String(), "", "test-token")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "bob@example.com", decode(w).Email)
+
+	w = do(http.MethodPost, "/users/notifications", `{"user_id":"`+userID.String()+`","email":"bob"}`, "test-token")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "email must be a plain address")
+
+	w = do(http.MethodPost, "/users/notifications", `{"user_id":"`+uuid.NewString()+`","digest":true}`, "test-token")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = do(http.MethodGet, "/users/notifications?user_id=42", "", "test-token")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+// WithUserRemoval включает POST /users/remove: удаление пользователя
+// с передачей его открытых ревью другим участникам команды.
+func WithUserRemoval(r service.UserRemover) Option {
+	return func(h *Handler) {
+		h.remover = r
+	}
+}
+
+// RemoveUser обрабатывает POST /users/remove.
+func (h *Handler) RemoveUser(c *gin.Context) {
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid user_id UUID")
+		return
+	}
+
+	removal, err := h.remover.RemoveUser(c.Request.Context(), userID)
+	if err != nil {
+		switch err.Error() {
+		case "USER_NOT_FOUND":
+			h.sendError(c, http.StatusNotFound, "NOT_FOUND", "user not found")
+		case "NO_CANDIDATE":
+			h.sendError(c, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate for an open review")
+		case "NO_SENIOR_CANDIDATE":
+			h.sendError(c, http.StatusConflict, "NO_SENIOR_CANDIDATE", "no replacement candidate of required seniority for an open review")
+		case "USER_HAS_OPEN_REVIEWS":
+			h.sendError(c, http.StatusConflict, "USER_HAS_OPEN_REVIEWS", "user keeps getting new reviews, retry the removal")
+		default:
+			h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "User removed", "user_id", userID, "reassigned_reviews", len(removal.Reassigned))
+	c.JSON(http.StatusOK, removal)
+}
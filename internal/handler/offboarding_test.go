@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+)
+
+func createPR(t *testing.T, router http.Handler, authorID uuid.UUID) uuid.UUID {
+	t.Helper()
+
+	prID := uuid.New()
+	w := orgRequest(router, "POST", "/pullRequest/create", "",
+		`{"pull_request_id":"`+prID.String()+`","pull_request_name":"Add search","author_id":"`+authorID.String()+`"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	return prID
+}
+
+func setActive(t *testing.T, router http.Handler, userID uuid.UUID, isActive bool) {
+	t.Helper()
+
+	active := "false"
+	if isActive {
+		active = "true"
+	}
+	w := orgRequest(router, "POST", "/users/setIsActive", "", `{"user_id":"`+userID.String()+`","is_active":`+active+`}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestRemoveUser(t *testing.T) {
+	router := newMemoryRouter(t)
+	a1, a2, a3, a4 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	w := orgRequest(router, "POST", "/team/add", "", teamBody("backend", a1, a2, a3, a4))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	// Пока a4 неактивен, ревьюверами обоих PR становятся a2 и a3.
+	setActive(t, router, a4, false)
+	mergedID := createPR(t, router, a1)
+	w = orgRequest(router, "POST", "/pullRequest/merge", "", `{"pull_request_id":"`+mergedID.String()+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	openID := createPR(t, router, a1)
+	setActive(t, router, a4, true)
+
+	w = orgRequest(router, "POST", "/users/remove", "", `{"user_id":"`+a2.String()+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var removal domain.UserRemoval
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &removal))
+	assert.Equal(t, a2, removal.User.UserID)
+	assert.False(t, removal.User.IsActive)
+	assert.Equal(t, []domain.ReviewerReplacement{{PullRequestID: openID, ReplacedBy: a4}}, removal.Reassigned)
+
+	w = orgRequest(router, "GET", "/team/get?team_name=backend", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), a2.String())
+
+	// Слитый PR остаётся в истории удалённого пользователя.
+	w = orgRequest(router, "GET", "/users/getReview?user_id="+a2.String(), "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), mergedID.String())
+	assert.NotContains(t, w.Body.String(), openID.String())
+
+	var stats domain.Statistics
+	w = orgRequest(router, "GET", "/stats", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, 3, stats.TotalUsers)
+	assert.Equal(t, 2, stats.PRStats.TotalPRs)
+
+	w = orgRequest(router, "POST", "/users/remove", "", `{"user_id":"`+a2.String()+`"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Удалённый пользователь не возвращается через добавление в команду.
+	w = orgRequest(router, "POST", "/team/add", "", teamBody("frontend", a2))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "USER_REMOVED")
+	w = orgRequest(router, "POST", "/admin/import", "", `{"teams":[`+teamBody("backend", a2)+`]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "USER_REMOVED")
+
+	w = orgRequest(router, "POST", "/users/remove", "", `{"user_id":"not-a-uuid"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRemoveUser_NoCandidate(t *testing.T) {
+	router := newMemoryRouter(t)
+	a1, a2, a3 := uuid.New(), uuid.New(), uuid.New()
+
+	w := orgRequest(router, "POST", "/team/add", "", teamBody("backend", a1, a2, a3))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	createPR(t, router, a1)
+
+	// Оба участника кроме автора уже ревьюверы этого PR, передать некому.
+	w = orgRequest(router, "POST", "/users/remove", "", `{"user_id":"`+a2.String()+`"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "NO_CANDIDATE")
+
+	w = orgRequest(router, "GET", "/team/get?team_name=backend", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), a2.String())
+}
+
+func TestRemoveUser_RequiresAdmin(t *testing.T) {
+	router := newMemoryRouter(t)
+
+	req := httptest.NewRequest("POST", "/users/remove", strings.NewReader(`{"user_id":"`+uuid.NewString()+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
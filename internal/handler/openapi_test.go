@@ -0,0 +1,306 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/chatops"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/events"
+	"github.com/T1mof/pr-reviewer-service/internal/notify"
+	"github.com/T1mof/pr-reviewer-service/internal/openapi"
+	"github.com/T1mof/pr-reviewer-service/internal/privacy"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/roster"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
+)
+
+func newSpecRouter(t *testing.T, svc *MockService) http.Handler {
+	t.Helper()
+
+	spec, err := openapi.NewValidator()
+	require.NoError(t, err)
+
+	return NewHandler(svc, "test-token", WithOpenAPI(spec, openapi.ValidationAll)).SetupRouter()
+}
+
+// Служебные маршруты не входят в спецификацию API.
+var undocumentedRoutes = map[string]bool{
+	"GET /metrics":      true,
+	"GET /openapi.json": true,
+	"GET /docs":         true,
+}
+
+func TestOpenAPI_RoutesMatchSpec(t *testing.T) {
+	spec, err := openapi.NewValidator()
+	require.NoError(t, err)
+
+	router := NewHandler(new(MockService), "test-token",
+		WithOpenAPI(spec, openapi.ValidationAll),
+		WithRoster(roster.NewService(repository.NewMemoryRepository())),
+		WithEvents(events.NewBroker(events.NewMemoryStore())),
+		WithGitHub(webhook.NewGitHub(new(MockService), "secret", &webhook.Mapping{}, webhook.NewMemoryDeliveries())),
+		WithGitLab(webhook.NewGitLab(new(MockService), "token", &webhook.Mapping{}, webhook.NewMemoryDeliveries())),
+		WithSlack(chatops.NewSlack(new(MockService), "secret", slackAccounts(uuid.New()), chatops.NewMemoryAbsences())),
+		WithNotifications(notify.NewNotifier(repository.NewMemoryRepository(), notify.NewMemoryStore(), nil)),
+		WithOrganizations(tenant.NewRegistry(tenant.NewMemoryStore())),
+		WithUserRemoval(service.NewReviewerService(repository.NewMemoryRepository())),
+		WithPrivacy(privacy.NewService(repository.NewMemoryRepository())),
+		WithSeniority(service.NewReviewerService(repository.NewMemoryRepository())),
+	).SetupRouter()
+
+	var registered []string
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		if !undocumentedRoutes[key] {
+			registered = append(registered, key)
+		}
+	}
+
+	var documented []string
+	for path, item := range spec.Doc().Paths.Map() {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+	assert.Equal(t, documented, registered, "routes in SetupRouter and api/openapi.yaml differ")
+}
+
+func TestOpenAPI_ResponsesMatchSpec(t *testing.T) {
+	teamID, prID, authorID, userID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	reviewers := []uuid.UUID{uuid.New(), uuid.New()}
+	mergedAt := time.Now()
+
+	openPR := &domain.PullRequestWithReviewers{
+		PullRequestID:     prID,
+		PullRequestName:   "Add feature",
+		AuthorID:          authorID,
+		Status:            domain.StatusOpen,
+		AssignedReviewers: reviewers,
+		CreatedAt:         time.Now(),
+	}
+	mergedPR := *openPR
+	mergedPR.Status = domain.StatusMerged
+	mergedPR.MergedAt = &mergedAt
+
+	team := &domain.Team{
+		TeamID:   teamID,
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: userID, Username: "alice", IsActive: true}},
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(m *MockService)
+		method string
+		path   string
+		body   any
+		admin  bool
+		status int
+	}{
+		{
+			name:   "health",
+			method: "GET", path: "/health",
+			status: http.StatusOK,
+		},
+		{
+			name:   "create team",
+			setup:  func(m *MockService) { m.On("CreateTeam", mock.Anything, mock.Anything).Return(nil) },
+			method: "POST", path: "/team/add",
+			body:   map[string]any{"team_name": "backend", "members": []map[string]any{{"user_id": userID.String(), "username": "alice", "is_active": true}}},
+			status: http.StatusCreated,
+		},
+		{
+			name: "create team exists",
+			setup: func(m *MockService) {
+				m.On("CreateTeam", mock.Anything, mock.Anything).Return(errors.New("TEAM_EXISTS"))
+			},
+			method: "POST", path: "/team/add",
+			body:   map[string]any{"team_name": "backend", "members": []map[string]any{{"user_id": userID.String(), "username": "alice"}}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "get team",
+			setup:  func(m *MockService) { m.On("GetTeam", mock.Anything, "backend").Return(team, nil) },
+			method: "GET", path: "/team/get?team_name=backend",
+			status: http.StatusOK,
+		},
+		{
+			name: "get team not found",
+			setup: func(m *MockService) {
+				m.On("GetTeam", mock.Anything, "ghost").Return(nil, errors.New("TEAM_NOT_FOUND"))
+			},
+			method: "GET", path: "/team/get?team_name=ghost",
+			status: http.StatusNotFound,
+		},
+		{
+			name: "set user active",
+			setup: func(m *MockService) {
+				m.On("SetUserActive", mock.Anything, userID, false).Return(&domain.User{UserID: userID, Username: "alice", TeamName: "backend"}, nil)
+			},
+			method: "POST", path: "/users/setIsActive",
+			body:   map[string]any{"user_id": userID.String(), "is_active": false},
+			admin:  true,
+			status: http.StatusOK,
+		},
+		{
+			name:   "set user active unauthorized",
+			method: "POST", path: "/users/setIsActive",
+			body:   map[string]any{"user_id": userID.String(), "is_active": false},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "get user reviews",
+			setup: func(m *MockService) {
+				m.On("GetUserReviews", mock.Anything, userID).Return([]domain.PullRequestShort{
+					{PullRequestID: prID, PullRequestName: "Add feature", AuthorID: authorID, Status: domain.StatusOpen},
+				}, nil)
+			},
+			method: "GET", path: "/users/getReview?user_id=" + userID.String(),
+			status: http.StatusOK,
+		},
+		{
+			name: "create PR",
+			setup: func(m *MockService) {
+				m.On("CreatePR", mock.Anything, prID, "Add feature", authorID).Return(openPR, nil)
+			},
+			method: "POST", path: "/pullRequest/create",
+			body:   map[string]any{"pull_request_id": prID.String(), "pull_request_name": "Add feature", "author_id": authorID.String()},
+			status: http.StatusCreated,
+		},
+		{
+			name: "create PR exists",
+			setup: func(m *MockService) {
+				m.On("CreatePR", mock.Anything, prID, "Add feature", authorID).Return(nil, errors.New("PR_EXISTS"))
+			},
+			method: "POST", path: "/pullRequest/create",
+			body:   map[string]any{"pull_request_id": prID.String(), "pull_request_name": "Add feature", "author_id": authorID.String()},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "merge PR",
+			setup:  func(m *MockService) { m.On("MergePR", mock.Anything, prID).Return(&mergedPR, nil) },
+			method: "POST", path: "/pullRequest/merge",
+			body:   map[string]any{"pull_request_id": prID.String()},
+			status: http.StatusOK,
+		},
+		{
+			name: "reassign reviewer",
+			setup: func(m *MockService) {
+				m.On("ReassignReviewer", mock.Anything, prID, reviewers[0]).Return(openPR, uuid.New(), nil)
+			},
+			method: "POST", path: "/pullRequest/reassign",
+			body:   map[string]any{"pull_request_id": prID.String(), "old_user_id": reviewers[0].String()},
+			status: http.StatusOK,
+		},
+		{
+			name: "reassign no candidate",
+			setup: func(m *MockService) {
+				m.On("ReassignReviewer", mock.Anything, prID, reviewers[0]).Return(nil, uuid.Nil, errors.New("NO_CANDIDATE"))
+			},
+			method: "POST", path: "/pullRequest/reassign",
+			body:   map[string]any{"pull_request_id": prID.String(), "old_user_id": reviewers[0].String()},
+			status: http.StatusConflict,
+		},
+		{
+			name: "stats",
+			setup: func(m *MockService) {
+				m.On("GetStatistics", mock.Anything).Return(&domain.Statistics{
+					UserStats: []domain.UserAssignmentStats{{UserID: userID, Username: "alice", TeamName: "backend", TotalAssignments: 1}},
+				}, nil)
+			},
+			method: "GET", path: "/stats",
+			status: http.StatusOK,
+		},
+		{
+			name:   "stats internal error",
+			setup:  func(m *MockService) { m.On("GetStatistics", mock.Anything).Return(nil, errors.New("db down")) },
+			method: "GET", path: "/stats",
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockService)
+			if tt.setup != nil {
+				tt.setup(mockService)
+			}
+			router := newSpecRouter(t, mockService)
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tt.body))
+			}
+			req := httptest.NewRequest(tt.method, tt.path, &body)
+			if tt.body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tt.admin {
+				req.Header.Set("X-Admin-Token", "test-token")
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.NotContains(t, w.Body.String(), "does not match OpenAPI spec")
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestOpenAPI_RequestValidation(t *testing.T) {
+	mockService := new(MockService)
+	router := newSpecRouter(t, mockService)
+
+	body := `{"pull_request_id": "not-a-uuid", "pull_request_name": "x", "author_id": "` + uuid.NewString() + `"}`
+	req := httptest.NewRequest("POST", "/pullRequest/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "INVALID_REQUEST", resp.Error.Code)
+	assert.Contains(t, resp.Error.Message, "pull_request_id")
+	mockService.AssertNotCalled(t, "CreatePR")
+}
+
+func TestOpenAPI_SpecServed(t *testing.T) {
+	router := newSpecRouter(t, new(MockService))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/docs", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/openapi.json")
+}
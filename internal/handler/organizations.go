@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/middleware"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// WithOrganizations включает разделение данных по организациям: запросы
+// с токеном организации в Authorization: Bearer работают только с её
+// данными. Организациями управляют endpoints /admin/organizations.
+func WithOrganizations(r *tenant.Registry) Option {
+	return func(h *Handler) {
+		h.orgs = r
+	}
+}
+
+// verifyOrgToken проверяет токены организаций для rate limiting; без
+// организаций Bearer токены не проверяются и лимит считается по IP.
+func (h *Handler) verifyOrgToken() middleware.TokenVerifier {
+	if h.orgs == nil {
+		return nil
+	}
+	return func(ctx context.Context, token string) bool {
+		_, err := h.orgs.Authenticate(ctx, token)
+		return err == nil
+	}
+}
+
+// CreateOrganization обрабатывает POST /admin/organizations.
+// Токен организации возвращается только в этом ответе.
+func (h *Handler) CreateOrganization(c *gin.Context) {
+	var req struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	org, token, err := h.orgs.Create(c.Request.Context(), req.Name)
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_NAME":
+			h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "name must be 1 to 255 characters")
+		case "ORG_EXISTS":
+			h.sendError(c, http.StatusBadRequest, "ORG_EXISTS", "organization name already exists")
+		default:
+			h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Organization created", "org_id", org.OrgID, "name", org.Name)
+	c.JSON(http.StatusCreated, gin.H{"organization": org, "token": token})
+}
+
+// ListOrganizations обрабатывает GET /admin/organizations.
+func (h *Handler) ListOrganizations(c *gin.Context) {
+	orgs, err := h.orgs.List(c.Request.Context())
+	if err != nil {
+		h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organizations": orgs})
+}
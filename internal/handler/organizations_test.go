@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// orgRequest выполняет запрос с токеном организации; пустой токен —
+// запрос к организации по умолчанию.
+func orgRequest(router http.Handler, method, target, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Admin-Token", "test-token")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func createOrganization(t *testing.T, router http.Handler, name string) string {
+	t.Helper()
+
+	w := orgRequest(router, "POST", "/admin/organizations", "", `{"name":"`+name+`"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp struct {
+		Organization tenant.Organization `json:"organization"`
+		Token        string              `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, name, resp.Organization.Name)
+	assert.True(t, strings.HasPrefix(resp.Token, "org_"))
+	return resp.Token
+}
+
+func teamBody(name string, ids ...uuid.UUID) string {
+	members := make([]string, len(ids))
+	for i, id := range ids {
+		members[i] = `{"user_id":"` + id.String() + `","username":"user` + id.String()[:4] + `","is_active":true}`
+	}
+	return `{"team_name":"` + name + `","members":[` + strings.Join(members, ",") + `]}`
+}
+
+func TestOrganizations_Admin(t *testing.T) {
+	router := newMemoryRouter(t)
+
+	req := httptest.NewRequest("POST", "/admin/organizations", strings.NewReader(`{"name":"acme"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	createOrganization(t, router, "acme")
+
+	w = orgRequest(router, "POST", "/admin/organizations", "", `{"name":"acme"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "ORG_EXISTS")
+
+	w = orgRequest(router, "POST", "/admin/organizations", "", `{"name":"   "}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_REQUEST")
+
+	w = orgRequest(router, "GET", "/admin/organizations", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list struct {
+		Organizations []tenant.Organization `json:"organizations"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Organizations, 2)
+	assert.Equal(t, "acme", list.Organizations[0].Name)
+	assert.Equal(t, tenant.DefaultOrgID, list.Organizations[1].OrgID)
+
+	w = orgRequest(router, "GET", "/stats", "org_unknown", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid organization token")
+}
+
+func TestOrganizations_TokenRequired(t *testing.T) {
+	mockService := new(MockService)
+	router := NewHandler(mockService, "test-token",
+		WithOrganizations(tenant.NewRegistry(tenant.NewMemoryStore())),
+	).SetupRouter()
+	mockService.On("GetStatistics", mock.Anything).Return(&domain.Statistics{}, nil)
+
+	// Организации создаются без токена организации.
+	token := createOrganization(t, router, "acme")
+
+	w := orgRequest(router, "GET", "/stats", "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "organization token required")
+
+	w = orgRequest(router, "GET", "/stats", token, "")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestOrganizations_Isolation(t *testing.T) {
+	router := newMemoryRouter(t)
+	acme := createOrganization(t, router, "acme")
+	globex := createOrganization(t, router, "globex")
+
+	a1, a2, a3 := uuid.New(), uuid.New(), uuid.New()
+	g1, g2, g3 := uuid.New(), uuid.New(), uuid.New()
+
+	// Имена команд уникальны только внутри организации.
+	w := orgRequest(router, "POST", "/team/add", acme, teamBody("backend", a1, a2, a3))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = orgRequest(router, "POST", "/team/add", globex, teamBody("backend", g1, g2, g3))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = orgRequest(router, "GET", "/team/get?team_name=backend", globex, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var team domain.Team
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &team))
+	assert.ElementsMatch(t, []uuid.UUID{g1, g2, g3}, []uuid.UUID{team.Members[0].UserID, team.Members[1].UserID, team.Members[2].UserID})
+
+	w = orgRequest(router, "GET", "/team/get?team_name=backend", "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// ID пользователя уникален в пределах организации: тот же ID у globex —
+	// другой пользователь, и ответ не выдаёт, что он занят в acme.
+	w = orgRequest(router, "POST", "/team/add", globex, teamBody("frontend", a1))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = orgRequest(router, "GET", "/team/get?team_name=backend", acme, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), a1.String())
+
+	prID := uuid.New()
+	w = orgRequest(router, "POST", "/pullRequest/create", acme,
+		`{"pull_request_id":"`+prID.String()+`","pull_request_name":"Add search","author_id":"`+a1.String()+`"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		PR domain.PullRequestWithReviewers `json:"pr"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotEmpty(t, created.PR.AssignedReviewers)
+	reviewer := created.PR.AssignedReviewers[0]
+
+	// Чужой PR не виден ни для переназначения, ни для слияния.
+	w = orgRequest(router, "POST", "/pullRequest/reassign", globex,
+		`{"pull_request_id":"`+prID.String()+`","old_user_id":"`+reviewer.String()+`"}`)
+	assert.Contains(t, w.Body.String(), "PR_NOT_FOUND")
+	w = orgRequest(router, "POST", "/pullRequest/merge", globex, `{"pull_request_id":"`+prID.String()+`"}`)
+	assert.Contains(t, w.Body.String(), "PR_NOT_FOUND")
+
+	w = orgRequest(router, "GET", "/users/getReview?user_id="+reviewer.String(), globex, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"pull_requests":null`)
+	w = orgRequest(router, "GET", "/users/getReview?user_id="+reviewer.String(), acme, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), prID.String())
+	assert.Contains(t, w.Body.String(), `"status":"open"`)
+
+	var stats domain.Statistics
+	w = orgRequest(router, "GET", "/stats", globex, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Zero(t, stats.PRStats.TotalPRs)
+	assert.Equal(t, 4, stats.TotalUsers)
+
+	w = orgRequest(router, "GET", "/stats", acme, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, 1, stats.PRStats.TotalPRs)
+	assert.Equal(t, 3, stats.TotalUsers)
+}
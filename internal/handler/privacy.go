@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/privacy"
+)
+
+// WithPrivacy включает GET /admin/users/export и POST /admin/users/anonymize:
+// выгрузку персональных данных пользователя и их анонимизацию.
+func WithPrivacy(p *privacy.Service) Option {
+	return func(h *Handler) {
+		h.privacy = p
+	}
+}
+
+// ExportUserData обрабатывает GET /admin/users/export.
+func (h *Handler) ExportUserData(c *gin.Context) {
+	userIDStr := c.Query("user_id")
+	if userIDStr == "" {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid user_id UUID")
+		return
+	}
+
+	export, err := h.privacy.Export(c.Request.Context(), userID)
+	if err != nil {
+		h.sendPrivacyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, export)
+}
+
+// AnonymizeUser обрабатывает POST /admin/users/anonymize.
+func (h *Handler) AnonymizeUser(c *gin.Context) {
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid user_id UUID")
+		return
+	}
+
+	result, err := h.privacy.Anonymize(c.Request.Context(), userID)
+	if err != nil {
+		h.sendPrivacyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) sendPrivacyError(c *gin.Context, err error) {
+	if err.Error() == "USER_NOT_FOUND" {
+		h.sendError(c, http.StatusNotFound, "NOT_FOUND", "user not found")
+		return
+	}
+	h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+}
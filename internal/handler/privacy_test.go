@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/privacy"
+)
+
+func TestExportUserData(t *testing.T) {
+	router := newMemoryRouter(t)
+	a1, a2, a3 := uuid.New(), uuid.New(), uuid.New()
+
+	w := orgRequest(router, "POST", "/team/add", "", teamBody("backend", a1, a2, a3))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	prID := createPR(t, router, a1)
+
+	w = orgRequest(router, "GET", "/admin/users/export?user_id="+a1.String(), "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var export privacy.Export
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+	assert.Equal(t, a1, export.Profile.UserID)
+	assert.Equal(t, []string{"backend"}, export.Teams)
+	require.Len(t, export.AuthoredPRs, 1)
+	assert.Equal(t, prID, export.AuthoredPRs[0].PullRequestID)
+	assert.Empty(t, export.Reviews)
+
+	w = orgRequest(router, "GET", "/admin/users/export?user_id="+uuid.NewString(), "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = orgRequest(router, "GET", "/admin/users/export?user_id=not-a-uuid", "", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	router := newMemoryRouter(t)
+	a1, a2, a3 := uuid.New(), uuid.New(), uuid.New()
+
+	w := orgRequest(router, "POST", "/team/add", "", teamBody("backend", a1, a2, a3))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	createPR(t, router, a2)
+
+	w = orgRequest(router, "POST", "/admin/users/anonymize", "", `{"user_id":"`+a2.String()+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var result privacy.Anonymization
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, 1, result.AuthoredPRs)
+	assert.NotContains(t, w.Body.String(), a2.String())
+
+	w = orgRequest(router, "GET", "/team/get?team_name=backend", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), a2.String())
+	assert.Contains(t, w.Body.String(), result.Username)
+
+	w = orgRequest(router, "POST", "/admin/users/anonymize", "", `{"user_id":"`+a2.String()+`"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPrivacy_RequiresAdmin(t *testing.T) {
+	router := newMemoryRouter(t)
+
+	req := httptest.NewRequest("GET", "/admin/users/export?user_id="+uuid.NewString(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/events"
+)
+
+// reviewStreamPath маршрут потока событий. На него не действует
+// REQUEST_TIMEOUT, а запись не ограничена HTTP_WRITE_TIMEOUT.
+const reviewStreamPath = "/users/reviewStream"
+
+const (
+	// streamKeepAlive интервал комментариев, не дающих прокси закрыть
+	// простаивающее соединение.
+	streamKeepAlive = 15 * time.Second
+	// streamBatchSize сколько событий читается из журнала за раз.
+	streamBatchSize = 100
+)
+
+// WithEvents включает поток GET /users/reviewStream.
+func WithEvents(b *events.Broker) Option {
+	return func(h *Handler) {
+		h.events = b
+	}
+}
+
+// ReviewStream обрабатывает GET /users/reviewStream?user_id=... — поток
+// Server-Sent Events о назначениях пользователя ревьювером, снятии при
+// переназначении и merge PR, которые он ревьюит. С заголовком
+// Last-Event-ID поток продолжается после этого события, иначе начинается
+// с новых событий.
+func (h *Handler) ReviewStream(c *gin.Context) {
+	userIDStr := c.Query("user_id")
+	if userIDStr == "" {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid user_id UUID")
+		return
+	}
+
+	var lastID int64
+	resume := c.GetHeader("Last-Event-ID")
+	if resume != "" {
+		lastID, err = strconv.ParseInt(resume, 10, 64)
+		if err != nil || lastID < 0 {
+			h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid Last-Event-ID")
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	// Подписка раньше чтения журнала: событие между чтением и подпиской
+	// иначе осталось бы без сигнала до следующего.
+	sub := h.events.Subscribe(userID)
+	defer sub.Close()
+
+	if resume == "" {
+		if lastID, err = h.events.LastID(ctx); err != nil {
+			h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+			return
+		}
+	}
+
+	rc := http.NewResponseController(c.Writer)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.WarnContext(ctx, "Failed to clear write deadline for review stream", "error", err)
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	slog.InfoContext(ctx, "Review stream opened", "user_id", userID, "last_event_id", lastID)
+	defer slog.InfoContext(ctx, "Review stream closed", "user_id", userID)
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		if lastID, err = h.sendReviewEvents(ctx, c, userID, lastID); err != nil {
+			if ctx.Err() == nil {
+				slog.WarnContext(ctx, "Review stream failed", "user_id", userID, "error", err)
+			}
+			return
+		}
+
+		// Журнал перечитывается и по keepalive: это страховка на случай
+		// уведомления, потерянного до переподключения слушателя.
+		select {
+		case <-sub.Wake():
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(c.Writer, ": keepalive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-ctx.Done():
+			return
+		case <-h.events.Done():
+			return
+		}
+	}
+}
+
+// sendReviewEvents отправляет события журнала после lastID и возвращает
+// ID последнего отправленного.
+func (h *Handler) sendReviewEvents(ctx context.Context, c *gin.Context, userID uuid.UUID, lastID int64) (int64, error) {
+	for {
+		batch, err := h.events.After(ctx, userID, lastID, streamBatchSize)
+		if err != nil {
+			return lastID, err
+		}
+
+		for _, e := range batch {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return lastID, fmt.Errorf("failed to encode event: %w", err)
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data); err != nil {
+				return lastID, fmt.Errorf("failed to write event: %w", err)
+			}
+			lastID = e.ID
+		}
+		if len(batch) > 0 {
+			c.Writer.Flush()
+		}
+
+		if len(batch) < streamBatchSize {
+			return lastID, nil
+		}
+	}
+}
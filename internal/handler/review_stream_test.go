@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/events"
+)
+
+// sseEvent событие, разобранное из потока.
+type sseEvent struct {
+	id, event, data string
+}
+
+func openReviewStream(t *testing.T, url, lastEventID string) (*http.Response, <-chan sseEvent) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	ch := make(chan sseEvent)
+	go func() {
+		defer close(ch)
+		var e sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if e.id != "" {
+					ch <- e
+				}
+				e = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				e.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				e.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				e.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return resp, ch
+}
+
+func nextEvent(t *testing.T, ch <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case e, ok := <-ch:
+		require.True(t, ok, "stream closed")
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+		return sseEvent{}
+	}
+}
+
+func TestReviewStream(t *testing.T) {
+	broker := events.NewBroker(events.NewMemoryStore())
+	router := NewHandler(new(MockService), "test-token", WithEvents(broker)).SetupRouter()
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	userID := uuid.New()
+	other := uuid.New()
+	prID := uuid.New()
+	ctx := context.Background()
+
+	broker.Publish(ctx,
+		domain.ReviewEvent{Type: domain.EventReviewAssigned, UserID: userID, PullRequestID: prID, PullRequestName: "old"},
+		domain.ReviewEvent{Type: domain.EventReviewAssigned, UserID: other, PullRequestID: prID},
+	)
+
+	url := srv.URL + "/users/reviewStream?user_id=" + userID.String()
+
+	t.Run("starts with new events", func(t *testing.T) {
+		resp, ch := openReviewStream(t, url, "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		broker.Publish(ctx, domain.ReviewEvent{Type: domain.EventPRMerged, UserID: userID, PullRequestID: prID, PullRequestName: "new"})
+
+		e := nextEvent(t, ch)
+		assert.Equal(t, "3", e.id)
+		assert.Equal(t, domain.EventPRMerged, e.event)
+		assert.Contains(t, e.data, `"pull_request_name":"new"`)
+	})
+
+	t.Run("resumes after Last-Event-ID", func(t *testing.T) {
+		_, ch := openReviewStream(t, url, "0")
+
+		assert.Equal(t, "1", nextEvent(t, ch).id)
+		assert.Equal(t, "3", nextEvent(t, ch).id)
+	})
+
+	t.Run("closes on broker shutdown", func(t *testing.T) {
+		_, ch := openReviewStream(t, url, "3")
+		broker.Close()
+
+		select {
+		case _, ok := <-ch:
+			assert.False(t, ok)
+		case <-time.After(2 * time.Second):
+			t.Fatal("stream not closed")
+		}
+	})
+}
+
+func TestReviewStream_InvalidRequest(t *testing.T) {
+	router := NewHandler(new(MockService), "test-token",
+		WithEvents(events.NewBroker(events.NewMemoryStore()))).SetupRouter()
+
+	tests := []struct {
+		name   string
+		query  string
+		header string
+	}{
+		{name: "missing user_id", query: ""},
+		{name: "invalid user_id", query: "user_id=abc"},
+		{name: "invalid Last-Event-ID", query: "user_id=" + uuid.NewString(), header: "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/reviewStream?"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("Last-Event-ID", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/roster"
+)
+
+// maxImportSize ограничение размера файла импорта.
+const maxImportSize = 10 << 20
+
+// WithRoster включает admin endpoints /admin/import и /admin/export.
+func WithRoster(svc *roster.Service) Option {
+	return func(h *Handler) {
+		h.roster = svc
+	}
+}
+
+// ImportRoster обрабатывает POST /admin/import?format=csv|yaml|json&dry_run=true.
+// Формат берётся из параметра format, иначе из Content-Type.
+func (h *Handler) ImportRoster(c *gin.Context) {
+	format, ok := roster.FormatFromContentType(c.ContentType())
+	if v := c.Query("format"); v != "" {
+		var err error
+		if format, err = roster.ParseFormat(v); err != nil {
+			h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+	} else if !ok {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"format is required: use ?format= or Content-Type text/csv, application/yaml, application/json")
+		return
+	}
+
+	dryRun := false
+	if v := c.Query("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "dry_run must be true or false")
+			return
+		}
+	}
+
+	records, err := roster.Decode(format, http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	report, err := h.roster.Import(c.Request.Context(), records, dryRun)
+	if err != nil {
+		var validationErr *roster.ValidationError
+		if errors.As(err, &validationErr) {
+			h.sendRowErrors(c, validationErr)
+			return
+		}
+		if err.Error() == "USER_REMOVED" {
+			h.sendError(c, http.StatusBadRequest, "USER_REMOVED", "user was removed")
+			return
+		}
+		h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// sendRowErrors отвечает 400 с перечнем ошибок по строкам файла.
+func (h *Handler) sendRowErrors(c *gin.Context, validationErr *roster.ValidationError) {
+	slog.ErrorContext(c.Request.Context(), "Request error",
+		"path", c.Request.URL.Path,
+		"method", c.Request.Method,
+		"status", http.StatusBadRequest,
+		"error_code", "INVALID_REQUEST",
+		"invalid_rows", len(validationErr.Rows),
+	)
+
+	var resp struct {
+		ErrorResponse
+		Rows []roster.RowError `json:"rows"`
+	}
+	resp.Error.Code = "INVALID_REQUEST"
+	resp.Error.Message = validationErr.Error()
+	resp.Rows = validationErr.Rows
+	c.JSON(http.StatusBadRequest, resp)
+}
+
+// ExportRoster обрабатывает GET /admin/export?format=csv|yaml|json.
+func (h *Handler) ExportRoster(c *gin.Context) {
+	format, err := roster.ParseFormat(c.DefaultQuery("format", string(roster.FormatJSON)))
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	teams, err := h.roster.Export(c.Request.Context())
+	if err != nil {
+		h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="teams.%s"`, format))
+	c.Status(http.StatusOK)
+	if err := roster.Encode(format, c.Writer, teams); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to write roster export", "error", err)
+	}
+}
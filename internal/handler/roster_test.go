@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/roster"
+)
+
+func rosterRequest(router http.Handler, method, target, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("X-Admin-Token", "test-token")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+const rosterCSV = `team_name,user_id,username,is_active,seniority
+backend,11111111-1111-1111-1111-111111111111,alice,true,senior
+backend,22222222-2222-2222-2222-222222222222,bob,false,
+`
+
+func TestImportRoster_DryRunThenApply(t *testing.T) {
+	router := newMemoryRouter(t)
+
+	w := rosterRequest(router, "POST", "/admin/import?dry_run=true", "text/csv", rosterCSV)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var report roster.Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Summary.TeamsCreated)
+	assert.Equal(t, 2, report.Summary.UsersCreated)
+
+	w = rosterRequest(router, "GET", "/admin/export?format=csv", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "team_name,user_id,username,is_active,seniority\n", w.Body.String(), "dry run must not apply")
+
+	w = rosterRequest(router, "POST", "/admin/import", "text/csv", rosterCSV)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = rosterRequest(router, "GET", "/admin/export?format=csv", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, rosterCSV, w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "teams.csv")
+}
+
+func TestImportRoster_Formats(t *testing.T) {
+	router := newMemoryRouter(t)
+
+	w := rosterRequest(router, "POST", "/admin/import", "application/yaml", `teams:
+  - team_name: backend
+    members:
+      - user_id: 11111111-1111-1111-1111-111111111111
+        username: alice
+`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = rosterRequest(router, "POST", "/admin/import?format=json", "application/json", `{"teams": [{"team_name": "frontend", "members": [
+		{"user_id": "11111111-1111-1111-1111-111111111111", "username": "alice"}
+	]}]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"action":"move_user"`)
+
+	w = rosterRequest(router, "GET", "/admin/export?format=yaml", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "team_name: frontend")
+}
+
+func TestImportRoster_RowErrors(t *testing.T) {
+	router := newMemoryRouter(t)
+
+	w := rosterRequest(router, "POST", "/admin/import", "text/csv", `team_name,user_id,username
+backend,not-a-uuid,alice
+backend,22222222-2222-2222-2222-222222222222,
+`)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+		Rows []roster.RowError `json:"rows"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "INVALID_REQUEST", resp.Error.Code)
+	require.Len(t, resp.Rows, 2)
+	assert.Equal(t, "line 2", resp.Rows[0].Location)
+	assert.Equal(t, "username cannot be empty", resp.Rows[1].Message)
+}
+
+func TestImportRoster_BadRequests(t *testing.T) {
+	router := newMemoryRouter(t)
+
+	w := rosterRequest(router, "POST", "/admin/import", "text/plain", rosterCSV)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = rosterRequest(router, "GET", "/admin/export?format=xml", "", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	req := httptest.NewRequest("GET", "/admin/export", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
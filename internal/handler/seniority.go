@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+// WithSeniority включает POST /users/setSeniority и POST /team/setSeniorityRule:
+// уровни ревьюверов и требование к числу старших ревьюверов на PR.
+func WithSeniority(m service.SeniorityManager) Option {
+	return func(h *Handler) {
+		h.seniority = m
+	}
+}
+
+// SetUserSeniority обрабатывает POST /users/setSeniority.
+func (h *Handler) SetUserSeniority(c *gin.Context) {
+	var req struct {
+		UserID    string `json:"user_id" binding:"required"`
+		Seniority string `json:"seniority" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid user_id UUID")
+		return
+	}
+
+	user, err := h.seniority.SetUserSeniority(c.Request.Context(), userID, req.Seniority)
+	if err != nil {
+		switch {
+		case err.Error() == "USER_NOT_FOUND":
+			h.sendError(c, http.StatusNotFound, "NOT_FOUND", "user not found")
+		case errors.Is(err, domain.ErrValidation):
+			h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		default:
+			h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "User seniority changed", "user_id", userID, "seniority", req.Seniority)
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// SetSeniorityRule обрабатывает POST /team/setSeniorityRule. Пустое
+// seniority_rule снимает требование с команды.
+func (h *Handler) SetSeniorityRule(c *gin.Context) {
+	var req struct {
+		TeamName string                `json:"team_name" binding:"required"`
+		Rule     *domain.SeniorityRule `json:"seniority_rule"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	team, err := h.seniority.SetSeniorityRule(c.Request.Context(), req.TeamName, req.Rule)
+	if err != nil {
+		switch {
+		case err.Error() == "TEAM_NOT_FOUND":
+			h.sendError(c, http.StatusNotFound, "NOT_FOUND", "team not found")
+		case errors.Is(err, domain.ErrValidation):
+			h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		default:
+			h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Team seniority rule changed", "team_name", req.TeamName, "rule", req.Rule)
+	c.JSON(http.StatusOK, gin.H{"team": team})
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+)
+
+func setSeniority(t *testing.T, router http.Handler, userID uuid.UUID, level string) domain.User {
+	t.Helper()
+
+	w := orgRequest(router, "POST", "/users/setSeniority", "", `{"user_id":"`+userID.String()+`","seniority":"`+level+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		User domain.User `json:"user"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.User
+}
+
+func TestSeniority_TeamAddWithLevelsAndRule(t *testing.T) {
+	router := newMemoryRouter(t)
+	a1, a2, a3 := uuid.New(), uuid.New(), uuid.New()
+
+	w := orgRequest(router, "POST", "/team/add", "", `{"team_name":"backend","seniority_rule":{"level":"senior","count":1},"members":[
+		{"user_id":"`+a1.String()+`","username":"u1","is_active":true,"seniority":"middle"},
+		{"user_id":"`+a2.String()+`","username":"u2","is_active":true,"seniority":"senior"},
+		{"user_id":"`+a3.String()+`","username":"u3","is_active":true}]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = orgRequest(router, "GET", "/team/get?team_name=backend", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var team domain.Team
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &team))
+	assert.Equal(t, &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 1}, team.SeniorityRule)
+	levels := map[uuid.UUID]string{}
+	for _, m := range team.Members {
+		levels[m.UserID] = m.Seniority
+	}
+	assert.Equal(t, map[uuid.UUID]string{a1: "middle", a2: "senior", a3: ""}, levels)
+
+	w = orgRequest(router, "POST", "/team/add", "", `{"team_name":"frontend","members":[
+		{"user_id":"`+uuid.NewString()+`","username":"u4","is_active":true,"seniority":"principal"}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
+
+func TestSeniority_RuleEnforcedOnCreateAndReassign(t *testing.T) {
+	router := newMemoryRouter(t)
+	a1, a2, a3, a4 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	w := orgRequest(router, "POST", "/team/add", "", teamBody("backend", a1, a2, a3, a4))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = orgRequest(router, "POST", "/team/setSeniorityRule", "", `{"team_name":"backend","seniority_rule":{"level":"senior","count":1}}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"seniority_rule":{"level":"senior","count":1}`)
+
+	// В команде нет ни одного senior.
+	w = orgRequest(router, "POST", "/pullRequest/create", "",
+		`{"pull_request_id":"`+uuid.NewString()+`","pull_request_name":"Add search","author_id":"`+a1.String()+`"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "NO_SENIOR_CANDIDATE")
+
+	user := setSeniority(t, router, a2, domain.SenioritySenior)
+	assert.Equal(t, domain.SenioritySenior, user.Seniority)
+
+	prID := createPR(t, router, a1)
+	w = orgRequest(router, "GET", "/users/getReview?user_id="+a2.String(), "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), prID.String())
+
+	// Единственного senior заменить некем.
+	w = orgRequest(router, "POST", "/pullRequest/reassign", "", `{"pull_request_id":"`+prID.String()+`","old_user_id":"`+a2.String()+`"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "NO_SENIOR_CANDIDATE")
+
+	w = orgRequest(router, "POST", "/users/remove", "", `{"user_id":"`+a2.String()+`"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "NO_SENIOR_CANDIDATE")
+
+	// a4 либо становится заменой, либо уже назначен и сохраняет правило.
+	setSeniority(t, router, a4, domain.SenioritySenior)
+	w = orgRequest(router, "POST", "/pullRequest/reassign", "", `{"pull_request_id":"`+prID.String()+`","old_user_id":"`+a2.String()+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		PR domain.PullRequestWithReviewers `json:"pr"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Contains(t, resp.PR.AssignedReviewers, a4)
+	assert.NotContains(t, resp.PR.AssignedReviewers, a2)
+
+	// null снимает правило с команды.
+	w = orgRequest(router, "POST", "/team/setSeniorityRule", "", `{"team_name":"backend","seniority_rule":null}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "seniority_rule")
+}
+
+func TestSeniority_Errors(t *testing.T) {
+	router := newMemoryRouter(t)
+	a1 := uuid.New()
+
+	w := orgRequest(router, "POST", "/team/add", "", teamBody("backend", a1, uuid.New(), uuid.New()))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+	}{
+		{"invalid level", "/users/setSeniority", `{"user_id":"` + a1.String() + `","seniority":"principal"}`, http.StatusBadRequest},
+		{"invalid user id", "/users/setSeniority", `{"user_id":"not-a-uuid","seniority":"senior"}`, http.StatusBadRequest},
+		{"unknown user", "/users/setSeniority", `{"user_id":"` + uuid.NewString() + `","seniority":"senior"}`, http.StatusNotFound},
+		{"count above reviewers", "/team/setSeniorityRule", `{"team_name":"backend","seniority_rule":{"level":"senior","count":3}}`, http.StatusBadRequest},
+		{"unknown team", "/team/setSeniorityRule", `{"team_name":"ghost","seniority_rule":{"level":"senior","count":1}}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := orgRequest(router, "POST", tt.path, "", tt.body)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestSeniority_RequiresAdmin(t *testing.T) {
+	router := newMemoryRouter(t)
+
+	bodies := map[string]string{
+		"/users/setSeniority":    `{"user_id":"` + uuid.NewString() + `","seniority":"senior"}`,
+		"/team/setSeniorityRule": `{"team_name":"backend","seniority_rule":null}`,
+	}
+	for path, body := range bodies {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, path)
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/chatops"
+)
+
+// WithSlack включает slash-команды Slack на /integrations/slack/command.
+func WithSlack(s *chatops.Slack) Option {
+	return func(h *Handler) {
+		h.slack = s
+	}
+}
+
+// SlackCommand обрабатывает POST /integrations/slack/command. Запрос
+// аутентифицируется подписью X-Slack-Signature. Ответ на команду, в том
+// числе об ошибке сервиса, возвращается со статусом 200: иначе Slack
+// покажет пользователю только код ответа.
+func (h *Handler) SlackCommand(c *gin.Context) {
+	body, ok := h.readWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := h.slack.VerifySignature(c.GetHeader("X-Slack-Request-Timestamp"), c.GetHeader("X-Slack-Signature"), body); err != nil {
+		h.sendError(c, http.StatusUnauthorized, "INVALID_SIGNATURE", "invalid X-Slack-Signature")
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid form body")
+		return
+	}
+
+	// Проверка SSL сертификата при настройке команды, тело ответа Slack
+	// не читает.
+	if form.Get("ssl_check") == "1" {
+		c.JSON(http.StatusOK, &chatops.Response{ResponseType: chatops.ResponseEphemeral})
+		return
+	}
+
+	if form.Get("user_id") == "" {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	resp := h.slack.Handle(c.Request.Context(), chatops.Command{
+		UserID:  form.Get("user_id"),
+		Command: form.Get("command"),
+		Text:    form.Get("text"),
+	})
+	c.JSON(http.StatusOK, resp)
+}
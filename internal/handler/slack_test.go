@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/chatops"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/openapi"
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
+)
+
+func TestSlackCommand(t *testing.T) {
+	mockService := new(MockService)
+	userID := uuid.New()
+
+	spec, err := openapi.NewValidator()
+	require.NoError(t, err)
+
+	slack := chatops.NewSlack(mockService, "secret", slackAccounts(userID), chatops.NewMemoryAbsences())
+	router := NewHandler(mockService, "test-token",
+		WithOpenAPI(spec, openapi.ValidationAll),
+		WithSlack(slack),
+	).SetupRouter()
+
+	send := func(form url.Values, sign func(timestamp, body string) string) *httptest.ResponseRecorder {
+		body := form.Encode()
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		req := httptest.NewRequest(http.MethodPost, "/integrations/slack/command", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+		req.Header.Set("X-Slack-Signature", sign(timestamp, body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	signWith := func(secret string) func(timestamp, body string) string {
+		return func(timestamp, body string) string {
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write([]byte("v0:" + timestamp + ":" + body))
+			return "v0=" + hex.EncodeToString(mac.Sum(nil))
+		}
+	}
+
+	t.Run("invalid signature", func(t *testing.T) {
+		w := send(url.Values{"command": {"/review"}, "text": {"mine"}, "user_id": {"U012AB3CD"}}, signWith("other"))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_SIGNATURE")
+	})
+
+	t.Run("mine", func(t *testing.T) {
+		mockService.On("GetUserReviews", mock.Anything, userID).Return([]domain.PullRequestShort{}, nil).Once()
+
+		w := send(url.Values{"command": {"/review"}, "text": {"mine"}, "user_id": {"U012AB3CD"}}, signWith("secret"))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"response_type":"ephemeral","text":"You have no open reviews."}`, w.Body.String())
+	})
+
+	t.Run("service error is an ephemeral message", func(t *testing.T) {
+		prID := uuid.New()
+		mockService.On("ReassignReviewer", mock.Anything, prID, userID).Return(nil, uuid.Nil, assert.AnError).Once()
+
+		w := send(url.Values{"command": {"/review"}, "text": {"reassign " + prID.String() + " me"}, "user_id": {"U012AB3CD"}}, signWith("secret"))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"response_type":"ephemeral","text":"Something went wrong, please try again later."}`, w.Body.String())
+	})
+
+	t.Run("ssl check", func(t *testing.T) {
+		w := send(url.Values{"ssl_check": {"1"}, "token": {"x"}}, signWith("secret"))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"response_type":"ephemeral","text":""}`, w.Body.String())
+	})
+
+	mockService.AssertExpectations(t)
+}
+
+// slackAccounts сопоставляет Slack ID U012AB3CD пользователю userID.
+func slackAccounts(userID uuid.UUID) webhook.Accounts {
+	mapping := &webhook.Mapping{Slack: webhook.SlackMapping{Users: webhook.Users{"u012ab3cd": userID}}}
+	return mapping.Accounts(webhook.ProviderSlack)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
+)
+
+// maxWebhookSize ограничение размера тела webhook'а.
+const maxWebhookSize = 5 << 20
+
+// WithGitHub включает приём webhook'ов GitHub на /integrations/github/webhook.
+func WithGitHub(g *webhook.GitHub) Option {
+	return func(h *Handler) {
+		h.github = g
+	}
+}
+
+// WithGitLab включает приём webhook'ов GitLab на /integrations/gitlab/webhook.
+func WithGitLab(g *webhook.GitLab) Option {
+	return func(h *Handler) {
+		h.gitlab = g
+	}
+}
+
+// GitHubWebhook обрабатывает POST /integrations/github/webhook. Запрос
+// аутентифицируется подписью X-Hub-Signature-256, а не admin токеном.
+func (h *Handler) GitHubWebhook(c *gin.Context) {
+	body, ok := h.readWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := h.github.VerifySignature(body, c.GetHeader("X-Hub-Signature-256")); err != nil {
+		h.sendError(c, http.StatusUnauthorized, "INVALID_SIGNATURE", "invalid X-Hub-Signature-256")
+		return
+	}
+
+	deliveryID := c.GetHeader("X-GitHub-Delivery")
+	if deliveryID == "" {
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "X-GitHub-Delivery is required")
+		return
+	}
+
+	result, err := h.github.Handle(c.Request.Context(), deliveryID, c.GetHeader("X-GitHub-Event"), body)
+	h.sendWebhookResult(c, result, err)
+}
+
+// GitLabWebhook обрабатывает POST /integrations/gitlab/webhook. Запрос
+// аутентифицируется секретным токеном X-Gitlab-Token.
+func (h *Handler) GitLabWebhook(c *gin.Context) {
+	if err := h.gitlab.VerifyToken(c.GetHeader("X-Gitlab-Token")); err != nil {
+		h.sendError(c, http.StatusUnauthorized, "INVALID_SIGNATURE", "invalid X-Gitlab-Token")
+		return
+	}
+
+	body, ok := h.readWebhook(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.gitlab.Handle(c.Request.Context(), c.GetHeader("X-Gitlab-Event-UUID"), c.GetHeader("X-Gitlab-Event"), body)
+	h.sendWebhookResult(c, result, err)
+}
+
+// readWebhook читает тело webhook'а целиком: подпись считается по сырым байтам.
+func (h *Handler) readWebhook(c *gin.Context) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.sendError(c, http.StatusRequestEntityTooLarge, "INVALID_REQUEST", "payload too large")
+			return nil, false
+		}
+		h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return nil, false
+	}
+	return body, true
+}
+
+func (h *Handler) sendWebhookResult(c *gin.Context, result *webhook.Result, err error) {
+	if err != nil {
+		if err.Error() == "INVALID_PAYLOAD" {
+			h.sendError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid webhook payload")
+			return
+		}
+		h.sendError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
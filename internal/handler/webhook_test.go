@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/openapi"
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
+)
+
+func TestGitHubWebhook(t *testing.T) {
+	mockService := new(MockService)
+	mapping := &webhook.Mapping{GitHub: webhook.GitHubMapping{
+		Users:        webhook.Users{"octocat": uuid.New()},
+		Repositories: map[int64]string{1296269: "backend"},
+	}}
+
+	spec, err := openapi.NewValidator()
+	require.NoError(t, err)
+
+	router := NewHandler(mockService, "test-token",
+		WithOpenAPI(spec, openapi.ValidationAll),
+		WithGitHub(webhook.NewGitHub(mockService, "secret", mapping, webhook.NewMemoryDeliveries())),
+	).SetupRouter()
+
+	body := []byte(`{"action":"closed","pull_request":{"id":7,"number":3,"title":"Fix","merged":true,"user":{"login":"octocat"}},"repository":{"id":1296269,"full_name":"octocat/Hello-World"}}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	send := func(signature, delivery string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/integrations/github/webhook", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Event", "pull_request")
+		req.Header.Set("X-GitHub-Delivery", delivery)
+		if signature != "" {
+			req.Header.Set("X-Hub-Signature-256", signature)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("invalid signature", func(t *testing.T) {
+		w := send("sha256=00", "d-1")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_SIGNATURE")
+
+		w = send("", "d-1")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		prID := webhook.PullRequestID(webhook.ProviderGitHub, "7")
+		mockService.On("MergePR", mock.Anything, prID).
+			Return(&domain.PullRequestWithReviewers{PullRequestID: prID, Status: domain.StatusMerged}, nil).Once()
+
+		w := send(signature, "d-1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"action":"merged","pull_request_id":"`+prID.String()+`"}`, w.Body.String())
+
+		w = send(signature, "d-1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"action":"duplicate"}`, w.Body.String())
+
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestGitLabWebhook(t *testing.T) {
+	mockService := new(MockService)
+	authorID := uuid.New()
+	mapping := &webhook.Mapping{GitLab: webhook.GitLabMapping{
+		Users:    webhook.Users{"root": authorID},
+		Projects: map[string]string{"group/project": "backend"},
+	}}
+
+	spec, err := openapi.NewValidator()
+	require.NoError(t, err)
+
+	router := NewHandler(mockService, "test-token",
+		WithOpenAPI(spec, openapi.ValidationAll),
+		WithGitLab(webhook.NewGitLab(mockService, "token", mapping, webhook.NewMemoryDeliveries())),
+	).SetupRouter()
+
+	body := `{"object_kind":"merge_request","user":{"id":1,"username":"root"},"project":{"id":5,"path_with_namespace":"group/project"},"object_attributes":{"iid":3,"title":"Fix","state":"opened","action":"open","author_id":1}}`
+
+	send := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/integrations/gitlab/webhook", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+		req.Header.Set("X-Gitlab-Token", token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("wrong")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_SIGNATURE")
+
+	prID := webhook.PullRequestID(webhook.ProviderGitLab, webhook.GitLabMergeRequestID("group/project", 3))
+	mockService.On("GetTeam", mock.Anything, "backend").
+		Return(&domain.Team{TeamName: "backend", Members: []domain.TeamMember{{UserID: authorID}}}, nil).Once()
+	mockService.On("CreatePR", mock.Anything, prID, "Fix", authorID).
+		Return(&domain.PullRequestWithReviewers{PullRequestID: prID}, nil).Once()
+
+	w = send("token")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"action":"created","pull_request_id":"`+prID.String()+`"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Database проверяет соединение с БД.
+func Database(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		if err := db.PingContext(ctx); err != nil {
+			return "", err
+		}
+		stats := db.Stats()
+		return fmt.Sprintf("%d open, %d in use", stats.OpenConnections, stats.InUse), nil
+	}
+}
+
+// Migrations сравнивает применённую версию схемы с последней миграцией,
+// встроенной в бинарник. Схема новее бинарника допустима: так бывает при
+// выкатке, пока старые реплики ещё работают.
+func Migrations(db *sql.DB, expected uint) CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		var version uint
+		var dirty bool
+		err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("no migrations applied, expected version %d", expected)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read schema version: %w", err)
+		}
+
+		switch {
+		case dirty:
+			return "", fmt.Errorf("schema version %d is dirty, run \"migrate force\"", version)
+		case version < expected:
+			return "", fmt.Errorf("schema version %d is behind expected %d, run \"migrate up\"", version, expected)
+		}
+		return fmt.Sprintf("version %d, expected %d", version, expected), nil
+	}
+}
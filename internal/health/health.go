@@ -0,0 +1,179 @@
+// Package health проверки готовности сервиса к приёму трафика.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Статусы проверок и сервиса в целом.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// CheckFunc проверяет одну зависимость. Возвращает пояснение для отчёта
+// (например, версию схемы) или ошибку.
+type CheckFunc func(ctx context.Context) (detail string, err error)
+
+// CheckResult результат одной проверки.
+type CheckResult struct {
+	Status     string `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report результат всех проверок.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// OK готов ли сервис принимать трафик.
+func (r *Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// errShuttingDown сообщается с начала graceful shutdown.
+var errShuttingDown = errors.New("server is shutting down")
+
+// Checker собирает проверки готовности. Проверки выполняются параллельно,
+// каждая со своим таймаутом.
+type Checker struct {
+	mu           sync.RWMutex
+	checks       map[string]CheckFunc
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewChecker() *Checker {
+	return &Checker{
+		checks:  make(map[string]CheckFunc),
+		timeout: 2 * time.Second,
+	}
+}
+
+// Add регистрирует проверку. Повторная регистрация имени заменяет проверку.
+func (c *Checker) Add(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Heartbeat регистрирует проверку фоновой задачи. Проверка не проходит,
+// если задача остановилась или, при interval > 0, не отмечалась дольше
+// трёх интервалов.
+func (c *Checker) Heartbeat(name string, interval time.Duration) *Heartbeat {
+	h := &Heartbeat{interval: interval}
+	h.Beat()
+	c.Add(name, h.check)
+	return h
+}
+
+// Shutdown переводит сервис в состояние "не готов" до остановки серверов,
+// чтобы балансировщик успел снять с него трафик.
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+// Check выполняет все проверки.
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.mu.RLock()
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checks := make([]CheckFunc, len(names))
+	for i, name := range names {
+		checks[i] = c.checks[name]
+	}
+	c.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := &Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(names)+1)}
+	for i, name := range names {
+		report.Checks[name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	if c.shuttingDown.Load() {
+		report.Status = StatusFail
+		report.Checks["shutdown"] = CheckResult{Status: StatusFail, Error: errShuttingDown.Error()}
+	}
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	detail, err := check(ctx)
+
+	res := CheckResult{
+		Status:     StatusOK,
+		Detail:     detail,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		res.Status = StatusFail
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// Heartbeat состояние фоновой задачи.
+type Heartbeat struct {
+	interval time.Duration
+	last     atomic.Int64
+	stopped  atomic.Pointer[error]
+}
+
+// Beat отмечает, что задача работает.
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+// Stop отмечает, что задача завершилась с ошибкой.
+func (h *Heartbeat) Stop(err error) {
+	if err == nil {
+		err = errors.New("stopped")
+	}
+	h.stopped.Store(&err)
+}
+
+func (h *Heartbeat) check(context.Context) (string, error) {
+	if err := h.stopped.Load(); err != nil {
+		return "", fmt.Errorf("worker stopped: %w", *err)
+	}
+
+	if h.interval <= 0 {
+		return "running", nil
+	}
+
+	since := time.Since(time.Unix(0, h.last.Load()))
+	if since > 3*h.interval {
+		return "", fmt.Errorf("no heartbeat for %s", since.Round(time.Second))
+	}
+	return fmt.Sprintf("last heartbeat %s ago", since.Round(time.Second)), nil
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Check(t *testing.T) {
+	c := NewChecker()
+
+	report := c.Check(context.Background())
+	assert.True(t, report.OK(), "no checks means ready")
+
+	c.Add("database", func(context.Context) (string, error) { return "2 open", nil })
+	c.Add("queue", func(context.Context) (string, error) { return "", errors.New("connection refused") })
+
+	report = c.Check(context.Background())
+	assert.False(t, report.OK())
+	assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, "2 open", report.Checks["database"].Detail)
+	assert.Equal(t, StatusFail, report.Checks["queue"].Status)
+	assert.Equal(t, "connection refused", report.Checks["queue"].Error)
+}
+
+func TestChecker_Shutdown(t *testing.T) {
+	c := NewChecker()
+	c.Add("database", func(context.Context) (string, error) { return "", nil })
+
+	c.Shutdown()
+
+	report := c.Check(context.Background())
+	assert.False(t, report.OK())
+	assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, "server is shutting down", report.Checks["shutdown"].Error)
+}
+
+func TestHeartbeat(t *testing.T) {
+	c := NewChecker()
+	stale := c.Heartbeat("stale", time.Millisecond)
+	stopped := c.Heartbeat("stopped", 0)
+	c.Heartbeat("running", 0)
+
+	time.Sleep(5 * time.Millisecond)
+	stopped.Stop(errors.New("listen failed"))
+
+	report := c.Check(context.Background())
+	assert.Contains(t, report.Checks["stale"].Error, "no heartbeat for")
+	assert.Equal(t, "worker stopped: listen failed", report.Checks["stopped"].Error)
+	assert.Equal(t, StatusOK, report.Checks["running"].Status)
+
+	stale.Beat()
+	report = c.Check(context.Background())
+	assert.Equal(t, StatusOK, report.Checks["stale"].Status)
+}
+
+func TestMigrations(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	check := Migrations(db, 2)
+
+	_, err = check(ctx)
+	assert.ErrorContains(t, err, "failed to read schema version")
+
+	_, err = db.Exec(`CREATE TABLE schema_migrations (version bigint NOT NULL, dirty boolean NOT NULL)`)
+	require.NoError(t, err)
+	_, err = check(ctx)
+	assert.EqualError(t, err, "no migrations applied, expected version 2")
+
+	_, err = db.Exec(`INSERT INTO schema_migrations VALUES (1, false)`)
+	require.NoError(t, err)
+	_, err = check(ctx)
+	assert.EqualError(t, err, `schema version 1 is behind expected 2, run "migrate up"`)
+
+	_, err = db.Exec(`UPDATE schema_migrations SET version = 2, dirty = true`)
+	require.NoError(t, err)
+	_, err = check(ctx)
+	assert.EqualError(t, err, `schema version 2 is dirty, run "migrate force"`)
+
+	_, err = db.Exec(`UPDATE schema_migrations SET version = 3, dirty = false`)
+	require.NoError(t, err)
+	detail, err := check(ctx)
+	require.NoError(t, err, "schema ahead of the binary is fine during rollouts")
+	assert.Equal(t, "version 3, expected 2", detail)
+}
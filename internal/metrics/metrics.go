@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_service"
+
+// Metrics содержит Prometheus-метрики сервиса и собственный registry.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpDuration  *prometheus.HistogramVec
+	prsCreated    *prometheus.CounterVec
+	prsMerged     *prometheus.CounterVec
+	reassignments *prometheus.CounterVec
+	noCandidate   *prometheus.CounterVec
+}
+
+// New создаёт и регистрирует HTTP и бизнес-метрики.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		prsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_created_total",
+			Help:      "Pull requests created, by organization and author team.",
+		}, []string{"org_id", "team"}),
+		prsMerged: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_merged_total",
+			Help:      "Pull requests merged, by organization and author team.",
+		}, []string{"org_id", "team"}),
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_total",
+			Help:      "Successful reviewer reassignments, by organization and team.",
+		}, []string{"org_id", "team"}),
+		noCandidate: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_failures_total",
+			Help:      "Operations that failed because no reviewer candidate was available, by organization and team.",
+		}, []string{"org_id", "team", "operation"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.prsCreated,
+		m.prsMerged,
+		m.reassignments,
+		m.noCandidate,
+	)
+
+	return m
+}
+
+// RegisterDB добавляет статистику пула соединений sql.DB.
+func (m *Metrics) RegisterDB(db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, "pr_service"))
+}
+
+// Register добавляет произвольный коллектор в registry сервиса.
+func (m *Metrics) Register(c prometheus.Collector) {
+	m.registry.MustRegister(c)
+}
+
+// Handler возвращает HTTP handler для /metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveHTTPRequest записывает длительность обработки HTTP запроса.
+func (m *Metrics) ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	m.httpDuration.WithLabelValues(route, method, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// PRCreated увеличивает счётчик созданных PR.
+func (m *Metrics) PRCreated(orgID uuid.UUID, team string) {
+	m.prsCreated.WithLabelValues(orgID.String(), team).Inc()
+}
+
+// PRMerged увеличивает счётчик смерженных PR.
+func (m *Metrics) PRMerged(orgID uuid.UUID, team string) {
+	m.prsMerged.WithLabelValues(orgID.String(), team).Inc()
+}
+
+// ReviewerReassigned увеличивает счётчик переназначений.
+func (m *Metrics) ReviewerReassigned(orgID uuid.UUID, team string) {
+	m.reassignments.WithLabelValues(orgID.String(), team).Inc()
+}
+
+// NoCandidate увеличивает счётчик отказов из-за отсутствия кандидатов.
+func (m *Metrics) NoCandidate(orgID uuid.UUID, team, operation string) {
+	m.noCandidate.WithLabelValues(orgID.String(), team, operation).Inc()
+}
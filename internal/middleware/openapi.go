@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/openapi"
+)
+
+// OpenAPIValidation проверяет входящие запросы по спецификации и отвечает
+// 400 INVALID_REQUEST на несоответствие. При validateResponses ответы
+// буферизуются и проверяются тоже; ответ, расходящийся со спецификацией,
+// заменяется на 500 — режим для тестов и staging. Потоковые ответы
+// (text/event-stream) не буферизуются и не проверяются.
+func OpenAPIValidation(v *openapi.Validator, validateResponses bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, err := v.ValidateRequest(c.Request)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_REQUEST",
+					"message": openapi.ErrorMessage(err),
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		if op == nil || !validateResponses || op.Streaming() {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buf := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = buf
+
+		c.Next()
+
+		c.Writer = original
+
+		if err := v.ValidateResponse(c.Request.Context(), op, buf.status, original.Header(), buf.body.Bytes()); err != nil {
+			slog.ErrorContext(c.Request.Context(), "Response does not match OpenAPI spec",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"status", buf.status,
+				"error", err,
+			)
+			original.Header().Del("Content-Length")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "response does not match OpenAPI spec: " + openapi.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		original.WriteHeader(buf.status)
+		if _, err := original.Write(buf.body.Bytes()); err != nil {
+			slog.ErrorContext(c.Request.Context(), "Failed to write response", "error", err)
+		}
+	}
+}
+
+// bufferedWriter придерживает ответ до проверки по спецификации.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/ratelimit"
+)
+
+// TokenVerifier проверяет токен из Authorization: Bearer.
+type TokenVerifier func(ctx context.Context, token string) bool
+
+// RateLimit ограничивает частоту запросов по token bucket.
+// Клиент определяется по проверенному токену: admin токену или токену,
+// который принял verify. Остальные запросы, в том числе с неверным
+// токеном, считаются по IP адресу, иначе новый токен в каждом запросе
+// давал бы новый bucket. verify может быть nil.
+func RateLimit(limiter ratelimit.Limiter, policy ratelimit.Resolver, adminToken string, verify TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, role := clientIdentity(c, adminToken, verify)
+		route := c.Request.Method + " " + c.FullPath()
+
+		limit, scope := policy.Resolve(role, route)
+		res, err := limiter.Allow(c.Request.Context(), identity+"|"+scope, limit)
+		if err != nil {
+			// Сбой хранилища лимитов не должен останавливать сервис.
+			slog.ErrorContext(c.Request.Context(), "Rate limiter failed", "error", err)
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(res.ResetAfter.Seconds()))))
+
+		if !res.Allowed {
+			retryAfter := int(math.Ceil(res.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+			slog.WarnContext(c.Request.Context(), "Rate limit exceeded",
+				"role", role,
+				"route", route,
+				"retry_after", retryAfter,
+			)
+
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": gin.H{
+					"code":    "RATE_LIMITED",
+					"message": "too many requests",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// clientIdentity возвращает ключ клиента и его роль.
+// Токены хешируются, чтобы не хранить их в открытом виде в ключах bucket'ов.
+func clientIdentity(c *gin.Context, adminToken string, verify TokenVerifier) (identity, role string) {
+	if token := c.GetHeader("X-Admin-Token"); adminToken != "" && token == adminToken {
+		return "token:" + hashToken(token), ratelimit.RoleAdmin
+	}
+
+	if auth := c.GetHeader("Authorization"); verify != nil && strings.HasPrefix(auth, "Bearer ") {
+		token := strings.TrimPrefix(auth, "Bearer ")
+		if verify(c.Request.Context(), token) {
+			return "token:" + hashToken(token), ratelimit.RoleClient
+		}
+	}
+
+	return "ip:" + c.ClientIP(), ratelimit.RoleAnonymous
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/tracing"
+)
+
+const (
+	RequestIDHeader   = "X-Request-ID"
+	TraceparentHeader = "traceparent"
+
+	maxRequestIDLength = 128
+)
+
+// RequestContext принимает или генерирует X-Request-ID, разбирает W3C traceparent
+// и кладёт оба значения в контекст запроса.
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = tracing.NewRequestID()
+		}
+
+		tc, ok := tracing.ParseTraceparent(c.GetHeader(TraceparentHeader))
+		if ok {
+			tc = tc.Child()
+		} else {
+			tc = tracing.NewTraceContext()
+		}
+
+		ctx := tracing.WithRequestID(c.Request.Context(), requestID)
+		ctx = tracing.WithTraceContext(ctx, tc)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Header(RequestIDHeader, requestID)
+		c.Header(TraceparentHeader, tc.Traceparent())
+
+		c.Next()
+	}
+}
+
+// AccessLog пишет одну структурированную запись на каждый запрос.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		}
+
+		slog.Log(c.Request.Context(), level, "HTTP request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"route", c.FullPath(),
+			"status", status,
+			"duration_ms", float64(time.Since(start).Microseconds())/1000,
+			"bytes", c.Writer.Size(),
+			"client_ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+		)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Tenant определяет организацию по токену из Authorization: Bearer.
+// Запросы без токена относятся к организации по умолчанию, если реестр
+// создан с tenant.WithDefaultFallback, иначе отклоняются.
+func Tenant(registry *tenant.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.GetHeader("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") {
+			if registry.DefaultFallback() {
+				c.Request = c.Request.WithContext(tenant.WithDefault(c.Request.Context()))
+				c.Next()
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": "organization token required",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		org, err := registry.Authenticate(c.Request.Context(), strings.TrimPrefix(auth, "Bearer "))
+		if err != nil {
+			if err.Error() == "ORG_NOT_FOUND" {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": gin.H{
+						"code":    "UNAUTHORIZED",
+						"message": "invalid organization token",
+					},
+				})
+				c.Abort()
+				return
+			}
+
+			slog.ErrorContext(c.Request.Context(), "Failed to authenticate organization", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "failed to authenticate organization",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(tenant.WithOrg(c.Request.Context(), org.OrgID))
+		c.Next()
+	}
+}
+
+// DefaultTenant относит все запросы к организации по умолчанию. Используется,
+// когда организации выключены.
+func DefaultTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenant.WithDefault(c.Request.Context()))
+		c.Next()
+	}
+}
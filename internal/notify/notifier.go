@@ -0,0 +1,401 @@
+// Package notify отправляет email-уведомления ревьюверам: письма о
+// назначении и переназначении ревью и ежедневную сводку открытых ревью.
+package notify
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Directory источник пользователей и их ревью; его реализует репозиторий.
+type Directory interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+	GetPRsByReviewer(ctx context.Context, userID uuid.UUID) ([]domain.PullRequestShort, error)
+}
+
+// Notifier отправляет письма о назначениях и ежедневные сводки. Реализует
+// service.EventPublisher: события ставятся в очередь и отправляются в Run,
+// не задерживая ответ API. Очередь хранится в памяти, при остановке
+// процесса неотправленные письма теряются.
+type Notifier struct {
+	dir        Directory
+	store      Store
+	mailer     Mailer
+	templates  *Templates
+	queue      chan []domain.ReviewEvent
+	now        func() time.Time
+	digestBeat func()
+}
+
+// Option настраивает Notifier.
+type Option func(*Notifier)
+
+// WithTemplates заменяет шаблоны писем по умолчанию.
+func WithTemplates(t *Templates) Option {
+	return func(n *Notifier) {
+		n.templates = t
+	}
+}
+
+// WithDigestHeartbeat передаёт функцию, которую RunDigest вызывает после
+// каждой разосланной сводки, например для проверки готовности.
+func WithDigestHeartbeat(beat func()) Option {
+	return func(n *Notifier) {
+		n.digestBeat = beat
+	}
+}
+
+// queueSize сколько операций может ждать отправки писем.
+const queueSize = 1024
+
+func NewNotifier(dir Directory, store Store, mailer Mailer, opts ...Option) *Notifier {
+	n := &Notifier{
+		dir:       dir,
+		store:     store,
+		mailer:    mailer,
+		templates: DefaultTemplates(),
+		queue:     make(chan []domain.ReviewEvent, queueSize),
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// Publish реализует service.EventPublisher. События одной операции
+// обрабатываются вместе, чтобы при переназначении написать обоим
+// ревьюверам друг о друге.
+func (n *Notifier) Publish(ctx context.Context, events ...domain.ReviewEvent) {
+	var batch []domain.ReviewEvent
+	for _, e := range events {
+		if e.Type == domain.EventReviewAssigned || e.Type == domain.EventReviewUnassigned {
+			if e.OrgID == uuid.Nil {
+				e.OrgID = tenant.OrgID(ctx)
+			}
+			batch = append(batch, e)
+		}
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	select {
+	case n.queue <- batch:
+	default:
+		slog.ErrorContext(ctx, "Notification queue is full, emails dropped", "pr_id", batch[0].PullRequestID)
+	}
+}
+
+// Run отправляет письма из очереди, пока не отменён ctx.
+func (n *Notifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case batch := <-n.queue:
+			// События одной операции относятся к одной организации.
+			n.notify(tenant.WithOrg(ctx, batch[0].OrgID), batch)
+		}
+	}
+}
+
+// notify пишет ревьюверам одной операции. Снятие одного ревьювера вместе
+// с назначением другого на тот же PR — переназначение.
+func (n *Notifier) notify(ctx context.Context, batch []domain.ReviewEvent) {
+	names := n.names()
+	byPR := make(map[uuid.UUID][]domain.ReviewEvent)
+	var order []uuid.UUID
+	for _, e := range batch {
+		if _, ok := byPR[e.PullRequestID]; !ok {
+			order = append(order, e.PullRequestID)
+		}
+		byPR[e.PullRequestID] = append(byPR[e.PullRequestID], e)
+	}
+
+	for _, prID := range order {
+		var assigned, unassigned []domain.ReviewEvent
+		for _, e := range byPR[prID] {
+			if e.Type == domain.EventReviewAssigned {
+				assigned = append(assigned, e)
+			} else {
+				unassigned = append(unassigned, e)
+			}
+		}
+
+		reassigned := len(assigned) == 1 && len(unassigned) == 1
+		for _, e := range assigned {
+			data := n.reviewData(ctx, names, e)
+			tmpl := n.templates.assigned
+			if reassigned {
+				tmpl = n.templates.reassigned
+				data.PreviousReviewer = names(ctx, unassigned[0].UserID)
+			}
+			n.sendReview(ctx, e, tmpl, data)
+		}
+		for _, e := range unassigned {
+			data := n.reviewData(ctx, names, e)
+			if reassigned {
+				data.NewReviewer = names(ctx, assigned[0].UserID)
+			}
+			n.sendReview(ctx, e, n.templates.unassigned, data)
+		}
+	}
+}
+
+func (n *Notifier) reviewData(ctx context.Context, names func(context.Context, uuid.UUID) string, e domain.ReviewEvent) ReviewData {
+	return ReviewData{
+		Username:        names(ctx, e.UserID),
+		PullRequestID:   e.PullRequestID,
+		PullRequestName: e.PullRequestName,
+		Author:          names(ctx, e.AuthorID),
+	}
+}
+
+func (n *Notifier) sendReview(ctx context.Context, e domain.ReviewEvent, tmpl compiled, data ReviewData) {
+	prefs, err := n.store.Get(ctx, e.UserID)
+	if err != nil {
+		if err.Error() != "PREFERENCES_NOT_FOUND" {
+			slog.ErrorContext(ctx, "Failed to get notification preferences", "user_id", e.UserID, "error", err)
+		}
+		return
+	}
+	if prefs.Email == "" || !prefs.Assignments {
+		return
+	}
+
+	if err := n.send(ctx, prefs.Email, tmpl, data); err != nil {
+		slog.ErrorContext(ctx, "Failed to send review notification",
+			"user_id", e.UserID,
+			"pr_id", e.PullRequestID,
+			"event", e.Type,
+			"error", err,
+		)
+		return
+	}
+	slog.InfoContext(ctx, "Review notification sent", "user_id", e.UserID, "pr_id", e.PullRequestID, "event", e.Type)
+}
+
+func (n *Notifier) send(ctx context.Context, to string, tmpl compiled, data any) error {
+	subject, body, err := tmpl.render(data)
+	if err != nil {
+		return err
+	}
+	return n.mailer.Send(ctx, Message{To: to, Subject: subject, Body: body})
+}
+
+// names возвращает функцию поиска имени пользователя с кэшем на время
+// одной рассылки. Если пользователя нет, вместо имени используется user_id.
+func (n *Notifier) names() func(context.Context, uuid.UUID) string {
+	cache := make(map[uuid.UUID]string)
+	return func(ctx context.Context, userID uuid.UUID) string {
+		if name, ok := cache[userID]; ok {
+			return name
+		}
+		name := userID.String()
+		if user, err := n.dir.GetUserByID(ctx, userID); err == nil {
+			name = user.Username
+		} else if err.Error() != "USER_NOT_FOUND" {
+			slog.WarnContext(ctx, "Failed to get user name for notification", "user_id", userID, "error", err)
+		}
+		cache[userID] = name
+		return name
+	}
+}
+
+// PreferencesUpdate изменение настроек; nil поля не меняются.
+type PreferencesUpdate struct {
+	Email       *string
+	Assignments *bool
+	Digest      *bool
+}
+
+// Preferences возвращает настройки пользователя, по умолчанию —
+// DefaultPreferences. Ошибка "USER_NOT_FOUND", если пользователя нет.
+func (n *Notifier) Preferences(ctx context.Context, userID uuid.UUID) (*Preferences, error) {
+	if _, err := n.dir.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	prefs, err := n.store.Get(ctx, userID)
+	if err != nil {
+		if err.Error() == "PREFERENCES_NOT_FOUND" {
+			defaults := DefaultPreferences(userID)
+			defaults.OrgID = tenant.OrgID(ctx)
+			return &defaults, nil
+		}
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences меняет настройки пользователя. Ошибки "USER_NOT_FOUND"
+// и "INVALID_EMAIL"; пустой email выключает письма.
+func (n *Notifier) UpdatePreferences(ctx context.Context, userID uuid.UUID, upd PreferencesUpdate) (*Preferences, error) {
+	prefs, err := n.Preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.Email != nil {
+		email := strings.TrimSpace(*upd.Email)
+		if email != "" {
+			addr, err := mail.ParseAddress(email)
+			if err != nil || addr.Address != email {
+				return nil, errors.New("INVALID_EMAIL")
+			}
+		}
+		prefs.Email = email
+	}
+	if upd.Assignments != nil {
+		prefs.Assignments = *upd.Assignments
+	}
+	if upd.Digest != nil {
+		prefs.Digest = *upd.Digest
+	}
+
+	if err := n.store.Save(ctx, *prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// SendDigests отправляет сводку открытых ревью каждому активному
+// пользователю, подписанному на неё. Пользователи без открытых ревью
+// письмо не получают. Возвращает число отправленных писем.
+func (n *Notifier) SendDigests(ctx context.Context) (int, error) {
+	list, err := n.store.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	names := n.names()
+	now := n.now()
+	sent := 0
+	for _, prefs := range list {
+		if !prefs.Digest {
+			continue
+		}
+
+		orgCtx := tenant.WithOrg(ctx, prefs.OrgID)
+		data, ok := n.digestData(orgCtx, names, prefs.UserID, now)
+		if !ok {
+			continue
+		}
+		if err := n.send(orgCtx, prefs.Email, n.templates.digest, data); err != nil {
+			slog.ErrorContext(ctx, "Failed to send review digest", "user_id", prefs.UserID, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// digestData собирает открытые ревью пользователя, самые старые первыми.
+// false, если сводка не нужна: пользователь неактивен, удалён или у него
+// нет открытых ревью.
+func (n *Notifier) digestData(ctx context.Context, names func(context.Context, uuid.UUID) string, userID uuid.UUID, now time.Time) (DigestData, bool) {
+	user, err := n.dir.GetUserByID(ctx, userID)
+	if err != nil {
+		if err.Error() != "USER_NOT_FOUND" {
+			slog.ErrorContext(ctx, "Failed to get user for digest", "user_id", userID, "error", err)
+		}
+		return DigestData{}, false
+	}
+	if !user.IsActive {
+		return DigestData{}, false
+	}
+
+	prs, err := n.dir.GetPRsByReviewer(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get reviews for digest", "user_id", userID, "error", err)
+		return DigestData{}, false
+	}
+
+	var open []domain.PullRequestShort
+	for _, pr := range prs {
+		if pr.Status == domain.StatusOpen {
+			open = append(open, pr)
+		}
+	}
+	if len(open) == 0 {
+		return DigestData{}, false
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		return open[i].CreatedAt.Before(open[j].CreatedAt)
+	})
+
+	data := DigestData{Username: user.Username, Reviews: make([]DigestReview, len(open))}
+	for i, pr := range open {
+		data.Reviews[i] = DigestReview{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			Author:          names(ctx, pr.AuthorID),
+			CreatedAt:       pr.CreatedAt,
+			Waiting:         waiting(now.Sub(pr.CreatedAt)),
+		}
+	}
+	return data, true
+}
+
+// RunDigest отправляет сводку каждый день в момент at от полуночи по
+// часовому поясу сервера, пока не отменён ctx. Сводку за день отправляет
+// одна реплика — та, что первой отметит её в Store.
+func (n *Notifier) RunDigest(ctx context.Context, at time.Duration) {
+	for {
+		next := nextDigest(n.now(), at)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		day := next.Format(time.DateOnly)
+		claimed, err := n.store.ClaimDigest(ctx, day)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to claim review digest", "day", day, "error", err)
+			continue
+		}
+		if !claimed {
+			slog.DebugContext(ctx, "Review digest already sent by another replica", "day", day)
+			n.beatDigest()
+			continue
+		}
+
+		sent, err := n.SendDigests(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to send review digests", "day", day, "error", err)
+			continue
+		}
+		slog.InfoContext(ctx, "Review digests sent", "day", day, "sent", sent)
+		n.beatDigest()
+	}
+}
+
+func (n *Notifier) beatDigest() {
+	if n.digestBeat != nil {
+		n.digestBeat()
+	}
+}
+
+// nextDigest ближайший после now момент at от полуночи.
+func nextDigest(now time.Time, at time.Duration) time.Time {
+	hour, minute := int(at/time.Hour), int(at%time.Hour/time.Minute)
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = time.Date(now.Year(), now.Month(), now.Day()+1, hour, minute, 0, 0, now.Location())
+	}
+	return next
+}
@@ -0,0 +1,304 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/notify/notifytest"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/repository/repotest"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+var (
+	alice = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	bob   = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	carol = uuid.MustParse("550e8400-e29b-41d4-a716-446655440003")
+	dave  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440004")
+)
+
+type fixture struct {
+	repo     repository.RepositoryInterface
+	svc      service.ServiceInterface
+	notifier *Notifier
+	store    *MemoryStore
+	smtp     *notifytest.Server
+}
+
+// newFixture команда backend из alice, bob и carol; письма уходят на
+// поддельный SMTP сервер.
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	smtp := notifytest.NewServer(t)
+	smtp.RequireAuth("mailer", "secret")
+	mailer := newMailer(t, smtp, "secret")
+
+	repo := repository.NewMemoryRepository()
+	store := NewMemoryStore()
+	notifier := NewNotifier(repo, store, mailer)
+	svc := service.NewReviewerService(repo, service.WithEvents(notifier))
+
+	repotest.Team(t, context.Background(), repo, "backend",
+		repotest.Member(alice, "alice"),
+		repotest.Member(bob, "bob"),
+		repotest.Member(carol, "carol"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go notifier.Run(ctx)
+
+	return &fixture{repo: repo, svc: svc, notifier: notifier, store: store, smtp: smtp}
+}
+
+// newMailer отправляет письма на поддельный сервер от пользователя mailer.
+func newMailer(t *testing.T, smtp *notifytest.Server, password string) *SMTPMailer {
+	t.Helper()
+
+	mailer, err := NewSMTPMailer(SMTPConfig{
+		Addr:     smtp.Addr(),
+		Username: "mailer",
+		Password: password,
+		From:     "Reviewer Service <reviews@example.com>",
+	})
+	require.NoError(t, err)
+	return mailer
+}
+
+func (f *fixture) subscribe(t *testing.T, userID uuid.UUID, email string) {
+	t.Helper()
+	_, err := f.notifier.UpdatePreferences(context.Background(), userID, PreferencesUpdate{Email: &email})
+	require.NoError(t, err)
+}
+
+func TestNotifier_AssignmentAndReassignment(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	f.subscribe(t, bob, "bob@example.com")
+	f.subscribe(t, carol, "carol@example.com")
+
+	prID := uuid.New()
+	_, err := f.svc.CreatePR(ctx, prID, "Add search", alice)
+	require.NoError(t, err)
+
+	mails := f.smtp.WaitMails(t, 2)
+	byRecipient := make(map[string]notifytest.Mail)
+	for _, m := range mails {
+		require.Len(t, m.To, 1)
+		byRecipient[m.To[0]] = m
+	}
+	require.Contains(t, byRecipient, "bob@example.com")
+	require.Contains(t, byRecipient, "carol@example.com")
+
+	m := byRecipient["bob@example.com"]
+	assert.Equal(t, "reviews@example.com", m.From)
+	assert.Equal(t, "mailer", m.Username)
+	assert.Equal(t, "Review requested: Add search", m.Subject)
+	assert.Equal(t, `"Reviewer Service" <reviews@example.com>`, m.Header.Get("From"))
+	assert.Equal(t, "Hi bob,\n\nalice opened \"Add search\" and you were assigned to review it.\n\nPull request: "+prID.String()+"\n", m.Body)
+
+	// dave без настроек: при переназначении пишут только bob.
+	require.NoError(t, f.repo.UpsertUser(ctx, &domain.User{UserID: dave, Username: "dave", TeamName: "backend", IsActive: true}))
+	_, newReviewer, err := f.svc.ReassignReviewer(ctx, prID, bob)
+	require.NoError(t, err)
+	require.Equal(t, dave, newReviewer)
+
+	mails = f.smtp.WaitMails(t, 3)
+	m = mails[2]
+	assert.Equal(t, []string{"bob@example.com"}, m.To)
+	assert.Equal(t, "Review reassigned: Add search", m.Subject)
+	assert.Contains(t, m.Body, `You no longer need to review "Add search" by alice: it was reassigned to dave.`)
+
+	f.subscribe(t, dave, "dave@example.com")
+	_, _, err = f.svc.ReassignReviewer(ctx, prID, dave)
+	require.NoError(t, err)
+
+	mails = f.smtp.WaitMails(t, 5)
+	byRecipient = map[string]notifytest.Mail{mails[3].To[0]: mails[3], mails[4].To[0]: mails[4]}
+	assert.Equal(t, "Review reassigned to you: Add search", byRecipient["bob@example.com"].Subject)
+	assert.Contains(t, byRecipient["bob@example.com"].Body, `was handed over to you from dave.`)
+	assert.Equal(t, "Review reassigned: Add search", byRecipient["dave@example.com"].Subject)
+}
+
+func TestNotifier_Preferences(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	prefs, err := f.notifier.Preferences(ctx, bob)
+	require.NoError(t, err)
+	defaults := DefaultPreferences(bob)
+	defaults.OrgID = tenant.DefaultOrgID
+	assert.Equal(t, defaults, *prefs)
+
+	_, err = f.notifier.Preferences(ctx, uuid.New())
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+
+	for _, email := range []string{"bob", "Bob <bob@example.com>", "bob@example.com\r\nBcc: x@example.com"} {
+		_, err = f.notifier.UpdatePreferences(ctx, bob, PreferencesUpdate{Email: &email})
+		assert.EqualError(t, err, "INVALID_EMAIL", email)
+	}
+
+	email, off := " bob@example.com ", false
+	prefs, err = f.notifier.UpdatePreferences(ctx, bob, PreferencesUpdate{Email: &email, Assignments: &off})
+	require.NoError(t, err)
+	assert.Equal(t, Preferences{UserID: bob, Email: "bob@example.com", Digest: true, OrgID: tenant.DefaultOrgID}, *prefs)
+
+	// Частичное изменение не трогает остальные настройки.
+	prefs, err = f.notifier.UpdatePreferences(ctx, bob, PreferencesUpdate{Digest: &off})
+	require.NoError(t, err)
+	assert.Equal(t, Preferences{UserID: bob, Email: "bob@example.com", OrgID: tenant.DefaultOrgID}, *prefs)
+
+	// Письма о назначениях выключены.
+	f.subscribe(t, carol, "carol@example.com")
+	_, err = f.svc.CreatePR(ctx, uuid.New(), "Add search", alice)
+	require.NoError(t, err)
+	mails := f.smtp.WaitMails(t, 1)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, f.smtp.Mails(), 1)
+	assert.Equal(t, []string{"carol@example.com"}, mails[0].To)
+}
+
+func TestNotifier_SendDigests(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	f.subscribe(t, bob, "bob@example.com")
+	f.subscribe(t, carol, "carol@example.com")
+	f.subscribe(t, alice, "alice@example.com")
+
+	older, newer, merged := uuid.New(), uuid.New(), uuid.New()
+	_, err := f.svc.CreatePR(ctx, older, "Older", alice)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	_, err = f.svc.CreatePR(ctx, newer, "Newer", alice)
+	require.NoError(t, err)
+	_, err = f.svc.CreatePR(ctx, merged, "Merged", alice)
+	require.NoError(t, err)
+	_, err = f.svc.MergePR(ctx, merged)
+	require.NoError(t, err)
+	f.smtp.WaitMails(t, 6)
+
+	// carol ушла в отпуск, alice ревью не назначены.
+	_, err = f.svc.SetUserActive(ctx, carol, false)
+	require.NoError(t, err)
+	f.notifier.now = func() time.Time { return time.Now().Add(26 * time.Hour) }
+
+	sent, err := f.notifier.SendDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	mails := f.smtp.WaitMails(t, 7)
+	m := mails[6]
+	assert.Equal(t, []string{"bob@example.com"}, m.To)
+	assert.Equal(t, "2 open reviews waiting for you", m.Subject)
+	assert.Equal(t, "Hi bob,\n\nThese pull requests are waiting for your review, oldest first:\n\n"+
+		"- Older by alice, waiting 1d 2h ("+older.String()+")\n"+
+		"- Newer by alice, waiting 1d 2h ("+newer.String()+")\n", m.Body)
+
+	// Отписка от сводки.
+	off := false
+	_, err = f.notifier.UpdatePreferences(ctx, bob, PreferencesUpdate{Digest: &off})
+	require.NoError(t, err)
+	sent, err = f.notifier.SendDigests(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
+
+func TestNotifier_DigestHeartbeat(t *testing.T) {
+	f := newFixture(t)
+	beats := make(chan struct{}, 1)
+	WithDigestHeartbeat(func() {
+		select {
+		case beats <- struct{}{}:
+		default:
+		}
+	})(f.notifier)
+	// Время сводки уже прошло, таймер срабатывает сразу.
+	f.notifier.now = func() time.Time { return time.Date(2024, 1, 1, 8, 59, 0, 0, time.UTC) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go f.notifier.RunDigest(ctx, 9*time.Hour)
+
+	select {
+	case <-beats:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no heartbeat after digest")
+	}
+}
+
+func TestNotifier_Organizations(t *testing.T) {
+	f := newFixture(t)
+	ctx := tenant.WithOrg(context.Background(), uuid.New())
+	erin, frank, grace := uuid.New(), uuid.New(), uuid.New()
+	repotest.Team(t, ctx, f.repo, "backend",
+		repotest.Member(erin, "erin"),
+		repotest.Member(frank, "frank"),
+		repotest.Member(grace, "grace"),
+	)
+	email := "frank@example.com"
+	_, err := f.notifier.UpdatePreferences(ctx, frank, PreferencesUpdate{Email: &email})
+	require.NoError(t, err)
+
+	// Фоновая отправка находит пользователей в организации PR.
+	_, err = f.svc.CreatePR(ctx, uuid.New(), "Add billing", erin)
+	require.NoError(t, err)
+	mails := f.smtp.WaitMails(t, 1)
+	assert.Equal(t, []string{"frank@example.com"}, mails[0].To)
+	assert.Contains(t, mails[0].Body, "Hi frank,\n\nerin opened")
+
+	f.notifier.now = func() time.Time { return time.Now().Add(time.Hour) }
+	sent, err := f.notifier.SendDigests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	mails = f.smtp.WaitMails(t, 2)
+	assert.Equal(t, "1 open review waiting for you", mails[1].Subject)
+}
+
+func TestNotifier_SMTPFailureIsLogged(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	f.subscribe(t, bob, "bob@example.com")
+	f.smtp.Fail(1)
+
+	_, err := f.svc.CreatePR(ctx, uuid.New(), "First", alice)
+	require.NoError(t, err)
+	_, err = f.svc.CreatePR(ctx, uuid.New(), "Second", alice)
+	require.NoError(t, err)
+
+	// Первое письмо отклонено, очередь не останавливается.
+	mails := f.smtp.WaitMails(t, 1)
+	assert.Equal(t, "Review requested: Second", mails[0].Subject)
+}
+
+func TestSMTPMailer(t *testing.T) {
+	smtp := notifytest.NewServer(t)
+	smtp.RequireAuth("mailer", "secret")
+
+	mailer := newMailer(t, smtp, "wrong")
+	err := mailer.Send(context.Background(), Message{To: "bob@example.com", Subject: "Hi", Body: "Hello"})
+	assert.ErrorContains(t, err, "SMTP authentication failed")
+
+	mailer = newMailer(t, smtp, "secret")
+	long := "Ревью: строка длиннее семидесяти шести символов, чтобы quoted-printable её перенёс"
+	require.NoError(t, mailer.Send(context.Background(), Message{To: "bob@example.com", Subject: "Ревью ждёт", Body: long + "\n"}))
+
+	mails := smtp.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, "Ревью ждёт", mails[0].Subject)
+	assert.Equal(t, long+"\n", mails[0].Body)
+	assert.NotEmpty(t, mails[0].Header.Get("Message-Id"))
+	assert.NotEmpty(t, mails[0].Header.Get("Date"))
+
+	_, err = NewSMTPMailer(SMTPConfig{Addr: "smtp.example.com", From: "reviews@example.com"})
+	assert.Error(t, err)
+	_, err = NewSMTPMailer(SMTPConfig{Addr: "smtp.example.com:587", From: "not an address"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+func TestStore(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(*testing.T) Store {
+			return NewMemoryStore()
+		},
+		"sqlite": func(t *testing.T) Store {
+			cfg := &config.Config{DatabaseURL: "sqlite://" + filepath.Join(t.TempDir(), "test.db")}
+
+			db, err := cfg.ConnectDB()
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+
+			require.NoError(t, cfg.RunMigrations(db.DB))
+			return NewSQLStore(db.DB)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore(t)
+			first, second := uuid.New(), uuid.New()
+
+			_, err := store.Get(ctx, first)
+			assert.EqualError(t, err, "PREFERENCES_NOT_FOUND")
+
+			require.NoError(t, store.Save(ctx, Preferences{UserID: first, Email: "first@example.com", Assignments: true}))
+			require.NoError(t, store.Save(ctx, Preferences{UserID: second, Digest: true}))
+
+			prefs, err := store.Get(ctx, first)
+			require.NoError(t, err)
+			assert.Equal(t, Preferences{UserID: first, Email: "first@example.com", Assignments: true, OrgID: tenant.DefaultOrgID}, *prefs)
+
+			// Настройки другой организации не видны.
+			_, err = store.Get(tenant.WithOrg(ctx, uuid.New()), first)
+			assert.EqualError(t, err, "PREFERENCES_NOT_FOUND")
+
+			// Повторное сохранение заменяет настройки.
+			require.NoError(t, store.Save(ctx, Preferences{UserID: first, Email: "first@example.org", Digest: true}))
+			prefs, err = store.Get(ctx, first)
+			require.NoError(t, err)
+			assert.Equal(t, Preferences{UserID: first, Email: "first@example.org", Digest: true, OrgID: tenant.DefaultOrgID}, *prefs)
+
+			// Без адреса пользователь в рассылку не попадает.
+			list, err := store.List(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []Preferences{*prefs}, list)
+
+			// Удаление в другой организации ничего не меняет.
+			require.NoError(t, store.Delete(tenant.WithOrg(ctx, uuid.New()), first))
+			_, err = store.Get(ctx, first)
+			require.NoError(t, err)
+			require.NoError(t, store.Delete(ctx, first))
+			_, err = store.Get(ctx, first)
+			assert.EqualError(t, err, "PREFERENCES_NOT_FOUND")
+			require.NoError(t, store.Delete(ctx, first))
+
+			claimed, err := store.ClaimDigest(ctx, "2026-10-19")
+			require.NoError(t, err)
+			assert.True(t, claimed)
+			claimed, err = store.ClaimDigest(ctx, "2026-10-19")
+			require.NoError(t, err)
+			assert.False(t, claimed)
+			claimed, err = store.ClaimDigest(ctx, "2026-10-20")
+			require.NoError(t, err)
+			assert.True(t, claimed)
+		})
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "templates.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	data := ReviewData{Username: "alice", PullRequestID: uuid.New(), PullRequestName: "Add search", Author: "bob"}
+
+	tmpl, err := LoadTemplates(write(`
+assigned:
+  subject: "[reviews] {{.Author}} needs you on {{.PullRequestName}}"
+`))
+	require.NoError(t, err)
+
+	subject, body, err := tmpl.assigned.render(data)
+	require.NoError(t, err)
+	assert.Equal(t, "[reviews] bob needs you on Add search", subject)
+	// Тело не переопределено и берётся по умолчанию.
+	assert.Contains(t, body, `bob opened "Add search" and you were assigned to review it.`)
+
+	_, err = LoadTemplates(write(`
+digest:
+  body: "{{range .Reviews}}{{.Title}}{{end}}"
+`))
+	assert.ErrorContains(t, err, "digest.body")
+	assert.ErrorContains(t, err, "can't evaluate field Title")
+
+	_, err = LoadTemplates(write(`
+assigned:
+  subject: "{{.PullRequestName"
+`))
+	assert.ErrorContains(t, err, "assigned subject")
+
+	_, err = LoadTemplates(write(`
+merged:
+  subject: "PR merged"
+`))
+	assert.ErrorContains(t, err, "field merged not found")
+
+	_, err = LoadTemplates(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestTemplates_SubjectIsSingleLine(t *testing.T) {
+	subject, _, err := DefaultTemplates().assigned.render(ReviewData{PullRequestName: "Fix\r\nBcc: victim@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "Review requested: Fix Bcc: victim@example.com", subject)
+}
+
+func TestWaiting(t *testing.T) {
+	assert.Equal(t, "0m", waiting(-time.Minute))
+	assert.Equal(t, "45m", waiting(45*time.Minute))
+	assert.Equal(t, "5h", waiting(5*time.Hour+59*time.Minute))
+	assert.Equal(t, "2d 3h", waiting(51*time.Hour+10*time.Minute))
+}
+
+func TestNextDigest(t *testing.T) {
+	at := 9*time.Hour + 30*time.Minute
+	morning := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC), nextDigest(morning, at))
+	assert.Equal(t, time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC), nextDigest(morning.Add(90*time.Minute), at))
+	assert.Equal(t, time.Date(2026, 11, 1, 9, 30, 0, 0, time.UTC), nextDigest(time.Date(2026, 10, 31, 23, 0, 0, 0, time.UTC), at))
+}
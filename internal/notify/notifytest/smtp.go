@@ -0,0 +1,250 @@
+// Package notifytest содержит поддельный SMTP сервер для тестов
+// уведомлений: он принимает письма, хранит их разобранными и умеет
+// требовать аутентификацию или отвечать временной ошибкой.
+package notifytest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Mail письмо, принятое сервером.
+type Mail struct {
+	From string
+	To   []string
+	// Username логин AUTH PLAIN, пустой без аутентификации.
+	Username string
+	Header   mail.Header
+	// Subject декодированная тема.
+	Subject string
+	// Body декодированное тело.
+	Body string
+}
+
+// Server поддельный SMTP сервер на 127.0.0.1.
+type Server struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	mails    []Mail
+	username string
+	password string
+	failures int
+	received chan struct{}
+}
+
+// NewServer запускает сервер, он останавливается по завершении теста.
+func NewServer(t testing.TB) *Server {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("notifytest: failed to listen: %v", err)
+	}
+
+	s := &Server{ln: ln, received: make(chan struct{}, 1)}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+// Addr адрес host:port для SMTPConfig.Addr.
+func (s *Server) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// RequireAuth принимает письма только после AUTH PLAIN с этими данными.
+func (s *Server) RequireAuth(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username, s.password = username, password
+}
+
+// Fail отвечает временной ошибкой 451 на следующие n писем.
+func (s *Server) Fail(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures += n
+}
+
+// Mails возвращает принятые письма.
+func (s *Server) Mails() []Mail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Mail(nil), s.mails...)
+}
+
+// WaitMails ждёт, пока сервер примет n писем, и возвращает их.
+func (s *Server) WaitMails(t testing.TB, n int) []Mail {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		if mails := s.Mails(); len(mails) >= n {
+			return mails
+		}
+		select {
+		case <-s.received:
+		case <-timeout:
+			t.Fatalf("notifytest: got %d mails, want %d", len(s.Mails()), n)
+		}
+	}
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(conn)
+	}
+}
+
+// session один SMTP диалог: EHLO, AUTH PLAIN, MAIL, RCPT, DATA, RSET, QUIT.
+func (s *Server) session(conn net.Conn) {
+	defer conn.Close()
+
+	r := textproto.NewReader(bufio.NewReader(conn))
+	reply := func(line string) {
+		io.WriteString(conn, line+"\r\n")
+	}
+
+	var from, username string
+	var to []string
+	authenticated := false
+
+	reply("220 notifytest ESMTP")
+	for {
+		line, err := r.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250-notifytest")
+			reply("250-8BITMIME")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			user, ok := s.auth(arg)
+			if !ok {
+				reply("535 5.7.8 Authentication failed")
+				continue
+			}
+			authenticated, username = true, user
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			if s.requiresAuth() && !authenticated {
+				reply("530 5.7.0 Authentication required")
+				continue
+			}
+			if s.takeFailure() {
+				reply("451 4.3.0 Try again later")
+				continue
+			}
+			from, to = address(arg), nil
+			reply("250 2.1.0 OK")
+		case "RCPT":
+			to = append(to, address(arg))
+			reply("250 2.1.5 OK")
+		case "DATA":
+			reply("354 Start mail input; end with <CRLF>.<CRLF>")
+			data, err := r.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.store(from, to, username, data)
+			reply("250 2.0.0 OK")
+		case "RSET":
+			from, to = "", nil
+			reply("250 2.0.0 OK")
+		case "NOOP":
+			reply("250 2.0.0 OK")
+		case "QUIT":
+			reply("221 2.0.0 Bye")
+			return
+		default:
+			reply("502 5.5.2 Command not implemented")
+		}
+	}
+}
+
+func (s *Server) requiresAuth() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.username != ""
+}
+
+func (s *Server) takeFailure() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures == 0 {
+		return false
+	}
+	s.failures--
+	return true
+}
+
+// auth проверяет "PLAIN <base64(\x00user\x00password)>".
+func (s *Server) auth(arg string) (string, bool) {
+	mechanism, initial, _ := strings.Cut(arg, " ")
+	if !strings.EqualFold(mechanism, "PLAIN") {
+		return "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(initial)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.Split(string(decoded), "\x00")
+	if len(parts) != 3 {
+		return "", false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if parts[1] != s.username || parts[2] != s.password {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func (s *Server) store(from string, to []string, username string, data []byte) {
+	m := Mail{From: from, To: to, Username: username}
+	if msg, err := mail.ReadMessage(bytes.NewReader(data)); err == nil {
+		m.Header = msg.Header
+		m.Subject, _ = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		body := msg.Body
+		if strings.EqualFold(msg.Header.Get("Content-Transfer-Encoding"), "quoted-printable") {
+			body = quotedprintable.NewReader(body)
+		}
+		raw, _ := io.ReadAll(body)
+		m.Body = strings.ReplaceAll(string(raw), "\r\n", "\n")
+	}
+
+	s.mu.Lock()
+	s.mails = append(s.mails, m)
+	s.mu.Unlock()
+
+	select {
+	case s.received <- struct{}{}:
+	default:
+	}
+}
+
+// address извлекает адрес из "FROM:<a@b>" или "TO:<a@b>".
+func address(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+	return strings.Trim(addr, "<>")
+}
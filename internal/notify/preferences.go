@@ -0,0 +1,228 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Preferences настройки уведомлений пользователя.
+type Preferences struct {
+	UserID uuid.UUID `json:"user_id"`
+	// Email адрес для писем, пустое значение выключает все письма.
+	Email string `json:"email"`
+	// Assignments письма о назначении и переназначении ревью.
+	Assignments bool `json:"assignments"`
+	// Digest ежедневная сводка открытых ревью.
+	Digest bool `json:"digest"`
+	// OrgID организация пользователя, заполняется хранилищем.
+	OrgID uuid.UUID `json:"-"`
+}
+
+// DefaultPreferences настройки пользователя, который их не менял: адреса
+// нет, поэтому письма не отправляются.
+func DefaultPreferences(userID uuid.UUID) Preferences {
+	return Preferences{UserID: userID, Assignments: true, Digest: true}
+}
+
+// Store хранит настройки уведомлений и отметки отправленных сводок.
+type Store interface {
+	// Get возвращает настройки пользователя организации из ctx
+	// или "PREFERENCES_NOT_FOUND".
+	Get(ctx context.Context, userID uuid.UUID) (*Preferences, error)
+	// Save создаёт или заменяет настройки пользователя в организации из ctx.
+	Save(ctx context.Context, prefs Preferences) error
+	// Delete удаляет настройки пользователя в организации из ctx, если они есть.
+	Delete(ctx context.Context, userID uuid.UUID) error
+	// List возвращает настройки всех пользователей с адресом во всех организациях.
+	List(ctx context.Context) ([]Preferences, error)
+	// ClaimDigest отмечает сводку за день (YYYY-MM-DD) как отправляемую.
+	// Возвращает false, если её уже отправила другая реплика.
+	ClaimDigest(ctx context.Context, day string) (bool, error)
+}
+
+// MemoryStore настройки в памяти процесса.
+type MemoryStore struct {
+	mu      sync.Mutex
+	prefs   map[prefsKey]Preferences
+	digests map[string]bool
+}
+
+// prefsKey ID пользователя уникален в пределах организации.
+type prefsKey struct {
+	orgID  uuid.UUID
+	userID uuid.UUID
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		prefs:   make(map[prefsKey]Preferences),
+		digests: make(map[string]bool),
+	}
+}
+
+// Get реализует Store.
+func (s *MemoryStore) Get(ctx context.Context, userID uuid.UUID) (*Preferences, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefs, ok := s.prefs[prefsKey{orgID: tenant.OrgID(ctx), userID: userID}]
+	if !ok {
+		return nil, errors.New("PREFERENCES_NOT_FOUND")
+	}
+	return &prefs, nil
+}
+
+// Save реализует Store.
+func (s *MemoryStore) Save(ctx context.Context, prefs Preferences) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefs.OrgID = tenant.OrgID(ctx)
+	s.prefs[prefsKey{orgID: prefs.OrgID, userID: prefs.UserID}] = prefs
+	return nil
+}
+
+// Delete реализует Store.
+func (s *MemoryStore) Delete(ctx context.Context, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.prefs, prefsKey{orgID: tenant.OrgID(ctx), userID: userID})
+	return nil
+}
+
+// List реализует Store.
+func (s *MemoryStore) List(_ context.Context) ([]Preferences, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var list []Preferences
+	for _, prefs := range s.prefs {
+		if prefs.Email != "" {
+			list = append(list, prefs)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].UserID.String() < list[j].UserID.String()
+	})
+	return list, nil
+}
+
+// ClaimDigest реализует Store.
+func (s *MemoryStore) ClaimDigest(_ context.Context, day string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.digests[day] {
+		return false, nil
+	}
+	s.digests[day] = true
+	return true, nil
+}
+
+// SQLStore настройки в таблицах notification_preferences и
+// notification_digests (PostgreSQL или SQLite), общих для всех реплик.
+type SQLStore struct {
+	db *sql.DB
+}
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+// Get реализует Store.
+func (s *SQLStore) Get(ctx context.Context, userID uuid.UUID) (*Preferences, error) {
+	orgID := tenant.OrgID(ctx)
+	prefs := Preferences{UserID: userID, OrgID: orgID}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT email, assignments, digest
+		FROM notification_preferences
+		WHERE user_id = $1 AND org_id = $2
+	`, userID.String(), orgID.String()).Scan(&prefs.Email, &prefs.Assignments, &prefs.Digest)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("PREFERENCES_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return &prefs, nil
+}
+
+// Save реализует Store.
+func (s *SQLStore) Save(ctx context.Context, prefs Preferences) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO notification_preferences (user_id, email, assignments, digest, updated_at, org_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET
+			email = excluded.email,
+			assignments = excluded.assignments,
+			digest = excluded.digest,
+			updated_at = excluded.updated_at
+	`, prefs.UserID.String(), prefs.Email, prefs.Assignments, prefs.Digest, time.Now().UTC(), tenant.OrgID(ctx).String())
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return nil
+}
+
+// Delete реализует Store.
+func (s *SQLStore) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM notification_preferences WHERE user_id = $1 AND org_id = $2
+	`, userID.String(), tenant.OrgID(ctx).String())
+	if err != nil {
+		return fmt.Errorf("failed to delete notification preferences: %w", err)
+	}
+	return nil
+}
+
+// List реализует Store.
+func (s *SQLStore) List(ctx context.Context) ([]Preferences, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT user_id, email, assignments, digest, org_id
+		FROM notification_preferences
+		WHERE email <> ''
+		ORDER BY user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	var list []Preferences
+	for rows.Next() {
+		var prefs Preferences
+		if err := rows.Scan(&prefs.UserID, &prefs.Email, &prefs.Assignments, &prefs.Digest, &prefs.OrgID); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preferences: %w", err)
+		}
+		list = append(list, prefs)
+	}
+	return list, rows.Err()
+}
+
+// ClaimDigest реализует Store.
+func (s *SQLStore) ClaimDigest(ctx context.Context, day string) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO notification_digests (digest_date, sent_at)
+		VALUES ($1, $2)
+		ON CONFLICT (digest_date) DO NOTHING
+	`, day, time.Now().UTC())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim digest: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim digest: %w", err)
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,255 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// ReviewData данные писем о назначении, переназначении и снятии ревью.
+type ReviewData struct {
+	// Username имя получателя.
+	Username        string
+	PullRequestID   uuid.UUID
+	PullRequestName string
+	// Author имя автора PR.
+	Author string
+	// PreviousReviewer ревьювер, от которого перешло ревью (reassigned).
+	PreviousReviewer string
+	// NewReviewer ревьювер, которому передано ревью (unassigned).
+	NewReviewer string
+}
+
+// DigestData данные ежедневной сводки.
+type DigestData struct {
+	Username string
+	// Reviews открытые ревью, самые старые первыми.
+	Reviews []DigestReview
+}
+
+// DigestReview открытое ревью в сводке.
+type DigestReview struct {
+	PullRequestID   uuid.UUID
+	PullRequestName string
+	Author          string
+	CreatedAt       time.Time
+	// Waiting сколько PR ждёт ревью, например "2d 3h".
+	Waiting string
+}
+
+// Template шаблон письма в синтаксисе text/template.
+type Template struct {
+	Subject string `yaml:"subject"`
+	Body    string `yaml:"body"`
+}
+
+// TemplateSet шаблоны всех писем. В файле NOTIFY_TEMPLATES_FILE можно
+// переопределить любые из них, остальные берутся из DefaultTemplateSet.
+type TemplateSet struct {
+	// Assigned ревьюверу, назначенному при создании PR.
+	Assigned Template `yaml:"assigned"`
+	// Reassigned ревьюверу, которому передали ревью.
+	Reassigned Template `yaml:"reassigned"`
+	// Unassigned ревьюверу, с которого сняли ревью.
+	Unassigned Template `yaml:"unassigned"`
+	// Digest ежедневная сводка.
+	Digest Template `yaml:"digest"`
+}
+
+// DefaultTemplateSet шаблоны по умолчанию.
+func DefaultTemplateSet() TemplateSet {
+	return TemplateSet{
+		Assigned: Template{
+			Subject: `Review requested: {{.PullRequestName}}`,
+			Body: `Hi {{.Username}},
+
+{{.Author}} opened "{{.PullRequestName}}" and you were assigned to review it.
+
+Pull request: {{.PullRequestID}}
+`,
+		},
+		Reassigned: Template{
+			Subject: `Review reassigned to you: {{.PullRequestName}}`,
+			Body: `Hi {{.Username}},
+
+The review of "{{.PullRequestName}}" by {{.Author}} was handed over to you{{if .PreviousReviewer}} from {{.PreviousReviewer}}{{end}}.
+
+Pull request: {{.PullRequestID}}
+`,
+		},
+		Unassigned: Template{
+			Subject: `Review reassigned: {{.PullRequestName}}`,
+			Body: `Hi {{.Username}},
+
+You no longer need to review "{{.PullRequestName}}" by {{.Author}}{{if .NewReviewer}}: it was reassigned to {{.NewReviewer}}{{end}}.
+
+Pull request: {{.PullRequestID}}
+`,
+		},
+		Digest: Template{
+			Subject: `{{len .Reviews}} open review{{if ne (len .Reviews) 1}}s{{end}} waiting for you`,
+			Body: `Hi {{.Username}},
+
+These pull requests are waiting for your review, oldest first:
+{{range .Reviews}}
+- {{.PullRequestName}} by {{.Author}}, waiting {{.Waiting}} ({{.PullRequestID}})
+{{- end}}
+`,
+		},
+	}
+}
+
+// Templates разобранные шаблоны писем.
+type Templates struct {
+	assigned   compiled
+	reassigned compiled
+	unassigned compiled
+	digest     compiled
+}
+
+type compiled struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// DefaultTemplates разобранные шаблоны по умолчанию.
+func DefaultTemplates() *Templates {
+	t, err := NewTemplates(DefaultTemplateSet())
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// NewTemplates разбирает шаблоны и проверяет их на примере данных, чтобы
+// ошибка вроде неизвестного поля обнаружилась при старте, а не при отправке.
+func NewTemplates(set TemplateSet) (*Templates, error) {
+	review := ReviewData{
+		Username: "alice", PullRequestID: uuid.New(), PullRequestName: "Example",
+		Author: "bob", PreviousReviewer: "carol", NewReviewer: "dave",
+	}
+	digest := DigestData{Username: "alice", Reviews: []DigestReview{{
+		PullRequestID: uuid.New(), PullRequestName: "Example", Author: "bob",
+		CreatedAt: time.Now(), Waiting: "1d 2h",
+	}}}
+
+	var t Templates
+	var errs []error
+	for _, tt := range []struct {
+		name string
+		src  Template
+		dst  *compiled
+		data any
+	}{
+		{"assigned", set.Assigned, &t.assigned, review},
+		{"reassigned", set.Reassigned, &t.reassigned, review},
+		{"unassigned", set.Unassigned, &t.unassigned, review},
+		{"digest", set.Digest, &t.digest, digest},
+	} {
+		c, err := compile(tt.name, tt.src)
+		if err == nil {
+			_, _, err = c.render(tt.data)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		*tt.dst = c
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &t, nil
+}
+
+// LoadTemplates читает переопределения шаблонов из YAML файла:
+//
+//	assigned:
+//	  subject: "Please review {{.PullRequestName}}"
+//	  body: |
+//	    ...
+//
+// Незаданные шаблоны, темы и тела берутся по умолчанию.
+func LoadTemplates(path string) (*Templates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open templates file: %w", err)
+	}
+	defer f.Close()
+
+	var file TemplateSet
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse templates file %s: %w", path, err)
+	}
+
+	set := DefaultTemplateSet()
+	override(&set.Assigned, file.Assigned)
+	override(&set.Reassigned, file.Reassigned)
+	override(&set.Unassigned, file.Unassigned)
+	override(&set.Digest, file.Digest)
+
+	t, err := NewTemplates(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid templates in %s: %w", path, err)
+	}
+	return t, nil
+}
+
+func override(dst *Template, src Template) {
+	if src.Subject != "" {
+		dst.Subject = src.Subject
+	}
+	if src.Body != "" {
+		dst.Body = src.Body
+	}
+}
+
+func compile(name string, src Template) (compiled, error) {
+	subject, err := template.New(name + ".subject").Option("missingkey=error").Parse(src.Subject)
+	if err != nil {
+		return compiled{}, fmt.Errorf("%s subject: %w", name, err)
+	}
+	body, err := template.New(name + ".body").Option("missingkey=error").Parse(src.Body)
+	if err != nil {
+		return compiled{}, fmt.Errorf("%s body: %w", name, err)
+	}
+	return compiled{subject: subject, body: body}, nil
+}
+
+// render возвращает тему и тело письма. Тема сворачивается в одну строку:
+// перевод строки в заголовке письма недопустим.
+func (c compiled) render(data any) (string, string, error) {
+	var subject, body bytes.Buffer
+	if err := c.subject.Execute(&subject, data); err != nil {
+		return "", "", fmt.Errorf("%s: %w", c.subject.Name(), err)
+	}
+	if err := c.body.Execute(&body, data); err != nil {
+		return "", "", fmt.Errorf("%s: %w", c.body.Name(), err)
+	}
+	return strings.Join(strings.Fields(subject.String()), " "), body.String(), nil
+}
+
+// waiting возвращает возраст PR в виде "45m", "5h" или "2d 3h".
+func waiting(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		days := int(d.Hours()) / 24
+		return fmt.Sprintf("%dd %dh", days, int(d.Hours())-days*24)
+	}
+}
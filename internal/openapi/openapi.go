@@ -0,0 +1,184 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/api"
+)
+
+var defineFormats sync.Once
+
+// Load разбирает встроенную в бинарник спецификацию api/openapi.yaml.
+func Load() (*openapi3.T, error) {
+	defineFormats.Do(func() {
+		// Формат uuid проверяется так же, как в handler (uuid.Parse).
+		openapi3.DefineStringFormatCallback("uuid", func(s string) error {
+			_, err := uuid.Parse(s)
+			return err
+		})
+	})
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(api.OpenAPISpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+	return doc, nil
+}
+
+// Validator проверяет HTTP запросы и ответы на соответствие спецификации.
+type Validator struct {
+	doc    *openapi3.T
+	router routers.Router
+	json   []byte
+}
+
+// NewValidator загружает спецификацию и строит по ней роутер.
+func NewValidator() (*Validator, error) {
+	doc, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI router: %w", err)
+	}
+
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI spec: %w", err)
+	}
+
+	return &Validator{doc: doc, router: router, json: data}, nil
+}
+
+// Doc возвращает разобранную спецификацию.
+func (v *Validator) Doc() *openapi3.T {
+	return v.doc
+}
+
+// JSON возвращает спецификацию в JSON для /openapi.json.
+func (v *Validator) JSON() []byte {
+	return v.json
+}
+
+// Operation результат проверки запроса, нужен для проверки ответа.
+type Operation struct {
+	input *openapi3filter.RequestValidationInput
+}
+
+// ValidateRequest проверяет запрос. Для маршрутов, которых нет в
+// спецификации (например, /metrics), возвращает nil без ошибки.
+// Тело запроса после проверки остаётся доступным для обработчика.
+func (v *Validator) ValidateRequest(r *http.Request) (*Operation, error) {
+	route, pathParams, err := v.router.FindRoute(r)
+	if err != nil {
+		if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			// Admin токен проверяет middleware.AdminAuth.
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			// Тело передаётся handler'у как есть: подстановка default из схемы
+			// требует перекодирования, а YAML и CSV kin-openapi кодировать не умеет.
+			SkipSettingDefaults: true,
+		},
+	}
+
+	if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+		return nil, err
+	}
+	return &Operation{input: input}, nil
+}
+
+// Streaming описан ли у операции ответ text/event-stream. Такой ответ
+// нельзя буферизовать до конца, поэтому он не проверяется.
+func (op *Operation) Streaming() bool {
+	for _, resp := range op.input.Route.Operation.Responses.Map() {
+		if resp.Value != nil && resp.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateResponse проверяет статус, заголовки и тело ответа.
+func (v *Validator) ValidateResponse(ctx context.Context, op *Operation, status int, header http.Header, body []byte) error {
+	return openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: op.input,
+		Status:                 status,
+		Header:                 header,
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+		},
+	})
+}
+
+// ErrorMessage сокращает ошибку валидации до одной строки без дампа схемы.
+func ErrorMessage(err error) string {
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		msg := schemaErr.Reason
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			msg = fmt.Sprintf("%s: %s", strings.Join(pointer, "."), msg)
+		}
+		return msg
+	}
+
+	var reqErr *openapi3filter.RequestError
+	if errors.As(err, &reqErr) {
+		if reqErr.Parameter != nil {
+			return fmt.Sprintf("parameter %q: %s", reqErr.Parameter.Name, reqErr.Reason)
+		}
+		if reqErr.Reason != "" {
+			return reqErr.Reason
+		}
+	}
+
+	return err.Error()
+}
+
+// ValidationMode режим проверки HTTP трафика по спецификации.
+type ValidationMode string
+
+const (
+	ValidationOff      ValidationMode = "off"
+	ValidationRequests ValidationMode = "requests"
+	// ValidationAll дополнительно проверяет ответы, для тестов и staging.
+	ValidationAll ValidationMode = "all"
+)
+
+// ParseValidationMode разбирает значение OPENAPI_VALIDATION.
+func ParseValidationMode(s string) (ValidationMode, error) {
+	switch mode := ValidationMode(s); mode {
+	case ValidationOff, ValidationRequests, ValidationAll:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown OpenAPI validation mode %q (want off, requests or all)", s)
+	}
+}
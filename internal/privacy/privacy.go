@@ -0,0 +1,269 @@
+// Package privacy реализует выгрузку персональных данных пользователя и их
+// необратимую анонимизацию. Анонимизация заменяет user_id и username на
+// псевдонимы во всех хранилищах, не удаляя PR и назначения, поэтому
+// статистика по пользователю сохраняется под новым идентификатором.
+package privacy
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/notify"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
+)
+
+// Events журнал событий ревью; его реализует *events.Broker.
+type Events interface {
+	After(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.ReviewEvent, error)
+	ReplaceUser(ctx context.Context, userID, newID uuid.UUID) error
+}
+
+// Preferences настройки уведомлений; его реализует notify.Store.
+type Preferences interface {
+	Get(ctx context.Context, userID uuid.UUID) (*notify.Preferences, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// Absences отметки отсутствия; его реализует chatops.Absences.
+type Absences interface {
+	Get(ctx context.Context, userID uuid.UUID) (time.Time, bool, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// Export все данные сервиса об одном пользователе.
+type Export struct {
+	Profile domain.UserProfile `json:"profile"`
+	// Teams команды пользователя. Сервис хранит только текущую команду.
+	Teams       []string                  `json:"teams"`
+	AuthoredPRs []domain.PullRequestShort `json:"authored_pull_requests"`
+	Reviews     []domain.PullRequestShort `json:"review_assignments"`
+	Events      []domain.ReviewEvent      `json:"events"`
+	// Notifications настройки писем, если пользователь их сохранял.
+	Notifications *notify.Preferences `json:"notifications,omitempty"`
+	// AwayUntil время возвращения, если пользователь отмечен отсутствующим.
+	AwayUntil *time.Time `json:"away_until,omitempty"`
+	// Accounts логины на хостингах и в Slack из файла сопоставления.
+	Accounts   map[string][]string `json:"accounts"`
+	ExportedAt time.Time           `json:"exported_at"`
+}
+
+// Anonymization результат анонимизации. Новый user_id не возвращается и не
+// логируется, иначе по нему можно было бы связать псевдоним с человеком.
+type Anonymization struct {
+	Username    string `json:"username"`
+	AuthoredPRs int    `json:"authored_pull_requests"`
+	Reviews     int    `json:"review_assignments"`
+}
+
+// eventsPage сколько событий читается из журнала за раз.
+const eventsPage = 1000
+
+// Service выгрузка и анонимизация персональных данных.
+type Service struct {
+	repo     repository.RepositoryInterface
+	events   Events
+	prefs    Preferences
+	absences Absences
+	mapping  *webhook.Mapping
+	now      func() time.Time
+}
+
+// Option настраивает Service.
+type Option func(*Service)
+
+// WithEvents включает журнал событий в выгрузку и анонимизацию.
+func WithEvents(e Events) Option {
+	return func(s *Service) {
+		s.events = e
+	}
+}
+
+// WithNotifications включает настройки уведомлений. При анонимизации они
+// удаляются: в них хранится адрес почты.
+func WithNotifications(p Preferences) Option {
+	return func(s *Service) {
+		s.prefs = p
+	}
+}
+
+// WithAbsences включает отметки отсутствия; при анонимизации они удаляются.
+func WithAbsences(a Absences) Option {
+	return func(s *Service) {
+		s.absences = a
+	}
+}
+
+// WithMapping добавляет в выгрузку логины из файла сопоставления. При
+// анонимизации логины пользователя удаляются из сопоставления и файла;
+// если файл не удалось переписать, анонимизация возвращает ошибку.
+func WithMapping(m *webhook.Mapping) Option {
+	return func(s *Service) {
+		s.mapping = m
+	}
+}
+
+func NewService(repo repository.RepositoryInterface, opts ...Option) *Service {
+	s := &Service{
+		repo: repo,
+		now:  time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Export собирает данные пользователя, в том числе удалённого.
+// Возвращает "USER_NOT_FOUND", если пользователя нет.
+func (s *Service) Export(ctx context.Context, userID uuid.UUID) (*Export, error) {
+	data, err := s.repo.GetUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		Profile:     data.Profile,
+		Teams:       []string{},
+		AuthoredPRs: data.AuthoredPRs,
+		Reviews:     data.Reviews,
+		Events:      []domain.ReviewEvent{},
+		Accounts:    s.accounts(userID),
+		ExportedAt:  s.now().UTC(),
+	}
+	if data.Profile.TeamName != "" {
+		export.Teams = append(export.Teams, data.Profile.TeamName)
+	}
+	if export.AuthoredPRs == nil {
+		export.AuthoredPRs = []domain.PullRequestShort{}
+	}
+	if export.Reviews == nil {
+		export.Reviews = []domain.PullRequestShort{}
+	}
+
+	if s.events != nil {
+		var afterID int64
+		for {
+			page, err := s.events.After(ctx, userID, afterID, eventsPage)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read events: %w", err)
+			}
+			export.Events = append(export.Events, page...)
+			if len(page) < eventsPage {
+				break
+			}
+			afterID = page[len(page)-1].ID
+		}
+	}
+
+	if s.prefs != nil {
+		prefs, err := s.prefs.Get(ctx, userID)
+		switch {
+		case err == nil:
+			export.Notifications = prefs
+		case err.Error() != "PREFERENCES_NOT_FOUND":
+			return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+		}
+	}
+
+	if s.absences != nil {
+		until, ok, err := s.absences.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			export.AwayUntil = &until
+		}
+	}
+
+	return export, nil
+}
+
+// Anonymize необратимо заменяет user_id и username пользователя на
+// псевдонимы, удаляет его адрес почты, отметку отсутствия и логины из
+// сопоставления и его файла. PR и назначения остаются, статистика переходит на новый
+// user_id. Возвращает "USER_NOT_FOUND", если пользователя нет.
+//
+// Хранилища разные, поэтому шаги не объединены в транзакцию. Новый
+// user_id выбирается один раз и хранится в записи пользователя, а сама
+// запись заменяется последней: если шаг упадёт, повторный вызов перепишет
+// оставшиеся данные на тот же user_id и завершит анонимизацию.
+func (s *Service) Anonymize(ctx context.Context, userID uuid.UUID) (*Anonymization, error) {
+	data, err := s.repo.GetUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	newID, err := s.repo.AnonymousID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	pseudonym, err := newPseudonym()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.prefs != nil {
+		if err := s.prefs.Delete(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to delete notification preferences: %w", err)
+		}
+	}
+	if s.absences != nil {
+		if err := s.absences.Delete(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	if s.events != nil {
+		if err := s.events.ReplaceUser(ctx, userID, newID); err != nil {
+			return nil, fmt.Errorf("failed to anonymize events: %w", err)
+		}
+	}
+	if s.mapping != nil {
+		n, err := s.mapping.Forget(userID)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "Anonymized user's logins removed from mapping", "logins", n)
+		}
+	}
+	if err := s.repo.AnonymizeUser(ctx, userID, newID, pseudonym); err != nil {
+		return nil, err
+	}
+
+	// user_id не логируется: по логу его можно было бы связать с псевдонимом.
+	slog.InfoContext(ctx, "Personal data anonymized",
+		"authored_prs", len(data.AuthoredPRs),
+		"reviews", len(data.Reviews),
+	)
+
+	return &Anonymization{
+		Username:    pseudonym,
+		AuthoredPRs: len(data.AuthoredPRs),
+		Reviews:     len(data.Reviews),
+	}, nil
+}
+
+// accounts ищет логины пользователя в файле сопоставления.
+func (s *Service) accounts(userID uuid.UUID) map[string][]string {
+	if s.mapping == nil {
+		return map[string][]string{}
+	}
+	return s.mapping.Logins(userID)
+}
+
+// newPseudonym случайное имя, не связанное с новым user_id.
+func newPseudonym() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate pseudonym: %w", err)
+	}
+	return "anonymous-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,223 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/chatops"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/events"
+	"github.com/T1mof/pr-reviewer-service/internal/notify"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/repository/repotest"
+	"github.com/T1mof/pr-reviewer-service/internal/webhook"
+)
+
+var (
+	aliceID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	bobID   = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	prID    = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+)
+
+type fixture struct {
+	repo     *flakyRepository
+	events   *events.Broker
+	prefs    *notify.MemoryStore
+	absences *chatops.MemoryAbsences
+	mapping  *webhook.Mapping
+	svc      *Service
+}
+
+// flakyRepository отказывает в AnonymizeUser, пока failAnonymize больше нуля.
+type flakyRepository struct {
+	*repository.MemoryRepository
+	failAnonymize int
+}
+
+func (r *flakyRepository) AnonymizeUser(ctx context.Context, userID, newID uuid.UUID, pseudonym string) error {
+	if r.failAnonymize > 0 {
+		r.failAnonymize--
+		return errors.New("connection reset")
+	}
+	return r.MemoryRepository.AnonymizeUser(ctx, userID, newID, pseudonym)
+}
+
+// newFixture команда из alice и bob; bob ревьюит PR alice, у alice
+// сохранены настройки писем и отметка отсутствия.
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	ctx := context.Background()
+
+	f := &fixture{
+		repo:     &flakyRepository{MemoryRepository: repository.NewMemoryRepository()},
+		events:   events.NewBroker(events.NewMemoryStore()),
+		prefs:    notify.NewMemoryStore(),
+		absences: chatops.NewMemoryAbsences(),
+		mapping: &webhook.Mapping{
+			GitHub: webhook.GitHubMapping{Users: webhook.Users{"alice-gh": aliceID, "bob-gh": bobID}},
+			Slack:  webhook.SlackMapping{Users: webhook.Users{"u0alice": aliceID}},
+		},
+	}
+	f.svc = NewService(f.repo,
+		WithEvents(f.events),
+		WithNotifications(f.prefs),
+		WithAbsences(f.absences),
+		WithMapping(f.mapping),
+	)
+
+	repotest.Team(t, ctx, f.repo, "backend",
+		repotest.Member(aliceID, "alice"),
+		repotest.Member(bobID, "bob"),
+	)
+	require.NoError(t, f.repo.CreatePR(ctx, &domain.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "Add search",
+		AuthorID:        aliceID,
+		Status:          domain.StatusOpen,
+	}, []uuid.UUID{bobID}))
+
+	f.events.Publish(ctx, domain.ReviewEvent{
+		Type:          domain.EventReviewAssigned,
+		UserID:        bobID,
+		PullRequestID: prID,
+		AuthorID:      aliceID,
+	})
+	require.NoError(t, f.prefs.Save(ctx, notify.Preferences{UserID: aliceID, Email: "alice@example.com", Assignments: true}))
+	require.NoError(t, f.absences.Set(ctx, aliceID, time.Now().Add(time.Hour)))
+	return f
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	alice, err := f.svc.Export(ctx, aliceID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", alice.Profile.Username)
+	assert.Equal(t, []string{"backend"}, alice.Teams)
+	require.Len(t, alice.AuthoredPRs, 1)
+	assert.Equal(t, prID, alice.AuthoredPRs[0].PullRequestID)
+	assert.Empty(t, alice.Reviews)
+	assert.NotNil(t, alice.Reviews)
+	assert.Empty(t, alice.Events)
+	require.NotNil(t, alice.Notifications)
+	assert.Equal(t, "alice@example.com", alice.Notifications.Email)
+	assert.NotNil(t, alice.AwayUntil)
+	assert.Equal(t, map[string][]string{"github": {"alice-gh"}, "slack": {"u0alice"}}, alice.Accounts)
+
+	bob, err := f.svc.Export(ctx, bobID)
+	require.NoError(t, err)
+	assert.Empty(t, bob.AuthoredPRs)
+	require.Len(t, bob.Reviews, 1)
+	require.Len(t, bob.Events, 1)
+	assert.Equal(t, prID, bob.Events[0].PullRequestID)
+	assert.Nil(t, bob.Notifications)
+	assert.Nil(t, bob.AwayUntil)
+
+	_, err = f.svc.Export(ctx, uuid.New())
+	require.Error(t, err)
+	assert.Equal(t, "USER_NOT_FOUND", err.Error())
+}
+
+func TestAnonymize(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	result, err := f.svc.Anonymize(ctx, aliceID)
+	require.NoError(t, err)
+	assert.Regexp(t, `^anonymous-[0-9a-f]{8}$`, result.Username)
+	assert.Equal(t, 1, result.AuthoredPRs)
+	assert.Equal(t, 0, result.Reviews)
+
+	_, err = f.svc.Export(ctx, aliceID)
+	require.Error(t, err)
+	assert.Equal(t, "USER_NOT_FOUND", err.Error())
+
+	_, err = f.prefs.Get(ctx, aliceID)
+	require.Error(t, err)
+	_, away, err := f.absences.Get(ctx, aliceID)
+	require.NoError(t, err)
+	assert.False(t, away)
+
+	// PR остался, но автор теперь псевдоним — и в репозитории, и в журнале.
+	pr, err := f.repo.GetPRByID(ctx, prID)
+	require.NoError(t, err)
+	assert.NotEqual(t, aliceID, pr.AuthorID)
+	author, err := f.repo.GetUserByID(ctx, pr.AuthorID)
+	require.NoError(t, err)
+	assert.Equal(t, result.Username, author.Username)
+
+	bob, err := f.svc.Export(ctx, bobID)
+	require.NoError(t, err)
+	require.Len(t, bob.Events, 1)
+	assert.Equal(t, pr.AuthorID, bob.Events[0].AuthorID)
+
+	// Логины alice больше не ведут ни к старому, ни к новому ID.
+	_, ok := f.mapping.Accounts(webhook.ProviderGitHub).UserID("alice-gh")
+	assert.False(t, ok)
+	_, ok = f.mapping.Accounts(webhook.ProviderSlack).UserID("u0alice")
+	assert.False(t, ok)
+	assert.Empty(t, f.mapping.Logins(pr.AuthorID))
+	assert.Equal(t, map[string][]string{"github": {"bob-gh"}}, bob.Accounts)
+
+	_, err = f.svc.Anonymize(ctx, aliceID)
+	require.Error(t, err)
+	assert.Equal(t, "USER_NOT_FOUND", err.Error())
+}
+
+func TestAnonymize_Retry(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	f.repo.failAnonymize = 1
+
+	_, err := f.svc.Anonymize(ctx, aliceID)
+	require.Error(t, err)
+
+	// Журнал уже переписан, но пользователь ещё на месте.
+	_, err = f.svc.Export(ctx, aliceID)
+	require.NoError(t, err)
+
+	_, err = f.svc.Anonymize(ctx, aliceID)
+	require.NoError(t, err)
+
+	// Повторная попытка использовала тот же user_id, что и первая.
+	pr, err := f.repo.GetPRByID(ctx, prID)
+	require.NoError(t, err)
+	bob, err := f.svc.Export(ctx, bobID)
+	require.NoError(t, err)
+	require.Len(t, bob.Events, 1)
+	assert.Equal(t, pr.AuthorID, bob.Events[0].AuthorID)
+}
+
+func TestAnonymize_MappingFileFailure(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	path := filepath.Join(t.TempDir(), "mapping.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("github:\n  users:\n    alice-gh: "+aliceID.String()+"\n"), 0o600))
+	mapping, err := webhook.LoadMapping(path)
+	require.NoError(t, err)
+	f.svc = NewService(f.repo, WithMapping(mapping))
+	// Файл пропал: логины нельзя удалить так, чтобы они не вернулись.
+	require.NoError(t, os.Remove(path))
+
+	_, err = f.svc.Anonymize(ctx, aliceID)
+	require.ErrorContains(t, err, "mapping file")
+
+	// Пользователь не обезличен, и повтор после исправления файла завершит работу.
+	_, err = f.repo.GetUserByID(ctx, aliceID)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("github:\n  users:\n    alice-gh: "+aliceID.String()+"\n"), 0o600))
+	_, err = f.svc.Anonymize(ctx, aliceID)
+	require.NoError(t, err)
+	_, ok := mapping.Accounts(webhook.ProviderGitHub).UserID("alice-gh")
+	assert.False(t, ok)
+}
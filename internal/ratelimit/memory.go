@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// MemoryLimiter хранит bucket'ы в памяти процесса. Подходит для одной реплики.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+	idleTTL time.Duration
+	lastGC  time.Time
+}
+
+// NewMemoryLimiter создаёт in-memory limiter.
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+		idleTTL: 10 * time.Minute,
+	}
+}
+
+// Allow реализует Limiter.
+func (l *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.collectIdle(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	tokens, res := take(b.tokens, now.Sub(b.lastSeen), limit)
+	b.tokens = tokens
+	b.lastSeen = now
+
+	return res, nil
+}
+
+// collectIdle удаляет давно неиспользуемые bucket'ы, чтобы карта не росла бесконечно.
+func (l *MemoryLimiter) collectIdle(now time.Time) {
+	if now.Sub(l.lastGC) < l.idleTTL {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > l.idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastGC = now
+}
+
+var _ Limiter = (*MemoryLimiter)(nil)
@@ -0,0 +1,57 @@
+package ratelimit
+
+import "sync/atomic"
+
+// Роли клиентов для выбора лимита.
+const (
+	RoleAdmin     = "admin"
+	RoleClient    = "client"
+	RoleAnonymous = "anonymous"
+)
+
+// Policy определяет, какой лимит применяется к запросу.
+// Приоритет: правило маршрута, затем правило роли, затем лимит по умолчанию.
+type Policy struct {
+	Default Limit
+	Routes  map[string]Limit
+	Roles   map[string]Limit
+}
+
+// Resolve возвращает лимит и область bucket'а для роли и маршрута ("METHOD /path").
+// Для маршрутов с собственным правилом bucket отдельный, иначе общий на клиента.
+func (p *Policy) Resolve(role, route string) (limit Limit, scope string) {
+	if l, ok := p.Routes[route]; ok {
+		return l, route
+	}
+	if l, ok := p.Roles[role]; ok {
+		return l, "*"
+	}
+	return p.Default, "*"
+}
+
+// Resolver выбирает лимит для запроса. Реализуется Policy и DynamicPolicy.
+type Resolver interface {
+	Resolve(role, route string) (limit Limit, scope string)
+}
+
+// DynamicPolicy политика, которую можно заменить без перезапуска,
+// например при перезагрузке конфигурации.
+type DynamicPolicy struct {
+	current atomic.Pointer[Policy]
+}
+
+func NewDynamicPolicy(p Policy) *DynamicPolicy {
+	d := &DynamicPolicy{}
+	d.Set(p)
+	return d
+}
+
+// Set заменяет политику. Запросы в обработке дочитывают прежнюю.
+func (d *DynamicPolicy) Set(p Policy) {
+	d.current.Store(&p)
+}
+
+// Resolve реализует Resolver по текущей политике.
+func (d *DynamicPolicy) Resolve(role, route string) (limit Limit, scope string) {
+	return d.current.Load().Resolve(role, route)
+}
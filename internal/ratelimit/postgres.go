@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// PostgresLimiter хранит bucket'ы в таблице rate_limit_buckets,
+// поэтому лимиты соблюдаются суммарно по всем репликам.
+type PostgresLimiter struct {
+	db *sql.DB
+}
+
+// NewPostgresLimiter создаёт limiter поверх PostgreSQL.
+func NewPostgresLimiter(db *sql.DB) *PostgresLimiter {
+	return &PostgresLimiter{db: db}
+}
+
+// Allow реализует Limiter. Bucket блокируется на время транзакции (SELECT ... FOR UPDATE).
+func (l *PostgresLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			slog.ErrorContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rate_limit_buckets (bucket_key, tokens, updated_at)
+		VALUES ($1, $2, clock_timestamp())
+		ON CONFLICT (bucket_key) DO NOTHING
+	`, key, limit.Burst)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to init bucket: %w", err)
+	}
+
+	var tokens, elapsedSeconds float64
+	err = tx.QueryRowContext(ctx, `
+		SELECT tokens, GREATEST(EXTRACT(EPOCH FROM (clock_timestamp() - updated_at)), 0)
+		FROM rate_limit_buckets
+		WHERE bucket_key = $1
+		FOR UPDATE
+	`, key).Scan(&tokens, &elapsedSeconds)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to load bucket: %w", err)
+	}
+
+	tokens, res := take(tokens, secondsToDuration(elapsedSeconds), limit)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE rate_limit_buckets
+		SET tokens = $1, updated_at = clock_timestamp()
+		WHERE bucket_key = $2
+	`, tokens, key)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to update bucket: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Result{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return res, nil
+}
+
+// Cleanup удаляет bucket'ы, не использовавшиеся дольше olderThan.
+func (l *PostgresLimiter) Cleanup(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result, err := l.db.ExecContext(ctx, `
+		DELETE FROM rate_limit_buckets
+		WHERE updated_at < clock_timestamp() - make_interval(secs => $1)
+	`, olderThan.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup buckets: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+var _ Limiter = (*PostgresLimiter)(nil)
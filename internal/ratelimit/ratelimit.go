@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit параметры token bucket: скорость пополнения и ёмкость.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+// Result результат попытки взять токен.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// Limiter хранит состояние bucket'ов и списывает токены.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// take пополняет bucket за прошедшее время и пытается списать один токен.
+// Возвращает новое количество токенов и результат.
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	burst := float64(limit.Burst)
+	tokens = math.Min(burst, tokens+elapsed.Seconds()*limit.RPS)
+
+	res := Result{Limit: limit.Burst}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - tokens) / limit.RPS)
+	}
+
+	res.Remaining = int(math.Floor(tokens))
+	res.ResetAfter = secondsToDuration((burst - tokens) / limit.RPS)
+	return tokens, res
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// ParseLimit разбирает лимит в формате "rps:burst", например "5:10".
+func ParseLimit(s string) (Limit, error) {
+	rpsStr, burstStr, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid limit %q: expected rps:burst", s)
+	}
+
+	rps, err := strconv.ParseFloat(rpsStr, 64)
+	if err != nil || rps <= 0 {
+		return Limit{}, fmt.Errorf("invalid limit %q: rps must be a positive number", s)
+	}
+
+	burst, err := strconv.Atoi(burstStr)
+	if err != nil || burst < 1 {
+		return Limit{}, fmt.Errorf("invalid limit %q: burst must be a positive integer", s)
+	}
+
+	return Limit{RPS: rps, Burst: burst}, nil
+}
+
+// ParseRules разбирает список правил "key=rps:burst" через запятую.
+// Ключ — маршрут ("POST /pullRequest/create") или роль ("admin").
+func ParseRules(s string) (map[string]Limit, error) {
+	rules := make(map[string]Limit)
+	if strings.TrimSpace(s) == "" {
+		return rules, nil
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid rule %q: expected key=rps:burst", item)
+		}
+
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		rules[key] = limit
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiter_BurstThenRefill(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limit := Limit{RPS: 1, Burst: 2}
+	ctx := context.Background()
+
+	res, _ := limiter.Allow(ctx, "k", limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+
+	res, _ = limiter.Allow(ctx, "k", limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	res, _ = limiter.Allow(ctx, "k", limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Second, res.RetryAfter)
+
+	now = now.Add(time.Second)
+	res, _ = limiter.Allow(ctx, "k", limit)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemoryLimiter_KeysAreIndependent(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	limit := Limit{RPS: 0.001, Burst: 1}
+
+	res, _ := limiter.Allow(context.Background(), "a", limit)
+	assert.True(t, res.Allowed)
+	res, _ = limiter.Allow(context.Background(), "b", limit)
+	assert.True(t, res.Allowed)
+	res, _ = limiter.Allow(context.Background(), "a", limit)
+	assert.False(t, res.Allowed)
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("POST /pullRequest/create=5:10, admin=100:200")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Limit{RPS: 5, Burst: 10}, rules["POST /pullRequest/create"])
+	assert.Equal(t, Limit{RPS: 100, Burst: 200}, rules["admin"])
+}
+
+func TestParseRules_Invalid(t *testing.T) {
+	for _, s := range []string{"admin", "admin=5", "admin=0:1", "admin=1:0", "=1:1"} {
+		_, err := ParseRules(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPolicy_Resolve(t *testing.T) {
+	p := &Policy{
+		Default: Limit{RPS: 1, Burst: 1},
+		Routes:  map[string]Limit{"POST /pullRequest/create": {RPS: 2, Burst: 2}},
+		Roles:   map[string]Limit{RoleAdmin: {RPS: 3, Burst: 3}},
+	}
+
+	limit, scope := p.Resolve(RoleAdmin, "POST /pullRequest/create")
+	assert.Equal(t, 2, limit.Burst)
+	assert.Equal(t, "POST /pullRequest/create", scope)
+
+	limit, scope = p.Resolve(RoleAdmin, "GET /stats")
+	assert.Equal(t, 3, limit.Burst)
+	assert.Equal(t, "*", scope)
+
+	limit, _ = p.Resolve(RoleAnonymous, "GET /stats")
+	assert.Equal(t, 1, limit.Burst)
+}
+
+func TestDynamicPolicy_Set(t *testing.T) {
+	d := NewDynamicPolicy(Policy{Default: Limit{RPS: 1, Burst: 1}})
+
+	limit, _ := d.Resolve(RoleAnonymous, "GET /stats")
+	assert.Equal(t, 1, limit.Burst)
+
+	d.Set(Policy{Default: Limit{RPS: 5, Burst: 10}})
+
+	limit, _ = d.Resolve(RoleAnonymous, "GET /stats")
+	assert.Equal(t, 10, limit.Burst)
+}
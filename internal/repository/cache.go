@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Invalidation описывает, какие записи кэша устарели.
+// Teams и Users — команды и пользователи организации Org.
+type Invalidation struct {
+	All   bool        `json:"all,omitempty"`
+	Org   uuid.UUID   `json:"org"`
+	Teams []string    `json:"teams,omitempty"`
+	Users []uuid.UUID `json:"users,omitempty"`
+}
+
+type cacheEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// CachedRepository read-through кэш составов команд и принадлежности
+// пользователей к командам поверх любой реализации RepositoryInterface.
+// Записи сбрасываются локально при CreateTeam, UpsertUser, SetUserActive,
+// SetUserSeniority, RemoveUser и AnonymizeUser. Остальные реплики узнают
+// об изменениях из pg_notify, который Repository с WithCacheNotify
+// отправляет в транзакции записи. Ключи записей включают организацию,
+// поэтому кэш не отдаёт данные другой организации.
+type CachedRepository struct {
+	RepositoryInterface
+
+	ttl time.Duration
+	now func() time.Time
+
+	mu         sync.Mutex
+	generation uint64
+	users      map[userKey]cacheEntry[domain.User]
+	teams      map[teamKey]cacheEntry[[]domain.User]
+}
+
+type userKey struct {
+	orgID  uuid.UUID
+	userID uuid.UUID
+}
+
+// NewCachedRepository оборачивает репозиторий кэшем. ttl ограничивает
+// жизнь записи на случай потерянных уведомлений.
+func NewCachedRepository(repo RepositoryInterface, ttl time.Duration) *CachedRepository {
+	return &CachedRepository{
+		RepositoryInterface: repo,
+		ttl:                 ttl,
+		now:                 time.Now,
+		users:               make(map[userKey]cacheEntry[domain.User]),
+		teams:               make(map[teamKey]cacheEntry[[]domain.User]),
+	}
+}
+
+// ========================================
+// Cached reads
+// ========================================
+
+func (r *CachedRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	key := userKey{orgID: tenant.OrgID(ctx), userID: userID}
+
+	r.mu.Lock()
+	entry, ok := r.users[key]
+	gen := r.generation
+	r.mu.Unlock()
+
+	if ok && r.now().Before(entry.expiresAt) {
+		user := entry.value
+		return &user, nil
+	}
+
+	user, err := r.RepositoryInterface.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	// Если за время чтения пришла инвалидация, результат мог устареть — не кэшируем.
+	if r.generation == gen {
+		r.users[key] = cacheEntry[domain.User]{value: *user, expiresAt: r.now().Add(r.ttl)}
+	}
+	r.mu.Unlock()
+
+	return user, nil
+}
+
+func (r *CachedRepository) GetTeamMembers(ctx context.Context, teamName string) ([]domain.User, error) {
+	key := teamKey{orgID: tenant.OrgID(ctx), name: teamName}
+
+	r.mu.Lock()
+	entry, ok := r.teams[key]
+	gen := r.generation
+	r.mu.Unlock()
+
+	if ok && r.now().Before(entry.expiresAt) {
+		return append([]domain.User(nil), entry.value...), nil
+	}
+
+	members, err := r.RepositoryInterface.GetTeamMembers(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if r.generation == gen {
+		r.teams[key] = cacheEntry[[]domain.User]{
+			value:     append([]domain.User(nil), members...),
+			expiresAt: r.now().Add(r.ttl),
+		}
+	}
+	r.mu.Unlock()
+
+	return members, nil
+}
+
+// ========================================
+// Invalidating writes
+// ========================================
+
+func (r *CachedRepository) CreateTeam(ctx context.Context, team *domain.Team) error {
+	if err := r.RepositoryInterface.CreateTeam(ctx, team); err != nil {
+		return err
+	}
+
+	// Участники могли переехать из других команд, чьи составы нам неизвестны.
+	r.Invalidate(Invalidation{All: true})
+	return nil
+}
+
+func (r *CachedRepository) ImportTeams(ctx context.Context, teams []domain.Team) error {
+	if err := r.RepositoryInterface.ImportTeams(ctx, teams); err != nil {
+		return err
+	}
+
+	r.Invalidate(Invalidation{All: true})
+	return nil
+}
+
+func (r *CachedRepository) UpsertUser(ctx context.Context, user *domain.User) error {
+	if err := r.RepositoryInterface.UpsertUser(ctx, user); err != nil {
+		return err
+	}
+
+	r.Invalidate(Invalidation{All: true})
+	return nil
+}
+
+func (r *CachedRepository) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool) error {
+	if err := r.RepositoryInterface.SetUserActive(ctx, userID, isActive); err != nil {
+		return err
+	}
+
+	inv := Invalidation{Org: tenant.OrgID(ctx), Users: []uuid.UUID{userID}}
+	if user, err := r.RepositoryInterface.GetUserByID(ctx, userID); err == nil {
+		inv.Teams = []string{user.TeamName}
+	} else {
+		inv = Invalidation{All: true}
+	}
+
+	r.Invalidate(inv)
+	return nil
+}
+
+func (r *CachedRepository) SetUserSeniority(ctx context.Context, userID uuid.UUID, seniority string) error {
+	if err := r.RepositoryInterface.SetUserSeniority(ctx, userID, seniority); err != nil {
+		return err
+	}
+
+	inv := Invalidation{Org: tenant.OrgID(ctx), Users: []uuid.UUID{userID}}
+	if user, err := r.RepositoryInterface.GetUserByID(ctx, userID); err == nil {
+		inv.Teams = []string{user.TeamName}
+	} else {
+		inv = Invalidation{All: true}
+	}
+
+	r.Invalidate(inv)
+	return nil
+}
+
+func (r *CachedRepository) RemoveUser(ctx context.Context, userID uuid.UUID) error {
+	// Команду нужно узнать до удаления: после него пользователь не читается.
+	inv := Invalidation{Org: tenant.OrgID(ctx), Users: []uuid.UUID{userID}}
+	if user, err := r.RepositoryInterface.GetUserByID(ctx, userID); err == nil {
+		inv.Teams = []string{user.TeamName}
+	} else {
+		inv = Invalidation{All: true}
+	}
+
+	if err := r.RepositoryInterface.RemoveUser(ctx, userID); err != nil {
+		return err
+	}
+
+	r.Invalidate(inv)
+	return nil
+}
+
+func (r *CachedRepository) AnonymizeUser(ctx context.Context, userID, newID uuid.UUID, pseudonym string) error {
+	if err := r.RepositoryInterface.AnonymizeUser(ctx, userID, newID, pseudonym); err != nil {
+		return err
+	}
+
+	// Команду удалённого пользователя через GetUserByID не узнать,
+	// поэтому сбрасывается весь кэш.
+	r.Invalidate(Invalidation{All: true})
+	return nil
+}
+
+// Invalidate сбрасывает записи кэша. Вызывается и для локальных изменений,
+// и для уведомлений от других реплик.
+func (r *CachedRepository) Invalidate(inv Invalidation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+
+	if inv.All {
+		clear(r.users)
+		clear(r.teams)
+		return
+	}
+	// ID пользователя уникален в пределах организации, поэтому записи
+	// того же ID в других организациях не трогаются.
+	for _, userID := range inv.Users {
+		delete(r.users, userKey{orgID: inv.Org, userID: userID})
+	}
+	for _, name := range inv.Teams {
+		delete(r.teams, teamKey{orgID: inv.Org, name: name})
+	}
+}
+
+// ========================================
+// Reviewer assignment
+// ========================================
+
+// CreatePR сбрасывает кэш, если репозиторий отклонил ревьювера, выбранного
+// по устаревшему составу: повторный выбор увидит актуальные данные.
+func (r *CachedRepository) CreatePR(ctx context.Context, pr *domain.PullRequest, reviewers []uuid.UUID) error {
+	err := r.RepositoryInterface.CreatePR(ctx, pr, reviewers)
+	if err != nil && err.Error() == "REVIEWER_INACTIVE" {
+		r.Invalidate(Invalidation{All: true})
+	}
+	return err
+}
+
+// ReplaceReviewer сбрасывает кэш по той же причине, что и CreatePR.
+func (r *CachedRepository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID uuid.UUID) error {
+	err := r.RepositoryInterface.ReplaceReviewer(ctx, prID, oldUserID, newUserID)
+	if err != nil && err.Error() == "REVIEWER_INACTIVE" {
+		r.Invalidate(Invalidation{All: true})
+	}
+	return err
+}
+
+// ========================================
+// Compile-time interface check
+// ========================================
+
+var _ RepositoryInterface = (*CachedRepository)(nil)
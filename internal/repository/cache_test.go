@@ -0,0 +1,132 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/repository/repotest"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// countingRepository считает обращения к чтениям, которые должен покрывать кэш.
+type countingRepository struct {
+	repository.RepositoryInterface
+
+	mu          sync.Mutex
+	userReads   int
+	memberReads int
+}
+
+func (r *countingRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	r.mu.Lock()
+	r.userReads++
+	r.mu.Unlock()
+	return r.RepositoryInterface.GetUserByID(ctx, userID)
+}
+
+func (r *countingRepository) GetTeamMembers(ctx context.Context, teamName string) ([]domain.User, error) {
+	r.mu.Lock()
+	r.memberReads++
+	r.mu.Unlock()
+	return r.RepositoryInterface.GetTeamMembers(ctx, teamName)
+}
+
+func TestCachedRepository_Contract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.RepositoryInterface {
+		return repository.NewCachedRepository(repository.NewMemoryRepository(), time.Minute)
+	})
+}
+
+func TestCachedRepository_ReadThrough(t *testing.T) {
+	ctx := context.Background()
+	backend := &countingRepository{RepositoryInterface: repository.NewMemoryRepository()}
+	cache := repository.NewCachedRepository(backend, time.Minute)
+	ids := repotest.Fixture(t, cache, "backend", "alice", "bob")
+
+	for i := 0; i < 3; i++ {
+		_, err := cache.GetUserByID(ctx, ids[0])
+		require.NoError(t, err)
+		_, err = cache.GetTeamMembers(ctx, "backend")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, backend.userReads)
+	assert.Equal(t, 1, backend.memberReads)
+}
+
+func TestCachedRepository_StaleReplicaRejectsInactiveReviewer(t *testing.T) {
+	ctx := context.Background()
+	shared := repository.NewMemoryRepository()
+	replicaA := repository.NewCachedRepository(shared, time.Minute)
+	replicaB := repository.NewCachedRepository(shared, time.Minute)
+
+	ids := repotest.Fixture(t, replicaA, "backend", "alice", "bob")
+
+	members, err := replicaB.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+	require.True(t, members[1].IsActive)
+
+	// Уведомление до replicaB не дошло: её состав устарел.
+	require.NoError(t, replicaA.SetUserActive(ctx, ids[1], false))
+
+	pr := &domain.PullRequest{
+		PullRequestID:   uuid.New(),
+		PullRequestName: "Stale",
+		AuthorID:        ids[0],
+		Status:          domain.StatusOpen,
+	}
+	err = replicaB.CreatePR(ctx, pr, []uuid.UUID{ids[1]})
+	require.Error(t, err)
+	assert.Equal(t, "REVIEWER_INACTIVE", err.Error())
+
+	members, err = replicaB.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+	assert.False(t, members[1].IsActive)
+}
+
+func TestCachedRepository_InvalidateUserInOrg(t *testing.T) {
+	acme := tenant.WithOrg(context.Background(), uuid.New())
+	globex := tenant.WithOrg(context.Background(), uuid.New())
+	backend := &countingRepository{RepositoryInterface: repository.NewMemoryRepository()}
+	cache := repository.NewCachedRepository(backend, time.Minute)
+
+	// Тот же ID в двух организациях — разные пользователи.
+	userID := uuid.New()
+	repotest.Team(t, acme, cache, "backend", repotest.Member(userID, "alice"))
+	repotest.Team(t, globex, cache, "backend", repotest.Member(userID, "alice"))
+	for _, ctx := range []context.Context{acme, globex} {
+		_, err := cache.GetUserByID(ctx, userID)
+		require.NoError(t, err)
+	}
+
+	cache.Invalidate(repository.Invalidation{Org: tenant.OrgID(acme), Users: []uuid.UUID{userID}})
+	for _, ctx := range []context.Context{acme, globex} {
+		_, err := cache.GetUserByID(ctx, userID)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 3, backend.userReads, "only the acme entry must be evicted")
+}
+
+func TestCachedRepository_TTLExpires(t *testing.T) {
+	ctx := context.Background()
+	backend := &countingRepository{RepositoryInterface: repository.NewMemoryRepository()}
+	cache := repository.NewCachedRepository(backend, time.Millisecond)
+	repotest.Fixture(t, cache, "backend", "alice")
+
+	_, err := cache.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = cache.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, backend.memberReads)
+}
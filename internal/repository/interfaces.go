@@ -13,21 +13,49 @@ type TeamRepository interface {
 	CreateTeam(ctx context.Context, team *domain.Team) error
 	GetTeamByName(ctx context.Context, teamName string) (*domain.Team, error)
 	TeamExists(ctx context.Context, teamName string) (bool, error)
+	// ListTeams возвращает все команды с участниками, отсортированные по имени.
+	ListTeams(ctx context.Context) ([]domain.Team, error)
+	// ImportTeams создаёт недостающие команды и обновляет участников
+	// (имя, команду, активность, уровень) в одной транзакции. Правило
+	// команды меняется, если задано; пустой уровень участника и nil
+	// правило оставляют прежние значения.
+	ImportTeams(ctx context.Context, teams []domain.Team) error
+	// GetSeniorityRule возвращает правило уровня ревьюверов команды или nil,
+	// если его нет. Ошибка "TEAM_NOT_FOUND", если команды нет.
+	GetSeniorityRule(ctx context.Context, teamName string) (*domain.SeniorityRule, error)
+	// SetSeniorityRule задаёт правило команды, nil снимает его.
+	// Ошибка "TEAM_NOT_FOUND", если команды нет.
+	SetSeniorityRule(ctx context.Context, teamName string, rule *domain.SeniorityRule) error
 }
 
 type UserRepository interface {
+	// UpsertUser создаёт пользователя или обновляет существующего.
+	// Ошибка "USER_REMOVED", если пользователь удалён.
 	UpsertUser(ctx context.Context, user *domain.User) error
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
 	GetTeamMembers(ctx context.Context, teamName string) ([]domain.User, error)
 	SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool) error
+	// RemoveUser помечает пользователя удалённым и деактивирует его. Строка
+	// остаётся ради истории PR, но пропадает из команд и кандидатов;
+	// повторное добавление в команду отвечает "USER_REMOVED".
+	// Ошибка "USER_NOT_FOUND", если пользователя нет или он уже удалён, и
+	// "USER_HAS_OPEN_REVIEWS", если он ещё назначен ревьювером открытых PR.
+	RemoveUser(ctx context.Context, userID uuid.UUID) error
+	// SetUserSeniority задаёт уровень пользователя.
+	// Ошибка "USER_NOT_FOUND", если пользователя нет или он удалён.
+	SetUserSeniority(ctx context.Context, userID uuid.UUID, seniority string) error
 }
 
 type PullRequestRepository interface {
+	// CreatePR создаёт PR с ревьюверами. Ошибка "REVIEWER_INACTIVE", если
+	// ревьювер неактивен: список участников мог устареть в кэше.
 	CreatePR(ctx context.Context, pr *domain.PullRequest, reviewers []uuid.UUID) error
 	GetPRByID(ctx context.Context, prID uuid.UUID) (*domain.PullRequestWithReviewers, error)
 	PRExists(ctx context.Context, prID uuid.UUID) (bool, error)
 	UpdatePRStatus(ctx context.Context, prID uuid.UUID, status string, mergedAt *time.Time) error
 	GetReviewersByPR(ctx context.Context, prID uuid.UUID) ([]uuid.UUID, error)
+	// ReplaceReviewer заменяет ревьювера. Ошибка "REVIEWER_NOT_FOUND", если
+	// oldUserID не назначен, и "REVIEWER_INACTIVE", если newUserID неактивен.
 	ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID uuid.UUID) error
 	GetPRsByReviewer(ctx context.Context, userID uuid.UUID) ([]domain.PullRequestShort, error)
 }
@@ -40,10 +68,27 @@ type StatsRepository interface {
 	GetActiveUsers(ctx context.Context) (int, error)
 }
 
+type PersonalDataRepository interface {
+	// GetUserData возвращает данные пользователя организации из ctx, в том
+	// числе удалённого. Ошибка "USER_NOT_FOUND", если пользователя нет.
+	GetUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error)
+	// AnonymousID возвращает ID, под которым пользователь будет обезличен.
+	// ID выбирается при первом вызове и хранится в записи пользователя до
+	// AnonymizeUser, поэтому повторная попытка после сбоя получает тот же
+	// ID. Ошибка "USER_NOT_FOUND", если пользователя нет.
+	AnonymousID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
+	// AnonymizeUser в одной транзакции заменяет user_id пользователя на
+	// newID, а username на pseudonym в профиле, авторстве PR и назначениях.
+	// Команда, активность и число назначений сохраняются, поэтому
+	// статистика не меняется. Ошибка "USER_NOT_FOUND", если пользователя нет.
+	AnonymizeUser(ctx context.Context, userID, newID uuid.UUID, pseudonym string) error
+}
+
 // RepositoryInterface объединяет все интерфейсы.
 type RepositoryInterface interface {
 	TeamRepository
 	UserRepository
 	PullRequestRepository
 	StatsRepository
+	PersonalDataRepository
 }
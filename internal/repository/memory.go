@@ -0,0 +1,815 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// MemoryRepository хранит данные в памяти процесса.
+// Используется для локальной разработки, демо без БД и быстрых тестов.
+// Как и в БД, ID пользователей и PR уникальны в пределах организации, а
+// записи другой организации не видны.
+type MemoryRepository struct {
+	mu sync.RWMutex
+
+	teams     map[uuid.UUID]*memTeam
+	teamNames map[teamKey]uuid.UUID
+	users     map[idKey]*memUser
+	prs       map[idKey]*memPR
+}
+
+// teamKey имя команды уникально в пределах организации.
+type teamKey struct {
+	orgID uuid.UUID
+	name  string
+}
+
+// idKey ID пользователя или PR уникален в пределах организации.
+type idKey struct {
+	orgID uuid.UUID
+	id    uuid.UUID
+}
+
+type memTeam struct {
+	id    uuid.UUID
+	orgID uuid.UUID
+	name  string
+	rule  *domain.SeniorityRule
+}
+
+type memUser struct {
+	id       uuid.UUID
+	orgID    uuid.UUID
+	username string
+	teamID   uuid.UUID
+	isActive bool
+	// seniority уровень, пустая строка — не задан.
+	seniority string
+	// removedAt время удаления: удалённый пользователь остаётся в истории
+	// PR и статистике назначений, но не виден в командах и не может быть
+	// ревьювером.
+	removedAt *time.Time
+	// anonymizedID ID для AnonymizeUser, выбранный AnonymousID.
+	anonymizedID uuid.UUID
+}
+
+type memPR struct {
+	orgID     uuid.UUID
+	pr        domain.PullRequest
+	reviewers []uuid.UUID
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		teams:     make(map[uuid.UUID]*memTeam),
+		teamNames: make(map[teamKey]uuid.UUID),
+		users:     make(map[idKey]*memUser),
+		prs:       make(map[idKey]*memPR),
+	}
+}
+
+// ========================================
+// TeamRepository Methods
+// ========================================
+
+func (r *MemoryRepository) CreateTeam(ctx context.Context, team *domain.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	orgID := tenant.OrgID(ctx)
+	key := teamKey{orgID: orgID, name: team.TeamName}
+	if _, exists := r.teamNames[key]; exists {
+		return errors.New("TEAM_EXISTS")
+	}
+	if err := r.checkMembersLocked(orgID, team.Members); err != nil {
+		return err
+	}
+
+	teamID := uuid.New()
+	r.teams[teamID] = &memTeam{id: teamID, orgID: orgID, name: team.TeamName, rule: copyRule(team.SeniorityRule)}
+	r.teamNames[key] = teamID
+
+	for _, member := range team.Members {
+		r.upsertMemberLocked(orgID, member, teamID)
+	}
+
+	slog.InfoContext(ctx, "Team created in memory", "team_name", team.TeamName, "team_id", teamID, "org_id", orgID)
+	return nil
+}
+
+func (r *MemoryRepository) GetTeamByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teamID, ok := r.teamNames[teamKey{orgID: tenant.OrgID(ctx), name: teamName}]
+	if !ok {
+		return nil, errors.New("TEAM_NOT_FOUND")
+	}
+
+	team := &domain.Team{TeamName: teamName, SeniorityRule: copyRule(r.teams[teamID].rule)}
+	for _, u := range r.membersLocked(teamID) {
+		team.Members = append(team.Members, domain.TeamMember{
+			UserID:    u.id,
+			Username:  u.username,
+			IsActive:  u.isActive,
+			Seniority: u.seniority,
+		})
+	}
+	return team, nil
+}
+
+func (r *MemoryRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.teamNames[teamKey{orgID: tenant.OrgID(ctx), name: teamName}]
+	return ok, nil
+}
+
+func (r *MemoryRepository) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	teams := make([]domain.Team, 0, len(r.teams))
+	for _, t := range r.teams {
+		if t.orgID != orgID {
+			continue
+		}
+		team := domain.Team{TeamName: t.name, Members: []domain.TeamMember{}, SeniorityRule: copyRule(t.rule)}
+		for _, u := range r.membersLocked(t.id) {
+			team.Members = append(team.Members, domain.TeamMember{
+				UserID:    u.id,
+				Username:  u.username,
+				IsActive:  u.isActive,
+				Seniority: u.seniority,
+			})
+		}
+		teams = append(teams, team)
+	}
+
+	sort.Slice(teams, func(i, j int) bool { return teams[i].TeamName < teams[j].TeamName })
+	return teams, nil
+}
+
+func (r *MemoryRepository) ImportTeams(ctx context.Context, teams []domain.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	orgID := tenant.OrgID(ctx)
+	// Импорт применяется целиком или не применяется вовсе, как транзакция в БД.
+	for _, team := range teams {
+		if err := r.checkMembersLocked(orgID, team.Members); err != nil {
+			return err
+		}
+	}
+
+	for _, team := range teams {
+		key := teamKey{orgID: orgID, name: team.TeamName}
+		teamID, ok := r.teamNames[key]
+		if !ok {
+			teamID = uuid.New()
+			r.teams[teamID] = &memTeam{id: teamID, orgID: orgID, name: team.TeamName}
+			r.teamNames[key] = teamID
+		}
+		if team.SeniorityRule != nil {
+			r.teams[teamID].rule = copyRule(team.SeniorityRule)
+		}
+
+		for _, member := range team.Members {
+			r.upsertMemberLocked(orgID, member, teamID)
+		}
+	}
+
+	slog.InfoContext(ctx, "Teams imported in memory", "teams_count", len(teams))
+	return nil
+}
+
+func (r *MemoryRepository) GetSeniorityRule(ctx context.Context, teamName string) (*domain.SeniorityRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teamID, ok := r.teamNames[teamKey{orgID: tenant.OrgID(ctx), name: teamName}]
+	if !ok {
+		return nil, errors.New("TEAM_NOT_FOUND")
+	}
+	return copyRule(r.teams[teamID].rule), nil
+}
+
+func (r *MemoryRepository) SetSeniorityRule(ctx context.Context, teamName string, rule *domain.SeniorityRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	teamID, ok := r.teamNames[teamKey{orgID: tenant.OrgID(ctx), name: teamName}]
+	if !ok {
+		return errors.New("TEAM_NOT_FOUND")
+	}
+	r.teams[teamID].rule = copyRule(rule)
+
+	slog.InfoContext(ctx, "Seniority rule updated", "team_name", teamName)
+	return nil
+}
+
+// ========================================
+// UserRepository Methods
+// ========================================
+
+func (r *MemoryRepository) UpsertUser(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	orgID := tenant.OrgID(ctx)
+	teamID, ok := r.teamNames[teamKey{orgID: orgID, name: user.TeamName}]
+	if !ok {
+		return fmt.Errorf("failed to upsert user: team %q does not exist", user.TeamName)
+	}
+	if u, ok := r.users[idKey{orgID: orgID, id: user.UserID}]; ok && u.removedAt != nil {
+		return errors.New("USER_REMOVED")
+	}
+
+	r.upsertMemberLocked(orgID, domain.TeamMember{
+		UserID:    user.UserID,
+		Username:  user.Username,
+		IsActive:  user.IsActive,
+		Seniority: user.Seniority,
+	}, teamID)
+	return nil
+}
+
+func (r *MemoryRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	u, ok := r.userLocked(ctx, userID)
+	if !ok {
+		return nil, errors.New("USER_NOT_FOUND")
+	}
+	user := r.toUserLocked(u)
+	return &user, nil
+}
+
+func (r *MemoryRepository) GetTeamMembers(ctx context.Context, teamName string) ([]domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teamID, ok := r.teamNames[teamKey{orgID: tenant.OrgID(ctx), name: teamName}]
+	if !ok {
+		return nil, nil
+	}
+
+	var members []domain.User
+	for _, u := range r.membersLocked(teamID) {
+		members = append(members, r.toUserLocked(u))
+	}
+	return members, nil
+}
+
+func (r *MemoryRepository) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.userLocked(ctx, userID)
+	if !ok {
+		return errors.New("USER_NOT_FOUND")
+	}
+	u.isActive = isActive
+
+	slog.InfoContext(ctx, "User active status updated", "user_id", userID, "is_active", isActive)
+	return nil
+}
+
+func (r *MemoryRepository) SetUserSeniority(ctx context.Context, userID uuid.UUID, seniority string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.userLocked(ctx, userID)
+	if !ok {
+		return errors.New("USER_NOT_FOUND")
+	}
+	u.seniority = seniority
+
+	slog.InfoContext(ctx, "User seniority updated", "user_id", userID, "seniority", seniority)
+	return nil
+}
+
+func (r *MemoryRepository) RemoveUser(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.userLocked(ctx, userID)
+	if !ok {
+		return errors.New("USER_NOT_FOUND")
+	}
+	orgID := tenant.OrgID(ctx)
+	for _, p := range r.prs {
+		if p.orgID == orgID && p.pr.Status == domain.StatusOpen && slices.Contains(p.reviewers, userID) {
+			return errors.New("USER_HAS_OPEN_REVIEWS")
+		}
+	}
+	now := time.Now()
+	u.isActive = false
+	u.removedAt = &now
+
+	slog.InfoContext(ctx, "User removed", "user_id", userID)
+	return nil
+}
+
+// ========================================
+// PullRequestRepository Methods
+// ========================================
+
+func (r *MemoryRepository) CreatePR(ctx context.Context, pr *domain.PullRequest, reviewers []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	orgID := tenant.OrgID(ctx)
+	if _, exists := r.prs[idKey{orgID: orgID, id: pr.PullRequestID}]; exists {
+		return errors.New("PR_EXISTS")
+	}
+	if _, ok := r.userLocked(ctx, pr.AuthorID); !ok {
+		return fmt.Errorf("failed to insert pull request: author %s does not exist", pr.AuthorID)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(reviewers))
+	for _, reviewerID := range reviewers {
+		u, ok := r.userLocked(ctx, reviewerID)
+		if !ok {
+			return fmt.Errorf("failed to insert reviewer: user %s does not exist", reviewerID)
+		}
+		if !u.isActive {
+			return errors.New("REVIEWER_INACTIVE")
+		}
+		if seen[reviewerID] {
+			return fmt.Errorf("failed to insert reviewer: duplicate reviewer %s", reviewerID)
+		}
+		seen[reviewerID] = true
+	}
+
+	stored := *pr
+	stored.CreatedAt = time.Now()
+	stored.MergedAt = nil
+	r.prs[idKey{orgID: orgID, id: pr.PullRequestID}] = &memPR{
+		orgID:     orgID,
+		pr:        stored,
+		reviewers: append([]uuid.UUID(nil), reviewers...),
+	}
+
+	slog.InfoContext(ctx, "Pull request created", "pr_id", pr.PullRequestID, "reviewers_count", len(reviewers))
+	return nil
+}
+
+func (r *MemoryRepository) GetPRByID(ctx context.Context, prID uuid.UUID) (*domain.PullRequestWithReviewers, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.prLocked(ctx, prID)
+	if !ok {
+		return nil, errors.New("PR_NOT_FOUND")
+	}
+
+	result := &domain.PullRequestWithReviewers{
+		PullRequestID:   p.pr.PullRequestID,
+		PullRequestName: p.pr.PullRequestName,
+		AuthorID:        p.pr.AuthorID,
+		Status:          p.pr.Status,
+		CreatedAt:       p.pr.CreatedAt,
+		MergedAt:        copyTime(p.pr.MergedAt),
+	}
+	if len(p.reviewers) > 0 {
+		result.AssignedReviewers = append([]uuid.UUID(nil), p.reviewers...)
+	}
+	return result, nil
+}
+
+func (r *MemoryRepository) PRExists(ctx context.Context, prID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.prLocked(ctx, prID)
+	return ok, nil
+}
+
+func (r *MemoryRepository) UpdatePRStatus(ctx context.Context, prID uuid.UUID, status string, mergedAt *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.prLocked(ctx, prID); ok {
+		p.pr.Status = status
+		p.pr.MergedAt = copyTime(mergedAt)
+	}
+
+	slog.InfoContext(ctx, "PR status updated", "pr_id", prID, "status", status)
+	return nil
+}
+
+func (r *MemoryRepository) GetReviewersByPR(ctx context.Context, prID uuid.UUID) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.prLocked(ctx, prID)
+	if !ok || len(p.reviewers) == 0 {
+		return nil, nil
+	}
+	return append([]uuid.UUID(nil), p.reviewers...), nil
+}
+
+func (r *MemoryRepository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.prLocked(ctx, prID)
+	if !ok {
+		return errors.New("REVIEWER_NOT_FOUND")
+	}
+
+	idx := -1
+	for i, id := range p.reviewers {
+		if id == oldUserID {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		return errors.New("REVIEWER_NOT_FOUND")
+	}
+	for _, id := range p.reviewers {
+		if id == newUserID && newUserID != oldUserID {
+			return fmt.Errorf("failed to replace reviewer: %s is already assigned", newUserID)
+		}
+	}
+	u, ok := r.userLocked(ctx, newUserID)
+	if !ok {
+		return fmt.Errorf("failed to replace reviewer: user %s does not exist", newUserID)
+	}
+	if !u.isActive {
+		return errors.New("REVIEWER_INACTIVE")
+	}
+
+	p.reviewers[idx] = newUserID
+
+	slog.InfoContext(ctx, "Reviewer replaced", "pr_id", prID, "old_user", oldUserID, "new_user", newUserID)
+	return nil
+}
+
+func (r *MemoryRepository) GetPRsByReviewer(ctx context.Context, userID uuid.UUID) ([]domain.PullRequestShort, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	var matched []*memPR
+	for _, p := range r.prs {
+		if p.orgID != orgID {
+			continue
+		}
+		for _, id := range p.reviewers {
+			if id == userID {
+				matched = append(matched, p)
+				break
+			}
+		}
+	}
+
+	return shortPRs(matched), nil
+}
+
+// ========================================
+// PersonalDataRepository Methods
+// ========================================
+
+func (r *MemoryRepository) GetUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	u, ok := r.users[idKey{orgID: orgID, id: userID}]
+	if !ok {
+		return nil, errors.New("USER_NOT_FOUND")
+	}
+
+	data := &domain.UserData{
+		Profile: domain.UserProfile{
+			UserID:    u.id,
+			Username:  u.username,
+			TeamName:  r.teams[u.teamID].name,
+			IsActive:  u.isActive,
+			Seniority: u.seniority,
+			RemovedAt: u.removedAt,
+		},
+	}
+
+	var authored, reviewed []*memPR
+	for _, p := range r.prs {
+		if p.orgID != orgID {
+			continue
+		}
+		if p.pr.AuthorID == userID {
+			authored = append(authored, p)
+		}
+		if slices.Contains(p.reviewers, userID) {
+			reviewed = append(reviewed, p)
+		}
+	}
+	data.AuthoredPRs = shortPRs(authored)
+	data.Reviews = shortPRs(reviewed)
+	return data, nil
+}
+
+func (r *MemoryRepository) AnonymousID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[idKey{orgID: tenant.OrgID(ctx), id: userID}]
+	if !ok {
+		return uuid.Nil, errors.New("USER_NOT_FOUND")
+	}
+	if u.anonymizedID == uuid.Nil {
+		u.anonymizedID = uuid.New()
+	}
+	return u.anonymizedID, nil
+}
+
+func (r *MemoryRepository) AnonymizeUser(ctx context.Context, userID, newID uuid.UUID, pseudonym string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	orgID := tenant.OrgID(ctx)
+	u, ok := r.users[idKey{orgID: orgID, id: userID}]
+	if !ok {
+		return errors.New("USER_NOT_FOUND")
+	}
+	if _, ok := r.users[idKey{orgID: orgID, id: newID}]; ok {
+		return errors.New("USER_EXISTS")
+	}
+
+	delete(r.users, idKey{orgID: orgID, id: userID})
+	u.id = newID
+	u.username = pseudonym
+	u.anonymizedID = uuid.Nil
+	r.users[idKey{orgID: orgID, id: newID}] = u
+
+	for _, p := range r.prs {
+		if p.orgID != orgID {
+			continue
+		}
+		if p.pr.AuthorID == userID {
+			p.pr.AuthorID = newID
+		}
+		for i, id := range p.reviewers {
+			if id == userID {
+				p.reviewers[i] = newID
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "User anonymized")
+	return nil
+}
+
+// ========================================
+// StatsRepository Methods
+// ========================================
+
+// GetUserAssignmentStats возвращает статистику назначений по пользователям.
+func (r *MemoryRepository) GetUserAssignmentStats(ctx context.Context) ([]domain.UserAssignmentStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	byUser := make(map[uuid.UUID]*domain.UserAssignmentStats, len(r.users))
+	for _, u := range r.users {
+		if u.orgID != orgID {
+			continue
+		}
+		byUser[u.id] = &domain.UserAssignmentStats{
+			UserID:   u.id,
+			Username: u.username,
+			TeamName: r.teams[u.teamID].name,
+		}
+	}
+
+	for _, p := range r.prs {
+		if p.orgID != orgID {
+			continue
+		}
+		for _, id := range p.reviewers {
+			stat := byUser[id]
+			stat.TotalAssignments++
+			switch p.pr.Status {
+			case domain.StatusOpen:
+				stat.OpenAssignments++
+			case domain.StatusMerged:
+				stat.MergedAssignments++
+			}
+		}
+	}
+
+	var stats []domain.UserAssignmentStats
+	for _, stat := range byUser {
+		stats = append(stats, *stat)
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].TotalAssignments > stats[j].TotalAssignments
+	})
+	return stats, nil
+}
+
+// GetPRStats возвращает общую статистику по PR.
+func (r *MemoryRepository) GetPRStats(ctx context.Context) (*domain.PRStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	var stats domain.PRStats
+	var mergeHours float64
+	var mergedWithTime int
+
+	for _, p := range r.prs {
+		if p.orgID != orgID {
+			continue
+		}
+		stats.TotalPRs++
+		switch p.pr.Status {
+		case domain.StatusOpen:
+			stats.TotalOpen++
+		case domain.StatusMerged:
+			stats.TotalMerged++
+		}
+		if p.pr.MergedAt != nil {
+			mergeHours += p.pr.MergedAt.Sub(p.pr.CreatedAt).Hours()
+			mergedWithTime++
+		}
+	}
+
+	if mergedWithTime > 0 {
+		stats.AvgMergeTimeHours = mergeHours / float64(mergedWithTime)
+	}
+	return &stats, nil
+}
+
+// GetTotalUsers возвращает общее количество пользователей.
+func (r *MemoryRepository) GetTotalUsers(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	count := 0
+	for _, u := range r.users {
+		if u.orgID == orgID && u.removedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetTotalTeams возвращает общее количество команд.
+func (r *MemoryRepository) GetTotalTeams(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	count := 0
+	for _, t := range r.teams {
+		if t.orgID == orgID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetActiveUsers возвращает количество активных пользователей.
+func (r *MemoryRepository) GetActiveUsers(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgID := tenant.OrgID(ctx)
+	count := 0
+	for _, u := range r.users {
+		if u.isActive && u.orgID == orgID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ========================================
+// Helpers
+// ========================================
+
+// userLocked возвращает неудалённого пользователя организации из ctx.
+func (r *MemoryRepository) userLocked(ctx context.Context, userID uuid.UUID) (*memUser, bool) {
+	u, ok := r.users[idKey{orgID: tenant.OrgID(ctx), id: userID}]
+	if !ok || u.removedAt != nil {
+		return nil, false
+	}
+	return u, true
+}
+
+// prLocked возвращает PR организации из ctx.
+func (r *MemoryRepository) prLocked(ctx context.Context, prID uuid.UUID) (*memPR, bool) {
+	p, ok := r.prs[idKey{orgID: tenant.OrgID(ctx), id: prID}]
+	return p, ok
+}
+
+// checkMembersLocked возвращает "USER_REMOVED", если участник удалён.
+func (r *MemoryRepository) checkMembersLocked(orgID uuid.UUID, members []domain.TeamMember) error {
+	for _, member := range members {
+		if u, ok := r.users[idKey{orgID: orgID, id: member.UserID}]; ok && u.removedAt != nil {
+			return errors.New("USER_REMOVED")
+		}
+	}
+	return nil
+}
+
+// shortPRs сортирует PR от новых к старым и возвращает их краткое описание.
+func shortPRs(matched []*memPR) []domain.PullRequestShort {
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].pr.CreatedAt.After(matched[j].pr.CreatedAt)
+	})
+
+	var prs []domain.PullRequestShort
+	for _, p := range matched {
+		prs = append(prs, domain.PullRequestShort{
+			PullRequestID:   p.pr.PullRequestID,
+			PullRequestName: p.pr.PullRequestName,
+			AuthorID:        p.pr.AuthorID,
+			Status:          p.pr.Status,
+			CreatedAt:       p.pr.CreatedAt,
+		})
+	}
+	return prs
+}
+
+// membersLocked возвращает неудалённых участников команды, отсортированных по username.
+func (r *MemoryRepository) membersLocked(teamID uuid.UUID) []*memUser {
+	var members []*memUser
+	for _, u := range r.users {
+		if u.teamID == teamID && u.removedAt == nil {
+			members = append(members, u)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].username < members[j].username
+	})
+	return members
+}
+
+func (r *MemoryRepository) toUserLocked(u *memUser) domain.User {
+	return domain.User{
+		UserID:    u.id,
+		Username:  u.username,
+		TeamName:  r.teams[u.teamID].name,
+		IsActive:  u.isActive,
+		Seniority: u.seniority,
+	}
+}
+
+// upsertMemberLocked добавляет участника в команду или переносит
+// существующего, как upsertMemberQuery: пустой уровень не меняет заданный
+// ранее. Удалённых участников отсекает checkMembersLocked.
+func (r *MemoryRepository) upsertMemberLocked(orgID uuid.UUID, member domain.TeamMember, teamID uuid.UUID) {
+	key := idKey{orgID: orgID, id: member.UserID}
+	seniority := member.Seniority
+	if u, ok := r.users[key]; ok && seniority == "" {
+		seniority = u.seniority
+	}
+	r.users[key] = &memUser{
+		id:        member.UserID,
+		orgID:     orgID,
+		username:  member.Username,
+		teamID:    teamID,
+		isActive:  member.IsActive,
+		seniority: seniority,
+	}
+}
+
+func copyRule(rule *domain.SeniorityRule) *domain.SeniorityRule {
+	if rule == nil {
+		return nil
+	}
+	c := *rule
+	return &c
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+// ========================================
+// Compile-time interface check
+// ========================================
+
+var _ RepositoryInterface = (*MemoryRepository)(nil)
@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/repository/repotest"
+)
+
+func TestMemoryRepository_Contract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.RepositoryInterface {
+		return repository.NewMemoryRepository()
+	})
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CacheInvalidationChannel канал LISTEN/NOTIFY для инвалидаций кэша.
+const CacheInvalidationChannel = "pr_service_cache_invalidation"
+
+// notifyInvalidation отправляет инвалидацию через pg_notify. Внутри
+// транзакции уведомление доставляется только после её коммита.
+func notifyInvalidation(ctx context.Context, db execer, inv Invalidation) error {
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invalidation: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, CacheInvalidationChannel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+	return nil
+}
+
+// PGInvalidationBus принимает инвалидации других реплик через PostgreSQL
+// LISTEN. Отправляет их Repository с WithCacheNotify.
+type PGInvalidationBus struct {
+	dsn string
+}
+
+// NewPGInvalidationBus создаёт шину. dsn нужен для отдельного LISTEN-соединения.
+func NewPGInvalidationBus(dsn string) *PGInvalidationBus {
+	return &PGInvalidationBus{dsn: dsn}
+}
+
+// Listen получает инвалидации от всех реплик, пока не отменён ctx.
+// После переподключения уведомления могли потеряться, поэтому handle
+// получает полную инвалидацию.
+func (b *PGInvalidationBus) Listen(ctx context.Context, handle func(Invalidation)) error {
+	listener := pq.NewListener(b.dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Warn("Cache invalidation listener event", "event", ev, "error", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(CacheInvalidationChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	slog.Info("Listening for cache invalidations", "channel", CacheInvalidationChannel)
+
+	ping := time.NewTicker(90 * time.Second)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case n := <-listener.Notify:
+			if n == nil {
+				slog.Warn("Cache invalidation listener reconnected, flushing cache")
+				handle(Invalidation{All: true})
+				continue
+			}
+
+			var inv Invalidation
+			if err := json.Unmarshal([]byte(n.Extra), &inv); err != nil {
+				slog.Error("Invalid cache invalidation payload", "payload", n.Extra, "error", err)
+				handle(Invalidation{All: true})
+				continue
+			}
+			handle(inv)
+
+		case <-ping.C:
+			go func() {
+				if err := listener.Ping(); err != nil {
+					slog.Warn("Cache invalidation listener ping failed", "error", err)
+				}
+			}()
+		}
+	}
+}
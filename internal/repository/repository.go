@@ -12,88 +12,206 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
 )
 
 type Repository struct {
 	db *sql.DB
+	// isUniqueViolation распознаёт нарушение уникальности у конкретного драйвера.
+	isUniqueViolation func(err error) bool
+	// notifyCache включает pg_notify об изменении составов в транзакциях записи.
+	notifyCache bool
 }
 
-func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: db}
+// RepositoryOption настраивает Repository.
+type RepositoryOption func(*Repository)
+
+// WithCacheNotify отправляет инвалидации кэша составов другим репликам
+// через pg_notify в той же транзакции, что и запись: уведомление уходит
+// только вместе с коммитом, а ошибка отправки откатывает запись.
+func WithCacheNotify() RepositoryOption {
+	return func(r *Repository) {
+		r.notifyCache = true
+	}
 }
 
-// ========================================
-// TeamRepository Methods
-// ========================================
+func NewRepository(db *sql.DB, opts ...RepositoryOption) *Repository {
+	r := &Repository{db: db, isUniqueViolation: isPQUniqueViolation}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
 
-func (r *Repository) CreateTeam(ctx context.Context, team *domain.Team) error {
+// inTx выполняет fn в транзакции и фиксирует её, если fn не вернула ошибку.
+func (r *Repository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() {
 		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
-			slog.Error("Failed to rollback transaction", "error", err)
+			slog.ErrorContext(ctx, "Failed to rollback transaction", "error", err)
 		}
 	}()
 
-	var teamID uuid.UUID
-	err = tx.QueryRowContext(ctx, `
-		INSERT INTO teams (team_name) 
-		VALUES ($1) 
-		RETURNING team_id
-	`, team.TeamName).Scan(&teamID)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// publishInvalidation отправляет инвалидацию кэша в транзакции tx,
+// если включён WithCacheNotify.
+func (r *Repository) publishInvalidation(ctx context.Context, tx *sql.Tx, inv Invalidation) error {
+	if !r.notifyCache {
+		return nil
+	}
+	return notifyInvalidation(ctx, tx, inv)
+}
+
+// publishUserInvalidation сбрасывает пользователя и состав его команды.
+func (r *Repository) publishUserInvalidation(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	if !r.notifyCache {
+		return nil
+	}
+
+	orgID := tenant.OrgID(ctx)
+	inv := Invalidation{Org: orgID, Users: []uuid.UUID{userID}}
+	var teamName string
+	err := tx.QueryRowContext(ctx, `
+		SELECT t.team_name
+		FROM users u
+		JOIN teams t ON u.team_id = t.team_id
+		WHERE u.user_id = $1 AND u.org_id = $2
+	`, userID, orgID).Scan(&teamName)
+	switch {
+	case err == nil:
+		inv.Teams = []string{teamName}
+	case errors.Is(err, sql.ErrNoRows):
+		inv = Invalidation{All: true}
+	default:
+		return fmt.Errorf("failed to get user team: %w", err)
+	}
+	return notifyInvalidation(ctx, tx, inv)
+}
+
+func isPQUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// upsertMemberQuery добавляет участника в команду или переносит существующего;
+// пустой уровень не меняет заданный ранее. Удалённый пользователь не
+// меняется: запрос не затрагивает строк. ID пользователя уникален в
+// пределах организации, поэтому тот же ID в другой организации — другой
+// пользователь.
+const upsertMemberQuery = `
+	INSERT INTO users (user_id, username, team_id, is_active, org_id, seniority)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (org_id, user_id) DO UPDATE SET
+		username = EXCLUDED.username,
+		team_id = EXCLUDED.team_id,
+		is_active = EXCLUDED.is_active,
+		seniority = COALESCE(NULLIF(EXCLUDED.seniority, ''), users.seniority),
+		updated_at = CURRENT_TIMESTAMP
+	WHERE users.removed_at IS NULL
+`
+
+// execer общий интерфейс *sql.DB и *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// upsertMember выполняет upsertMemberQuery и возвращает "USER_REMOVED",
+// если пользователь удалён.
+func upsertMember(ctx context.Context, db execer, member domain.TeamMember, teamID, orgID uuid.UUID) error {
+	result, err := db.ExecContext(ctx, upsertMemberQuery, member.UserID, member.Username, teamID, member.IsActive, orgID, member.Seniority)
 	if err != nil {
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
-			return errors.New("TEAM_EXISTS")
-		}
-		return fmt.Errorf("failed to insert team: %w", err)
+		return err
 	}
+	return checkNotRemoved(result)
+}
 
-	for _, member := range team.Members {
-		_, err = tx.ExecContext(ctx, `
-			INSERT INTO users (user_id, username, team_id, is_active)
-			VALUES ($1, $2, $3, $4)
-			ON CONFLICT (user_id) DO UPDATE SET
-				username = EXCLUDED.username,
-				team_id = EXCLUDED.team_id,
-				is_active = EXCLUDED.is_active,
-				updated_at = NOW()
-		`, member.UserID, member.Username, teamID, member.IsActive)
+// checkNotRemoved возвращает "USER_REMOVED", если upsert пользователя не
+// затронул строк из-за условия removed_at IS NULL.
+func checkNotRemoved(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.New("USER_REMOVED")
+	}
+	return nil
+}
+
+// ========================================
+// TeamRepository Methods
+// ========================================
+
+func (r *Repository) CreateTeam(ctx context.Context, team *domain.Team) error {
+	orgID := tenant.OrgID(ctx)
+	teamID := uuid.New()
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		level, count := seniorityRuleColumns(team.SeniorityRule)
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO teams (team_id, team_name, org_id, seniority_level, seniority_count) 
+			VALUES ($1, $2, $3, $4, $5)
+		`, teamID, team.TeamName, orgID, level, count)
 		if err != nil {
-			return fmt.Errorf("failed to insert user: %w", err)
+			if r.isUniqueViolation(err) {
+				return errors.New("TEAM_EXISTS")
+			}
+			return fmt.Errorf("failed to insert team: %w", err)
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		for _, member := range team.Members {
+			if err := upsertMember(ctx, tx, member, teamID, orgID); err != nil {
+				if err.Error() == "USER_REMOVED" {
+					return err
+				}
+				return fmt.Errorf("failed to insert user: %w", err)
+			}
+		}
+
+		// Участники могли переехать из других команд.
+		return r.publishInvalidation(ctx, tx, Invalidation{All: true})
+	})
+	if err != nil {
+		return err
 	}
 
-	slog.Info("Team created in DB", "team_name", team.TeamName, "team_id", teamID)
+	slog.InfoContext(ctx, "Team created in DB", "team_name", team.TeamName, "team_id", teamID, "org_id", orgID)
 	return nil
 }
 
 func (r *Repository) GetTeamByName(ctx context.Context, teamName string) (*domain.Team, error) {
 	var team domain.Team
 	var teamID uuid.UUID
+	var level sql.NullString
+	var count int
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT team_id, team_name 
+		SELECT team_id, team_name, seniority_level, seniority_count 
 		FROM teams 
-		WHERE team_name = $1
-	`, teamName).Scan(&teamID, &team.TeamName)
+		WHERE team_name = $1 AND org_id = $2
+	`, teamName, tenant.OrgID(ctx)).Scan(&teamID, &team.TeamName, &level, &count)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("TEAM_NOT_FOUND")
 		}
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
+	team.SeniorityRule = seniorityRule(level, count)
 
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT user_id, username, is_active 
+		SELECT user_id, username, is_active, seniority 
 		FROM users 
-		WHERE team_id = $1
+		WHERE team_id = $1 AND removed_at IS NULL
 		ORDER BY username
 	`, teamID)
 	if err != nil {
@@ -103,7 +221,7 @@ func (r *Repository) GetTeamByName(ctx context.Context, teamName string) (*domai
 
 	for rows.Next() {
 		var member domain.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.Seniority); err != nil {
 			return nil, fmt.Errorf("failed to scan member: %w", err)
 		}
 		team.Members = append(team.Members, member)
@@ -115,42 +233,209 @@ func (r *Repository) GetTeamByName(ctx context.Context, teamName string) (*domai
 func (r *Repository) TeamExists(ctx context.Context, teamName string) (bool, error) {
 	var exists bool
 	err := r.db.QueryRowContext(ctx, `
-		SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)
-	`, teamName).Scan(&exists)
+		SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1 AND org_id = $2)
+	`, teamName, tenant.OrgID(ctx)).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check team existence: %w", err)
 	}
 	return exists, nil
 }
 
+func (r *Repository) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT t.team_name, t.seniority_level, t.seniority_count,
+			u.user_id, u.username, u.is_active, u.seniority
+		FROM teams t
+		LEFT JOIN users u ON u.team_id = t.team_id AND u.removed_at IS NULL
+		WHERE t.org_id = $1
+		ORDER BY t.team_name, u.username
+	`, tenant.OrgID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []domain.Team
+	for rows.Next() {
+		var (
+			teamName  string
+			level     sql.NullString
+			count     int
+			userID    uuid.NullUUID
+			username  sql.NullString
+			isActive  sql.NullBool
+			seniority sql.NullString
+		)
+		if err := rows.Scan(&teamName, &level, &count, &userID, &username, &isActive, &seniority); err != nil {
+			return nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, domain.Team{
+				TeamName:      teamName,
+				Members:       []domain.TeamMember{},
+				SeniorityRule: seniorityRule(level, count),
+			})
+		}
+		// Команда без участников даёт одну строку с NULL.
+		if userID.Valid {
+			team := &teams[len(teams)-1]
+			team.Members = append(team.Members, domain.TeamMember{
+				UserID:    userID.UUID,
+				Username:  username.String,
+				IsActive:  isActive.Bool,
+				Seniority: seniority.String,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (r *Repository) ImportTeams(ctx context.Context, teams []domain.Team) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		orgID := tenant.OrgID(ctx)
+		for _, team := range teams {
+			var teamID uuid.UUID
+			err := tx.QueryRowContext(ctx, `
+				SELECT team_id FROM teams WHERE team_name = $1 AND org_id = $2
+			`, team.TeamName, orgID).Scan(&teamID)
+			level, count := seniorityRuleColumns(team.SeniorityRule)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				teamID = uuid.New()
+				_, err = tx.ExecContext(ctx, `
+					INSERT INTO teams (team_id, team_name, org_id, seniority_level, seniority_count)
+					VALUES ($1, $2, $3, $4, $5)
+				`, teamID, team.TeamName, orgID, level, count)
+			case err == nil && team.SeniorityRule != nil:
+				_, err = tx.ExecContext(ctx, `
+					UPDATE teams SET seniority_level = $1, seniority_count = $2 WHERE team_id = $3
+				`, level, count, teamID)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to import team %q: %w", team.TeamName, err)
+			}
+
+			for _, member := range team.Members {
+				if err := upsertMember(ctx, tx, member, teamID, orgID); err != nil {
+					if err.Error() == "USER_REMOVED" {
+						return err
+					}
+					return fmt.Errorf("failed to import user %s: %w", member.UserID, err)
+				}
+			}
+		}
+
+		return r.publishInvalidation(ctx, tx, Invalidation{All: true})
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Teams imported in DB", "teams_count", len(teams))
+	return nil
+}
+
+func (r *Repository) GetSeniorityRule(ctx context.Context, teamName string) (*domain.SeniorityRule, error) {
+	var level sql.NullString
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT seniority_level, seniority_count
+		FROM teams
+		WHERE team_name = $1 AND org_id = $2
+	`, teamName, tenant.OrgID(ctx)).Scan(&level, &count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("TEAM_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to get seniority rule: %w", err)
+	}
+	return seniorityRule(level, count), nil
+}
+
+func (r *Repository) SetSeniorityRule(ctx context.Context, teamName string, rule *domain.SeniorityRule) error {
+	level, count := seniorityRuleColumns(rule)
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE teams
+		SET seniority_level = $1, seniority_count = $2
+		WHERE team_name = $3 AND org_id = $4
+	`, level, count, teamName, tenant.OrgID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update seniority rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.New("TEAM_NOT_FOUND")
+	}
+
+	slog.InfoContext(ctx, "Seniority rule updated", "team_name", teamName, "level", level.String, "count", count)
+	return nil
+}
+
+// seniorityRuleColumns раскладывает правило по колонкам teams.
+func seniorityRuleColumns(rule *domain.SeniorityRule) (sql.NullString, int) {
+	if rule == nil {
+		return sql.NullString{}, 0
+	}
+	return sql.NullString{String: rule.Level, Valid: true}, rule.Count
+}
+
+// seniorityRule собирает правило из колонок teams; nil, если его нет.
+func seniorityRule(level sql.NullString, count int) *domain.SeniorityRule {
+	if !level.Valid || count == 0 {
+		return nil
+	}
+	return &domain.SeniorityRule{Level: level.String, Count: count}
+}
+
 // ========================================
 // UserRepository Methods
 // ========================================
 
 func (r *Repository) UpsertUser(ctx context.Context, user *domain.User) error {
-	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO users (user_id, username, team_id, is_active)
-		VALUES ($1, $2, (SELECT team_id FROM teams WHERE team_name = $3), $4)
-		ON CONFLICT (user_id) DO UPDATE SET
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		return r.upsertUser(ctx, tx, user)
+	})
+}
+
+func (r *Repository) upsertUser(ctx context.Context, tx *sql.Tx, user *domain.User) error {
+	orgID := tenant.OrgID(ctx)
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO users (user_id, username, team_id, is_active, org_id, seniority)
+		VALUES ($1, $2, (SELECT team_id FROM teams WHERE team_name = $3 AND org_id = $4), $5, $4, $6)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET
 			username = EXCLUDED.username,
 			team_id = EXCLUDED.team_id,
 			is_active = EXCLUDED.is_active,
-			updated_at = NOW()
-	`, user.UserID, user.Username, user.TeamName, user.IsActive)
+			seniority = COALESCE(NULLIF(EXCLUDED.seniority, ''), users.seniority),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE users.removed_at IS NULL
+	`, user.UserID, user.Username, user.TeamName, orgID, user.IsActive, user.Seniority)
 	if err != nil {
 		return fmt.Errorf("failed to upsert user: %w", err)
 	}
-	return nil
+	if err := checkNotRemoved(result); err != nil {
+		return err
+	}
+	return r.publishInvalidation(ctx, tx, Invalidation{All: true})
 }
 
 func (r *Repository) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
 	var user domain.User
 	err := r.db.QueryRowContext(ctx, `
-		SELECT u.user_id, u.username, t.team_name, u.is_active
+		SELECT u.user_id, u.username, t.team_name, u.is_active, u.seniority
 		FROM users u
 		JOIN teams t ON u.team_id = t.team_id
-		WHERE u.user_id = $1
-	`, userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+		WHERE u.user_id = $1 AND u.org_id = $2 AND u.removed_at IS NULL
+	`, userID, tenant.OrgID(ctx)).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Seniority)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("USER_NOT_FOUND")
@@ -162,12 +447,12 @@ func (r *Repository) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain
 
 func (r *Repository) GetTeamMembers(ctx context.Context, teamName string) ([]domain.User, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT u.user_id, u.username, t.team_name, u.is_active
+		SELECT u.user_id, u.username, t.team_name, u.is_active, u.seniority
 		FROM users u
 		JOIN teams t ON u.team_id = t.team_id
-		WHERE t.team_name = $1
+		WHERE t.team_name = $1 AND t.org_id = $2 AND u.removed_at IS NULL
 		ORDER BY u.username
-	`, teamName)
+	`, teamName, tenant.OrgID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team members: %w", err)
 	}
@@ -176,7 +461,7 @@ func (r *Repository) GetTeamMembers(ctx context.Context, teamName string) ([]dom
 	var members []domain.User
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Seniority); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		members = append(members, user)
@@ -185,24 +470,101 @@ func (r *Repository) GetTeamMembers(ctx context.Context, teamName string) ([]dom
 }
 
 func (r *Repository) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool) error {
-	result, err := r.db.ExecContext(ctx, `
-		UPDATE users 
-		SET is_active = $1, updated_at = NOW() 
-		WHERE user_id = $2
-	`, isActive, userID)
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE users 
+			SET is_active = $1, updated_at = CURRENT_TIMESTAMP 
+			WHERE user_id = $2 AND org_id = $3 AND removed_at IS NULL
+		`, isActive, userID, tenant.OrgID(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to update user active status: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return errors.New("USER_NOT_FOUND")
+		}
+		return r.publishUserInvalidation(ctx, tx, userID)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update user active status: %w", err)
+		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	slog.InfoContext(ctx, "User active status updated", "user_id", userID, "is_active", isActive)
+	return nil
+}
+
+func (r *Repository) SetUserSeniority(ctx context.Context, userID uuid.UUID, seniority string) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE users 
+			SET seniority = $1, updated_at = CURRENT_TIMESTAMP 
+			WHERE user_id = $2 AND org_id = $3 AND removed_at IS NULL
+		`, seniority, userID, tenant.OrgID(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to update user seniority: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return errors.New("USER_NOT_FOUND")
+		}
+		return r.publishUserInvalidation(ctx, tx, userID)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return err
 	}
-	if rowsAffected == 0 {
-		return errors.New("USER_NOT_FOUND")
+
+	slog.InfoContext(ctx, "User seniority updated", "user_id", userID, "seniority", seniority)
+	return nil
+}
+
+func (r *Repository) RemoveUser(ctx context.Context, userID uuid.UUID) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE users 
+			SET removed_at = CURRENT_TIMESTAMP, is_active = false, updated_at = CURRENT_TIMESTAMP 
+			WHERE user_id = $1 AND org_id = $2 AND removed_at IS NULL
+		`, userID, tenant.OrgID(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to remove user: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return errors.New("USER_NOT_FOUND")
+		}
+
+		// Ревью могли назначить, пока сервис переназначал прежние:
+		// такого пользователя удалять нельзя.
+		var open int
+		err = tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pr_reviewers rv
+			JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id AND pr.org_id = rv.org_id
+			WHERE rv.user_id = $1 AND rv.org_id = $2 AND pr.status = $3
+		`, userID, tenant.OrgID(ctx), domain.StatusOpen).Scan(&open)
+		if err != nil {
+			return fmt.Errorf("failed to check open reviews: %w", err)
+		}
+		if open > 0 {
+			return errors.New("USER_HAS_OPEN_REVIEWS")
+		}
+		return r.publishUserInvalidation(ctx, tx, userID)
+	})
+	if err != nil {
+		return err
 	}
 
-	slog.Info("User active status updated", "user_id", userID, "is_active", isActive)
+	slog.InfoContext(ctx, "User removed", "user_id", userID)
 	return nil
 }
 
@@ -211,43 +573,62 @@ func (r *Repository) SetUserActive(ctx context.Context, userID uuid.UUID, isActi
 // ========================================
 
 func (r *Repository) CreatePR(ctx context.Context, pr *domain.PullRequest, reviewers []uuid.UUID) error {
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() {
-		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
-			slog.Error("Failed to rollback transaction", "error", err)
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		// Удалённый автор по внешнему ключу существует, но PR создать не может.
+		orgID := tenant.OrgID(ctx)
+		var members int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM users WHERE user_id = $1 AND org_id = $2 AND removed_at IS NULL
+		`, pr.AuthorID, orgID).Scan(&members)
+		if err != nil {
+			return fmt.Errorf("failed to check author: %w", err)
 		}
-	}()
-
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
-		VALUES ($1, $2, $3, $4)
-	`, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status)
-	if err != nil {
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
-			return errors.New("PR_EXISTS")
+		if members == 0 {
+			return fmt.Errorf("failed to insert pull request: author %s does not exist", pr.AuthorID)
 		}
-		return fmt.Errorf("failed to insert pull request: %w", err)
-	}
 
-	for _, reviewerID := range reviewers {
 		_, err = tx.ExecContext(ctx, `
-			INSERT INTO pr_reviewers (pull_request_id, user_id)
-			VALUES ($1, $2)
-		`, pr.PullRequestID, reviewerID)
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, org_id)
+			VALUES ($1, $2, $3, $4, $5)
+		`, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, orgID)
 		if err != nil {
-			return fmt.Errorf("failed to insert reviewer: %w", err)
+			if r.isUniqueViolation(err) {
+				return errors.New("PR_EXISTS")
+			}
+			return fmt.Errorf("failed to insert pull request: %w", err)
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		for _, reviewerID := range reviewers {
+			// Активность проверяется здесь, а не по списку участников из кэша.
+			var isActive bool
+			err = tx.QueryRowContext(ctx, `
+				SELECT is_active FROM users WHERE user_id = $1 AND org_id = $2 AND removed_at IS NULL
+			`, reviewerID, orgID).Scan(&isActive)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("failed to insert reviewer: user %s does not exist", reviewerID)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to check reviewer: %w", err)
+			}
+			if !isActive {
+				return errors.New("REVIEWER_INACTIVE")
+			}
+
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO pr_reviewers (pull_request_id, user_id, org_id)
+				VALUES ($1, $2, $3)
+			`, pr.PullRequestID, reviewerID, orgID)
+			if err != nil {
+				return fmt.Errorf("failed to insert reviewer: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	slog.Info("Pull request created", "pr_id", pr.PullRequestID, "reviewers_count", len(reviewers))
+	slog.InfoContext(ctx, "Pull request created", "pr_id", pr.PullRequestID, "reviewers_count", len(reviewers))
 	return nil
 }
 
@@ -257,8 +638,8 @@ func (r *Repository) GetPRByID(ctx context.Context, prID uuid.UUID) (*domain.Pul
 	err := r.db.QueryRowContext(ctx, `
 		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
 		FROM pull_requests
-		WHERE pull_request_id = $1
-	`, prID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
+		WHERE pull_request_id = $1 AND org_id = $2
+	`, prID, tenant.OrgID(ctx)).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("PR_NOT_FOUND")
@@ -269,8 +650,8 @@ func (r *Repository) GetPRByID(ctx context.Context, prID uuid.UUID) (*domain.Pul
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT user_id 
 		FROM pr_reviewers 
-		WHERE pull_request_id = $1
-	`, prID)
+		WHERE pull_request_id = $1 AND org_id = $2
+	`, prID, tenant.OrgID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviewers: %w", err)
 	}
@@ -290,8 +671,8 @@ func (r *Repository) GetPRByID(ctx context.Context, prID uuid.UUID) (*domain.Pul
 func (r *Repository) PRExists(ctx context.Context, prID uuid.UUID) (bool, error) {
 	var exists bool
 	err := r.db.QueryRowContext(ctx, `
-		SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)
-	`, prID).Scan(&exists)
+		SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1 AND org_id = $2)
+	`, prID, tenant.OrgID(ctx)).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check PR existence: %w", err)
 	}
@@ -302,22 +683,22 @@ func (r *Repository) UpdatePRStatus(ctx context.Context, prID uuid.UUID, status
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE pull_requests 
 		SET status = $1, merged_at = $2 
-		WHERE pull_request_id = $3
-	`, status, mergedAt, prID)
+		WHERE pull_request_id = $3 AND org_id = $4
+	`, status, mergedAt, prID, tenant.OrgID(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to update PR status: %w", err)
 	}
 
-	slog.Info("PR status updated", "pr_id", prID, "status", status)
+	slog.InfoContext(ctx, "PR status updated", "pr_id", prID, "status", status)
 	return nil
 }
 
 func (r *Repository) GetReviewersByPR(ctx context.Context, prID uuid.UUID) ([]uuid.UUID, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT user_id 
-		FROM pr_reviewers 
-		WHERE pull_request_id = $1
-	`, prID)
+		FROM pr_reviewers
+		WHERE pull_request_id = $1 AND org_id = $2
+	`, prID, tenant.OrgID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviewers: %w", err)
 	}
@@ -335,49 +716,198 @@ func (r *Repository) GetReviewersByPR(ctx context.Context, prID uuid.UUID) ([]uu
 }
 
 func (r *Repository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID uuid.UUID) error {
-	result, err := r.db.ExecContext(ctx, `
-		UPDATE pr_reviewers 
-		SET user_id = $1 
-		WHERE pull_request_id = $2 AND user_id = $3
-	`, newUserID, prID, oldUserID)
-	if err != nil {
-		return fmt.Errorf("failed to replace reviewer: %w", err)
-	}
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		orgID := tenant.OrgID(ctx)
+		result, err := tx.ExecContext(ctx, `
+			UPDATE pr_reviewers 
+			SET user_id = $1 
+			WHERE pull_request_id = $2 AND user_id = $3 AND org_id = $4
+				AND EXISTS (SELECT 1 FROM users WHERE user_id = $1 AND org_id = $4 AND is_active AND removed_at IS NULL)
+		`, newUserID, prID, oldUserID, orgID)
+		if err != nil {
+			return fmt.Errorf("failed to replace reviewer: %w", err)
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-	if rowsAffected == 0 {
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected > 0 {
+			return nil
+		}
+
+		// Различаем неактивного нового ревьювера и отсутствующего старого.
+		var inactive bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM users WHERE user_id = $1 AND org_id = $2 AND NOT is_active AND removed_at IS NULL)
+				AND EXISTS (
+					SELECT 1 FROM pr_reviewers
+					WHERE pull_request_id = $3 AND user_id = $4 AND org_id = $2
+				)
+		`, newUserID, orgID, prID, oldUserID).Scan(&inactive)
+		if err != nil {
+			return fmt.Errorf("failed to check reviewer: %w", err)
+		}
+		if inactive {
+			return errors.New("REVIEWER_INACTIVE")
+		}
 		return errors.New("REVIEWER_NOT_FOUND")
+	})
+	if err != nil {
+		return err
 	}
 
-	slog.Info("Reviewer replaced", "pr_id", prID, "old_user", oldUserID, "new_user", newUserID)
+	slog.InfoContext(ctx, "Reviewer replaced", "pr_id", prID, "old_user", oldUserID, "new_user", newUserID)
 	return nil
 }
 
 func (r *Repository) GetPRsByReviewer(ctx context.Context, userID uuid.UUID) ([]domain.PullRequestShort, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT pr.pull_request_id, pr.pull_request_name, pr.status
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at
 		FROM pull_requests pr
-		JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
-		WHERE prr.user_id = $1
+		JOIN pr_reviewers prr ON pr.org_id = prr.org_id AND pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = $1 AND pr.org_id = $2
 		ORDER BY pr.created_at DESC
-	`, userID)
+	`, userID, tenant.OrgID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PRs: %w", err)
 	}
+	return scanShortPRs(rows)
+}
+
+// scanShortPRs читает строки pull_request_id, pull_request_name,
+// author_id, status, created_at и закрывает rows.
+func scanShortPRs(rows *sql.Rows) ([]domain.PullRequestShort, error) {
 	defer rows.Close()
 
 	var prs []domain.PullRequestShort
 	for rows.Next() {
 		var pr domain.PullRequestShort
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.Status); err != nil {
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
 		prs = append(prs, pr)
 	}
-	return prs, nil
+	return prs, rows.Err()
+}
+
+// ========================================
+// PersonalDataRepository Methods
+// ========================================
+
+func (r *Repository) GetUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	var data domain.UserData
+	var removedAt sql.NullTime
+
+	profile := &data.Profile
+	err := r.db.QueryRowContext(ctx, `
+		SELECT u.user_id, u.username, t.team_name, u.is_active, u.seniority, u.removed_at
+		FROM users u
+		JOIN teams t ON u.team_id = t.team_id
+		WHERE u.user_id = $1 AND u.org_id = $2
+	`, userID, tenant.OrgID(ctx)).Scan(&profile.UserID, &profile.Username, &profile.TeamName, &profile.IsActive, &profile.Seniority, &removedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("USER_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if removedAt.Valid {
+		profile.RemovedAt = &removedAt.Time
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at
+		FROM pull_requests
+		WHERE author_id = $1 AND org_id = $2
+		ORDER BY created_at DESC
+	`, userID, tenant.OrgID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authored PRs: %w", err)
+	}
+	if data.AuthoredPRs, err = scanShortPRs(rows); err != nil {
+		return nil, err
+	}
+
+	if data.Reviews, err = r.GetPRsByReviewer(ctx, userID); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *Repository) AnonymousID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	var newID uuid.UUID
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE users SET anonymized_id = COALESCE(anonymized_id, $1)
+		WHERE user_id = $2 AND org_id = $3
+		RETURNING anonymized_id
+	`, uuid.New(), userID, tenant.OrgID(ctx)).Scan(&newID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errors.New("USER_NOT_FOUND")
+		}
+		return uuid.Nil, fmt.Errorf("failed to reserve anonymous ID: %w", err)
+	}
+	return newID, nil
+}
+
+func (r *Repository) AnonymizeUser(ctx context.Context, userID, newID uuid.UUID, pseudonym string) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		orgID := tenant.OrgID(ctx)
+		var (
+			teamID    uuid.UUID
+			isActive  bool
+			seniority string
+			createdAt time.Time
+			removedAt sql.NullTime
+		)
+		err := tx.QueryRowContext(ctx, `
+			SELECT team_id, is_active, seniority, created_at, removed_at
+			FROM users
+			WHERE user_id = $1 AND org_id = $2
+		`, userID, orgID).Scan(&teamID, &isActive, &seniority, &createdAt, &removedAt)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("USER_NOT_FOUND")
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		// Первичный ключ нельзя поменять под внешними ключами, поэтому
+		// пользователь копируется под новым ID, ссылки переносятся на копию,
+		// а исходная строка удаляется.
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users (user_id, username, team_id, is_active, org_id, seniority, created_at, removed_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, newID, pseudonym, teamID, isActive, orgID, seniority, createdAt, removedAt)
+		if err != nil {
+			if r.isUniqueViolation(err) {
+				return errors.New("USER_EXISTS")
+			}
+			return fmt.Errorf("failed to copy user: %w", err)
+		}
+
+		for _, query := range []string{
+			`UPDATE pull_requests SET author_id = $1 WHERE author_id = $2 AND org_id = $3`,
+			`UPDATE pr_reviewers SET user_id = $1 WHERE user_id = $2 AND org_id = $3`,
+		} {
+			if _, err := tx.ExecContext(ctx, query, newID, userID, orgID); err != nil {
+				return fmt.Errorf("failed to move user references: %w", err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE user_id = $1 AND org_id = $2`, userID, orgID); err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+
+		return r.publishInvalidation(ctx, tx, Invalidation{All: true})
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "User anonymized")
+	return nil
 }
 
 // ========================================
@@ -396,13 +926,14 @@ func (r *Repository) GetUserAssignmentStats(ctx context.Context) ([]domain.UserA
 			COALESCE(COUNT(CASE WHEN pr_main.status = 'merged' THEN 1 END), 0) as merged_assignments
 		FROM users u
 		JOIN teams t ON u.team_id = t.team_id
-		LEFT JOIN pr_reviewers pr ON u.user_id = pr.user_id
-		LEFT JOIN pull_requests pr_main ON pr.pull_request_id = pr_main.pull_request_id
+		LEFT JOIN pr_reviewers pr ON u.org_id = pr.org_id AND u.user_id = pr.user_id
+		LEFT JOIN pull_requests pr_main ON pr.org_id = pr_main.org_id AND pr.pull_request_id = pr_main.pull_request_id
+		WHERE u.org_id = $1
 		GROUP BY u.user_id, u.username, t.team_name
 		ORDER BY total_assignments DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, tenant.OrgID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user assignment stats: %w", err)
 	}
@@ -437,10 +968,11 @@ func (r *Repository) GetPRStats(ctx context.Context) (*domain.PRStats, error) {
 			COUNT(*) as total_prs,
 			COALESCE(AVG(EXTRACT(EPOCH FROM (merged_at - created_at))/3600) FILTER (WHERE merged_at IS NOT NULL), 0) as avg_merge_time_hours
 		FROM pull_requests
+		WHERE org_id = $1
 	`
 
 	var stats domain.PRStats
-	err := r.db.QueryRowContext(ctx, query).Scan(
+	err := r.db.QueryRowContext(ctx, query, tenant.OrgID(ctx)).Scan(
 		&stats.TotalOpen,
 		&stats.TotalMerged,
 		&stats.TotalPRs,
@@ -456,7 +988,7 @@ func (r *Repository) GetPRStats(ctx context.Context) (*domain.PRStats, error) {
 // GetTotalUsers возвращает общее количество пользователей.
 func (r *Repository) GetTotalUsers(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&count)
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE org_id = $1 AND removed_at IS NULL`, tenant.OrgID(ctx)).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total users: %w", err)
 	}
@@ -466,7 +998,7 @@ func (r *Repository) GetTotalUsers(ctx context.Context) (int, error) {
 // GetTotalTeams возвращает общее количество команд.
 func (r *Repository) GetTotalTeams(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM teams`).Scan(&count)
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM teams WHERE org_id = $1`, tenant.OrgID(ctx)).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total teams: %w", err)
 	}
@@ -476,7 +1008,7 @@ func (r *Repository) GetTotalTeams(ctx context.Context) (int, error) {
 // GetActiveUsers возвращает количество активных пользователей.
 func (r *Repository) GetActiveUsers(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE is_active = true`).Scan(&count)
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE is_active = true AND org_id = $1`, tenant.OrgID(ctx)).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get active users: %w", err)
 	}
@@ -0,0 +1,932 @@
+// Package repotest содержит контрактные тесты, которые обязана проходить
+// любая реализация repository.RepositoryInterface.
+package repotest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Factory возвращает пустое хранилище для одного теста.
+type Factory func(t *testing.T) repository.RepositoryInterface
+
+// Run запускает контрактный набор тестов против реализации.
+func Run(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.RepositoryInterface)
+	}{
+		{"CreateTeam", testCreateTeam},
+		{"CreateTeamDuplicate", testCreateTeamDuplicate},
+		{"CreateTeamMovesExistingUser", testCreateTeamMovesExistingUser},
+		{"GetTeamNotFound", testGetTeamNotFound},
+		{"ListTeams", testListTeams},
+		{"ImportTeams", testImportTeams},
+		{"UpsertUser", testUpsertUser},
+		{"GetUserNotFound", testGetUserNotFound},
+		{"SetUserActive", testSetUserActive},
+		{"RemoveUser", testRemoveUser},
+		{"UserSeniority", testUserSeniority},
+		{"SeniorityRule", testSeniorityRule},
+		{"GetUserData", testGetUserData},
+		{"AnonymizeUser", testAnonymizeUser},
+		{"AnonymousID", testAnonymousID},
+		{"CreatePR", testCreatePR},
+		{"CreatePRDuplicate", testCreatePRDuplicate},
+		{"GetPRNotFound", testGetPRNotFound},
+		{"UpdatePRStatus", testUpdatePRStatus},
+		{"ReplaceReviewer", testReplaceReviewer},
+		{"InactiveReviewer", testInactiveReviewer},
+		{"GetPRsByReviewer", testGetPRsByReviewer},
+		{"Stats", testStats},
+		{"TenantReadsAreIsolated", testTenantReadsAreIsolated},
+		{"TenantWritesAreIsolated", testTenantWritesAreIsolated},
+		{"TenantIDsAreIndependent", testTenantIDsAreIndependent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+// OtherOrgID вторая организация для проверок изоляции. SQL реализации
+// должны создать её через SeedOrganizations до Run.
+var OtherOrgID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+// SeedOrganizations создаёт OtherOrgID в таблице organizations.
+func SeedOrganizations(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`
+		INSERT INTO organizations (org_id, name) VALUES ($1, 'other')
+		ON CONFLICT (org_id) DO NOTHING
+	`, OtherOrgID.String())
+	require.NoError(t, err)
+}
+
+// Fixture создаёт команду с участниками и возвращает их ID в порядке имён.
+func Fixture(t *testing.T, repo repository.RepositoryInterface, teamName string, usernames ...string) []uuid.UUID {
+	t.Helper()
+	return fixtureIn(t, context.Background(), repo, teamName, usernames...)
+}
+
+// fixtureIn как Fixture, но в организации из ctx.
+func fixtureIn(t *testing.T, ctx context.Context, repo repository.RepositoryInterface, teamName string, usernames ...string) []uuid.UUID {
+	t.Helper()
+
+	members := make([]domain.TeamMember, len(usernames))
+	ids := make([]uuid.UUID, len(usernames))
+	for i, name := range usernames {
+		ids[i] = uuid.New()
+		members[i] = Member(ids[i], name)
+	}
+
+	Team(t, ctx, repo, teamName, members...)
+	return ids
+}
+
+// Member активный участник команды для Team.
+func Member(userID uuid.UUID, username string) domain.TeamMember {
+	return domain.TeamMember{UserID: userID, Username: username, IsActive: true}
+}
+
+// Team создаёт команду с участниками с известными ID в организации из ctx.
+func Team(t *testing.T, ctx context.Context, repo repository.RepositoryInterface, teamName string, members ...domain.TeamMember) {
+	t.Helper()
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: teamName, Members: members}))
+}
+
+func newPR(authorID uuid.UUID, name string) *domain.PullRequest {
+	return &domain.PullRequest{
+		PullRequestID:   uuid.New(),
+		PullRequestName: name,
+		AuthorID:        authorID,
+		Status:          domain.StatusOpen,
+	}
+}
+
+func testCreateTeam(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "bob", "alice")
+
+	exists, err := repo.TeamExists(ctx, "backend")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	team, err := repo.GetTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", team.TeamName)
+	require.Len(t, team.Members, 2)
+	assert.Equal(t, "alice", team.Members[0].Username)
+	assert.Equal(t, ids[1], team.Members[0].UserID)
+	assert.Equal(t, "bob", team.Members[1].Username)
+
+	members, err := repo.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "alice", members[0].Username)
+	assert.Equal(t, "backend", members[0].TeamName)
+	assert.True(t, members[0].IsActive)
+}
+
+func testCreateTeamDuplicate(t *testing.T, repo repository.RepositoryInterface) {
+	Fixture(t, repo, "backend", "alice")
+
+	err := repo.CreateTeam(context.Background(), &domain.Team{
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: uuid.New(), Username: "bob", IsActive: true}},
+	})
+	assert.EqualError(t, err, "TEAM_EXISTS")
+}
+
+func testCreateTeamMovesExistingUser(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice")
+
+	err := repo.CreateTeam(ctx, &domain.Team{
+		TeamName: "frontend",
+		Members:  []domain.TeamMember{{UserID: ids[0], Username: "alice2", IsActive: false}},
+	})
+	require.NoError(t, err)
+
+	user, err := repo.GetUserByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName)
+	assert.Equal(t, "alice2", user.Username)
+	assert.False(t, user.IsActive)
+
+	members, err := repo.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func testGetTeamNotFound(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+
+	_, err := repo.GetTeamByName(ctx, "missing")
+	assert.EqualError(t, err, "TEAM_NOT_FOUND")
+
+	exists, err := repo.TeamExists(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	members, err := repo.GetTeamMembers(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func testListTeams(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+
+	teams, err := repo.ListTeams(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+
+	frontend := Fixture(t, repo, "frontend", "carol")
+	backend := Fixture(t, repo, "backend", "bob", "alice")
+	// Единственный участник frontend переезжает, команда остаётся пустой.
+	require.NoError(t, repo.UpsertUser(ctx, &domain.User{UserID: frontend[0], Username: "carol", TeamName: "backend", IsActive: false}))
+	require.NoError(t, repo.SetUserSeniority(ctx, backend[1], domain.SenioritySenior))
+	rule := &domain.SeniorityRule{Level: domain.SeniorityMiddle, Count: 1}
+	require.NoError(t, repo.SetSeniorityRule(ctx, "backend", rule))
+
+	teams, err = repo.ListTeams(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Team{
+		{TeamName: "backend", SeniorityRule: rule, Members: []domain.TeamMember{
+			{UserID: backend[1], Username: "alice", IsActive: true, Seniority: domain.SenioritySenior},
+			{UserID: backend[0], Username: "bob", IsActive: true},
+			{UserID: frontend[0], Username: "carol", IsActive: false},
+		}},
+		{TeamName: "frontend", Members: []domain.TeamMember{}},
+	}, teams)
+}
+
+func testImportTeams(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob")
+	newUser := uuid.New()
+
+	rule := &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 1}
+	err := repo.ImportTeams(ctx, []domain.Team{
+		{TeamName: "backend", SeniorityRule: rule, Members: []domain.TeamMember{
+			{UserID: ids[0], Username: "alice", IsActive: false, Seniority: domain.SeniorityMiddle},
+		}},
+		{TeamName: "platform", SeniorityRule: rule, Members: []domain.TeamMember{
+			{UserID: ids[1], Username: "bob", IsActive: true},
+			{UserID: newUser, Username: "dave", IsActive: true, Seniority: domain.SenioritySenior},
+		}},
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"backend", "platform"} {
+		got, err := repo.GetSeniorityRule(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, rule, got, name)
+	}
+
+	alice, err := repo.GetUserByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, "backend", alice.TeamName)
+	assert.False(t, alice.IsActive)
+
+	bob, err := repo.GetUserByID(ctx, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, "platform", bob.TeamName)
+
+	members, err := repo.GetTeamMembers(ctx, "platform")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "dave", members[1].Username)
+	assert.Equal(t, domain.SenioritySenior, members[1].Seniority)
+
+	// Без уровня и правила повторный импорт их не сбрасывает.
+	require.NoError(t, repo.ImportTeams(ctx, []domain.Team{
+		{TeamName: "backend", Members: []domain.TeamMember{
+			{UserID: ids[0], Username: "alice", IsActive: true},
+		}},
+	}))
+	alice, err = repo.GetUserByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, domain.SeniorityMiddle, alice.Seniority)
+	got, err := repo.GetSeniorityRule(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, rule, got)
+}
+
+func testUpsertUser(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	Fixture(t, repo, "backend", "alice")
+
+	userID := uuid.New()
+	require.NoError(t, repo.UpsertUser(ctx, &domain.User{UserID: userID, Username: "carol", TeamName: "backend", IsActive: true}))
+	require.NoError(t, repo.UpsertUser(ctx, &domain.User{UserID: userID, Username: "carol-renamed", TeamName: "backend", IsActive: false}))
+
+	user, err := repo.GetUserByID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "carol-renamed", user.Username)
+	assert.False(t, user.IsActive)
+
+	err = repo.UpsertUser(ctx, &domain.User{UserID: uuid.New(), Username: "dave", TeamName: "missing", IsActive: true})
+	assert.Error(t, err)
+}
+
+func testGetUserNotFound(t *testing.T, repo repository.RepositoryInterface) {
+	_, err := repo.GetUserByID(context.Background(), uuid.New())
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+}
+
+func testSetUserActive(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice")
+
+	require.NoError(t, repo.SetUserActive(ctx, ids[0], false))
+
+	user, err := repo.GetUserByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	assert.EqualError(t, repo.SetUserActive(ctx, uuid.New(), true), "USER_NOT_FOUND")
+}
+
+// testUserSeniority уровень задаётся при создании команды и отдельно;
+// повторное добавление без уровня и анонимизация его сохраняют.
+func testUserSeniority(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: alice, Username: "alice", IsActive: true, Seniority: domain.SenioritySenior},
+			{UserID: bob, Username: "bob", IsActive: true},
+		},
+	}))
+
+	team, err := repo.GetTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 2)
+	assert.Equal(t, domain.SenioritySenior, team.Members[0].Seniority)
+	assert.Empty(t, team.Members[1].Seniority)
+
+	require.NoError(t, repo.SetUserSeniority(ctx, bob, domain.SeniorityJunior))
+	members, err := repo.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, domain.SenioritySenior, members[0].Seniority)
+	assert.Equal(t, domain.SeniorityJunior, members[1].Seniority)
+
+	require.NoError(t, repo.ImportTeams(ctx, []domain.Team{{
+		TeamName: "platform",
+		Members:  []domain.TeamMember{{UserID: alice, Username: "alice", IsActive: true}},
+	}}))
+	user, err := repo.GetUserByID(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, "platform", user.TeamName)
+	assert.Equal(t, domain.SenioritySenior, user.Seniority)
+
+	newID := uuid.New()
+	require.NoError(t, repo.AnonymizeUser(ctx, alice, newID, "anonymous-1"))
+	user, err = repo.GetUserByID(ctx, newID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SenioritySenior, user.Seniority)
+
+	assert.EqualError(t, repo.SetUserSeniority(ctx, uuid.New(), domain.SenioritySenior), "USER_NOT_FOUND")
+}
+
+func testSeniorityRule(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	rule := &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 1}
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{
+		TeamName:      "backend",
+		Members:       []domain.TeamMember{{UserID: uuid.New(), Username: "alice", IsActive: true}},
+		SeniorityRule: rule,
+	}))
+	Fixture(t, repo, "platform", "bob")
+
+	got, err := repo.GetSeniorityRule(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, rule, got)
+
+	team, err := repo.GetTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, rule, team.SeniorityRule)
+
+	got, err = repo.GetSeniorityRule(ctx, "platform")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	updated := &domain.SeniorityRule{Level: domain.SeniorityMiddle, Count: 2}
+	require.NoError(t, repo.SetSeniorityRule(ctx, "platform", updated))
+	got, err = repo.GetSeniorityRule(ctx, "platform")
+	require.NoError(t, err)
+	assert.Equal(t, updated, got)
+
+	require.NoError(t, repo.SetSeniorityRule(ctx, "backend", nil))
+	got, err = repo.GetSeniorityRule(ctx, "backend")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = repo.GetSeniorityRule(ctx, "missing")
+	assert.EqualError(t, err, "TEAM_NOT_FOUND")
+	assert.EqualError(t, repo.SetSeniorityRule(ctx, "missing", rule), "TEAM_NOT_FOUND")
+}
+
+// testRemoveUser удалённый пользователь пропадает из команд и кандидатов,
+// но его PR и статистика назначений сохраняются.
+func testRemoveUser(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+
+	pr := newPR(ids[0], "Before removal")
+	require.NoError(t, repo.CreatePR(ctx, pr, []uuid.UUID{ids[1]}))
+
+	// Ревьювера открытого PR удалять нельзя: сервис сначала переназначает.
+	assert.EqualError(t, repo.RemoveUser(ctx, ids[1]), "USER_HAS_OPEN_REVIEWS")
+	_, err := repo.GetUserByID(ctx, ids[1])
+	require.NoError(t, err)
+	mergedAt := time.Now()
+	require.NoError(t, repo.UpdatePRStatus(ctx, pr.PullRequestID, domain.StatusMerged, &mergedAt))
+
+	require.NoError(t, repo.RemoveUser(ctx, ids[1]))
+	assert.EqualError(t, repo.RemoveUser(ctx, ids[1]), "USER_NOT_FOUND")
+	assert.EqualError(t, repo.RemoveUser(ctx, uuid.New()), "USER_NOT_FOUND")
+
+	_, err = repo.GetUserByID(ctx, ids[1])
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+	assert.EqualError(t, repo.SetUserActive(ctx, ids[1], true), "USER_NOT_FOUND")
+
+	team, err := repo.GetTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 2)
+	assert.Equal(t, "alice", team.Members[0].Username)
+	assert.Equal(t, "carol", team.Members[1].Username)
+
+	teams, err := repo.ListTeams(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Len(t, teams[0].Members, 2)
+
+	members, err := repo.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	// Удалённого нельзя назначить ни при создании PR, ни при замене.
+	assert.Error(t, repo.CreatePR(ctx, newPR(ids[0], "After removal"), []uuid.UUID{ids[1]}))
+	assert.Error(t, repo.CreatePR(ctx, newPR(ids[1], "By removed"), nil))
+	other := newPR(ids[0], "Reviewed by carol")
+	require.NoError(t, repo.CreatePR(ctx, other, []uuid.UUID{ids[2]}))
+	assert.Error(t, repo.ReplaceReviewer(ctx, other.PullRequestID, ids[2], ids[1]))
+
+	// История остаётся: PR, назначение и статистика.
+	got, err := repo.GetPRByID(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{ids[1]}, got.AssignedReviewers)
+
+	prs, err := repo.GetPRsByReviewer(ctx, ids[1])
+	require.NoError(t, err)
+	assert.Len(t, prs, 1)
+
+	userStats, err := repo.GetUserAssignmentStats(ctx)
+	require.NoError(t, err)
+	require.Len(t, userStats, 3)
+	for _, stat := range userStats {
+		if stat.UserID == ids[1] {
+			assert.Equal(t, 1, stat.TotalAssignments)
+			assert.Equal(t, 1, stat.MergedAssignments)
+		}
+	}
+
+	totalUsers, err := repo.GetTotalUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, totalUsers)
+
+	activeUsers, err := repo.GetActiveUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, activeUsers)
+
+	// Повторное добавление в команду не восстанавливает пользователя молча.
+	err = repo.UpsertUser(ctx, &domain.User{UserID: ids[1], Username: "bob", TeamName: "backend", IsActive: true})
+	assert.EqualError(t, err, "USER_REMOVED")
+	err = repo.CreateTeam(ctx, &domain.Team{
+		TeamName: "frontend",
+		Members:  []domain.TeamMember{{UserID: uuid.New(), Username: "dave", IsActive: true}, {UserID: ids[1], Username: "bob", IsActive: true}},
+	})
+	assert.EqualError(t, err, "USER_REMOVED")
+	err = repo.ImportTeams(ctx, []domain.Team{
+		{TeamName: "platform", Members: []domain.TeamMember{{UserID: uuid.New(), Username: "erin", IsActive: true}}},
+		{TeamName: "backend", Members: []domain.TeamMember{{UserID: ids[1], Username: "bob", IsActive: true}}},
+	})
+	assert.EqualError(t, err, "USER_REMOVED")
+
+	_, err = repo.GetUserByID(ctx, ids[1])
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+	for _, name := range []string{"frontend", "platform"} {
+		exists, err := repo.TeamExists(ctx, name)
+		require.NoError(t, err)
+		assert.False(t, exists, "failed %s is rolled back", name)
+	}
+
+	members, err = repo.GetTeamMembers(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+}
+
+func testGetUserData(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+
+	authored := newPR(ids[0], "By alice")
+	require.NoError(t, repo.CreatePR(ctx, authored, []uuid.UUID{ids[1]}))
+	reviewed := newPR(ids[1], "By bob")
+	require.NoError(t, repo.CreatePR(ctx, reviewed, []uuid.UUID{ids[0], ids[2]}))
+
+	data, err := repo.GetUserData(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserProfile{UserID: ids[0], Username: "alice", TeamName: "backend", IsActive: true}, data.Profile)
+	require.Len(t, data.AuthoredPRs, 1)
+	assert.Equal(t, authored.PullRequestID, data.AuthoredPRs[0].PullRequestID)
+	require.Len(t, data.Reviews, 1)
+	assert.Equal(t, reviewed.PullRequestID, data.Reviews[0].PullRequestID)
+
+	// Данные удалённого пользователя тоже выгружаются.
+	mergedAt := time.Now()
+	require.NoError(t, repo.UpdatePRStatus(ctx, reviewed.PullRequestID, domain.StatusMerged, &mergedAt))
+	require.NoError(t, repo.RemoveUser(ctx, ids[0]))
+	data, err = repo.GetUserData(ctx, ids[0])
+	require.NoError(t, err)
+	assert.False(t, data.Profile.IsActive)
+	require.NotNil(t, data.Profile.RemovedAt)
+	assert.Len(t, data.AuthoredPRs, 1)
+
+	_, err = repo.GetUserData(ctx, uuid.New())
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+	_, err = repo.GetUserData(tenant.WithOrg(ctx, OtherOrgID), ids[1])
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+}
+
+// testAnonymizeUser после обезличивания старого ID нет нигде, а
+// статистика назначений та же под новым ID и псевдонимом.
+func testAnonymizeUser(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+
+	authored := newPR(ids[0], "By alice")
+	require.NoError(t, repo.CreatePR(ctx, authored, []uuid.UUID{ids[1], ids[2]}))
+	reviewed := newPR(ids[1], "By bob")
+	require.NoError(t, repo.CreatePR(ctx, reviewed, []uuid.UUID{ids[0]}))
+	mergedAt := time.Now()
+	require.NoError(t, repo.UpdatePRStatus(ctx, reviewed.PullRequestID, domain.StatusMerged, &mergedAt))
+
+	before, err := repo.GetUserAssignmentStats(ctx)
+	require.NoError(t, err)
+
+	assert.EqualError(t, repo.AnonymizeUser(tenant.WithOrg(ctx, OtherOrgID), ids[0], uuid.New(), "anonymous"), "USER_NOT_FOUND")
+	assert.EqualError(t, repo.AnonymizeUser(ctx, uuid.New(), uuid.New(), "anonymous"), "USER_NOT_FOUND")
+	assert.EqualError(t, repo.AnonymizeUser(ctx, ids[0], ids[1], "anonymous"), "USER_EXISTS")
+
+	newID := uuid.New()
+	require.NoError(t, repo.AnonymizeUser(ctx, ids[0], newID, "anonymous-1"))
+
+	_, err = repo.GetUserData(ctx, ids[0])
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+	prs, err := repo.GetPRsByReviewer(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+
+	data, err := repo.GetUserData(ctx, newID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserProfile{UserID: newID, Username: "anonymous-1", TeamName: "backend", IsActive: true}, data.Profile)
+	require.Len(t, data.AuthoredPRs, 1)
+	assert.Equal(t, newID, data.AuthoredPRs[0].AuthorID)
+	require.Len(t, data.Reviews, 1)
+	assert.Equal(t, reviewed.PullRequestID, data.Reviews[0].PullRequestID)
+
+	team, err := repo.GetTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 3)
+	assert.Equal(t, "anonymous-1", team.Members[0].Username)
+
+	after, err := repo.GetUserAssignmentStats(ctx)
+	require.NoError(t, err)
+	require.Len(t, after, len(before))
+	for i := range before {
+		if before[i].UserID == ids[0] {
+			before[i].UserID = newID
+			before[i].Username = "anonymous-1"
+		}
+	}
+	assert.ElementsMatch(t, before, after)
+}
+
+// testAnonymousID повторный вызов возвращает тот же ID, пока пользователь
+// не обезличен.
+func testAnonymousID(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob")
+
+	newID, err := repo.AnonymousID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, newID)
+	again, err := repo.AnonymousID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, newID, again)
+
+	bobID, err := repo.AnonymousID(ctx, ids[1])
+	require.NoError(t, err)
+	assert.NotEqual(t, newID, bobID)
+
+	_, err = repo.AnonymousID(tenant.WithOrg(ctx, OtherOrgID), ids[0])
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+	_, err = repo.AnonymousID(ctx, uuid.New())
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+
+	require.NoError(t, repo.AnonymizeUser(ctx, ids[0], newID, "anonymous-1"))
+	_, err = repo.AnonymousID(ctx, ids[0])
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+	next, err := repo.AnonymousID(ctx, newID)
+	require.NoError(t, err)
+	assert.NotEqual(t, newID, next)
+}
+
+func testCreatePR(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+
+	pr := newPR(ids[0], "Add feature")
+	require.NoError(t, repo.CreatePR(ctx, pr, []uuid.UUID{ids[1], ids[2]}))
+
+	exists, err := repo.PRExists(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	got, err := repo.GetPRByID(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, pr.PullRequestID, got.PullRequestID)
+	assert.Equal(t, "Add feature", got.PullRequestName)
+	assert.Equal(t, ids[0], got.AuthorID)
+	assert.Equal(t, domain.StatusOpen, got.Status)
+	assert.ElementsMatch(t, []uuid.UUID{ids[1], ids[2]}, got.AssignedReviewers)
+	assert.False(t, got.CreatedAt.IsZero())
+	assert.Nil(t, got.MergedAt)
+
+	reviewers, err := repo.GetReviewersByPR(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{ids[1], ids[2]}, reviewers)
+
+	err = repo.CreatePR(ctx, newPR(uuid.New(), "Unknown author"), nil)
+	assert.Error(t, err)
+}
+
+func testCreatePRDuplicate(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice")
+
+	pr := newPR(ids[0], "Add feature")
+	require.NoError(t, repo.CreatePR(ctx, pr, nil))
+	assert.EqualError(t, repo.CreatePR(ctx, pr, nil), "PR_EXISTS")
+}
+
+func testGetPRNotFound(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+
+	_, err := repo.GetPRByID(ctx, uuid.New())
+	assert.EqualError(t, err, "PR_NOT_FOUND")
+
+	exists, err := repo.PRExists(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func testUpdatePRStatus(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice")
+
+	pr := newPR(ids[0], "Add feature")
+	require.NoError(t, repo.CreatePR(ctx, pr, nil))
+
+	mergedAt := time.Now().Add(time.Hour)
+	require.NoError(t, repo.UpdatePRStatus(ctx, pr.PullRequestID, domain.StatusMerged, &mergedAt))
+
+	got, err := repo.GetPRByID(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusMerged, got.Status)
+	require.NotNil(t, got.MergedAt)
+	assert.WithinDuration(t, mergedAt, *got.MergedAt, time.Second)
+}
+
+func testReplaceReviewer(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol", "dave")
+
+	pr := newPR(ids[0], "Add feature")
+	require.NoError(t, repo.CreatePR(ctx, pr, []uuid.UUID{ids[1], ids[2]}))
+
+	require.NoError(t, repo.ReplaceReviewer(ctx, pr.PullRequestID, ids[1], ids[3]))
+
+	reviewers, err := repo.GetReviewersByPR(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{ids[3], ids[2]}, reviewers)
+
+	err = repo.ReplaceReviewer(ctx, pr.PullRequestID, ids[1], ids[3])
+	assert.EqualError(t, err, "REVIEWER_NOT_FOUND")
+
+	err = repo.ReplaceReviewer(ctx, pr.PullRequestID, ids[2], ids[3])
+	assert.Error(t, err)
+}
+
+func testInactiveReviewer(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+	require.NoError(t, repo.SetUserActive(ctx, ids[2], false))
+
+	err := repo.CreatePR(ctx, newPR(ids[0], "Inactive"), []uuid.UUID{ids[2]})
+	assert.EqualError(t, err, "REVIEWER_INACTIVE")
+
+	pr := newPR(ids[0], "Add feature")
+	require.NoError(t, repo.CreatePR(ctx, pr, []uuid.UUID{ids[1]}))
+
+	err = repo.ReplaceReviewer(ctx, pr.PullRequestID, ids[1], ids[2])
+	assert.EqualError(t, err, "REVIEWER_INACTIVE")
+
+	reviewers, err := repo.GetReviewersByPR(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{ids[1]}, reviewers)
+}
+
+func testGetPRsByReviewer(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+
+	first := newPR(ids[0], "First")
+	require.NoError(t, repo.CreatePR(ctx, first, []uuid.UUID{ids[1]}))
+	time.Sleep(10 * time.Millisecond)
+	second := newPR(ids[0], "Second")
+	require.NoError(t, repo.CreatePR(ctx, second, []uuid.UUID{ids[1], ids[2]}))
+
+	prs, err := repo.GetPRsByReviewer(ctx, ids[1])
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, second.PullRequestID, prs[0].PullRequestID)
+	assert.Equal(t, first.PullRequestID, prs[1].PullRequestID)
+	assert.Equal(t, ids[0], prs[0].AuthorID)
+	assert.Equal(t, domain.StatusOpen, prs[0].Status)
+	assert.False(t, prs[1].CreatedAt.IsZero())
+	assert.False(t, prs[0].CreatedAt.Before(prs[1].CreatedAt))
+
+	prs, err = repo.GetPRsByReviewer(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+}
+
+func testStats(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+	Fixture(t, repo, "frontend", "dave")
+
+	open := newPR(ids[0], "Open")
+	require.NoError(t, repo.CreatePR(ctx, open, []uuid.UUID{ids[1], ids[2]}))
+	require.NoError(t, repo.SetUserActive(ctx, ids[2], false))
+	merged := newPR(ids[0], "Merged")
+	require.NoError(t, repo.CreatePR(ctx, merged, []uuid.UUID{ids[1]}))
+	mergedAt := time.Now()
+	require.NoError(t, repo.UpdatePRStatus(ctx, merged.PullRequestID, domain.StatusMerged, &mergedAt))
+
+	totalUsers, err := repo.GetTotalUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, totalUsers)
+
+	totalTeams, err := repo.GetTotalTeams(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, totalTeams)
+
+	activeUsers, err := repo.GetActiveUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, activeUsers)
+
+	prStats, err := repo.GetPRStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, prStats.TotalPRs)
+	assert.Equal(t, 1, prStats.TotalOpen)
+	assert.Equal(t, 1, prStats.TotalMerged)
+	assert.GreaterOrEqual(t, prStats.AvgMergeTimeHours, 0.0)
+
+	userStats, err := repo.GetUserAssignmentStats(ctx)
+	require.NoError(t, err)
+	require.Len(t, userStats, 4)
+	assert.Equal(t, ids[1], userStats[0].UserID)
+	assert.Equal(t, 2, userStats[0].TotalAssignments)
+	assert.Equal(t, 1, userStats[0].OpenAssignments)
+	assert.Equal(t, 1, userStats[0].MergedAssignments)
+	assert.Equal(t, "backend", userStats[0].TeamName)
+}
+
+// testTenantReadsAreIsolated организация не видит команды, пользователей,
+// PR и статистику другой, даже при совпадающих именах команд.
+func testTenantReadsAreIsolated(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	other := tenant.WithOrg(ctx, OtherOrgID)
+
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+	otherIDs := fixtureIn(t, other, repo, "backend", "dave", "erin")
+	Fixture(t, repo, "frontend", "frank")
+
+	pr := newPR(ids[0], "Add feature")
+	require.NoError(t, repo.CreatePR(ctx, pr, []uuid.UUID{ids[1], ids[2]}))
+
+	team, err := repo.GetTeamByName(other, "backend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 2)
+	assert.Equal(t, otherIDs[0], team.Members[0].UserID)
+	assert.Equal(t, otherIDs[1], team.Members[1].UserID)
+
+	_, err = repo.GetTeamByName(other, "frontend")
+	assert.EqualError(t, err, "TEAM_NOT_FOUND")
+	exists, err := repo.TeamExists(other, "frontend")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	teams, err := repo.ListTeams(other)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Len(t, teams[0].Members, 2)
+
+	members, err := repo.GetTeamMembers(other, "backend")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	_, err = repo.GetUserByID(other, ids[0])
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+	_, err = repo.GetUserByID(ctx, otherIDs[0])
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+
+	_, err = repo.GetPRByID(other, pr.PullRequestID)
+	assert.EqualError(t, err, "PR_NOT_FOUND")
+	exists, err = repo.PRExists(other, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	reviewers, err := repo.GetReviewersByPR(other, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.Empty(t, reviewers)
+	prs, err := repo.GetPRsByReviewer(other, ids[1])
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+
+	totalUsers, err := repo.GetTotalUsers(other)
+	require.NoError(t, err)
+	assert.Equal(t, 2, totalUsers)
+	totalTeams, err := repo.GetTotalTeams(other)
+	require.NoError(t, err)
+	assert.Equal(t, 1, totalTeams)
+	activeUsers, err := repo.GetActiveUsers(other)
+	require.NoError(t, err)
+	assert.Equal(t, 2, activeUsers)
+	prStats, err := repo.GetPRStats(other)
+	require.NoError(t, err)
+	assert.Zero(t, prStats.TotalPRs)
+	userStats, err := repo.GetUserAssignmentStats(other)
+	require.NoError(t, err)
+	assert.Len(t, userStats, 2)
+
+	totalUsers, err = repo.GetTotalUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, totalUsers)
+}
+
+// testTenantWritesAreIsolated изменения по ID чужих пользователей и PR не
+// применяются, а переназначить ревью на пользователя другой организации нельзя.
+func testTenantWritesAreIsolated(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	other := tenant.WithOrg(ctx, OtherOrgID)
+
+	ids := Fixture(t, repo, "backend", "alice", "bob", "carol")
+	otherIDs := fixtureIn(t, other, repo, "backend", "dave", "erin")
+
+	pr := newPR(ids[0], "Add feature")
+	require.NoError(t, repo.CreatePR(ctx, pr, []uuid.UUID{ids[1]}))
+
+	err := repo.SetUserActive(other, ids[1], false)
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+
+	err = repo.ReplaceReviewer(other, pr.PullRequestID, ids[1], otherIDs[0])
+	assert.Error(t, err)
+	err = repo.ReplaceReviewer(ctx, pr.PullRequestID, ids[1], otherIDs[0])
+	assert.Error(t, err)
+
+	mergedAt := time.Now()
+	require.NoError(t, repo.UpdatePRStatus(other, pr.PullRequestID, domain.StatusMerged, &mergedAt))
+
+	// Автор из другой организации: PR не создаётся.
+	assert.Error(t, repo.CreatePR(other, newPR(ids[0], "Foreign author"), []uuid.UUID{otherIDs[0]}))
+
+	got, err := repo.GetPRByID(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOpen, got.Status)
+	assert.Nil(t, got.MergedAt)
+	assert.Equal(t, []uuid.UUID{ids[1]}, got.AssignedReviewers)
+
+	user, err := repo.GetUserByID(ctx, ids[1])
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+}
+
+// testTenantIDsAreIndependent ID пользователей и PR уникальны в пределах
+// организации: тот же ID в другой организации — отдельная запись, и по
+// ошибке нельзя узнать, что он занят у соседей.
+func testTenantIDsAreIndependent(t *testing.T, repo repository.RepositoryInterface) {
+	ctx := context.Background()
+	other := tenant.WithOrg(ctx, OtherOrgID)
+
+	ids := Fixture(t, repo, "backend", "alice", "bob")
+
+	require.NoError(t, repo.CreateTeam(other, &domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: ids[0], Username: "mallory", IsActive: false},
+			{UserID: ids[1], Username: "trent", IsActive: true},
+		},
+	}))
+	require.NoError(t, repo.UpsertUser(other, &domain.User{UserID: ids[0], Username: "mallory", TeamName: "backend", IsActive: true}))
+	require.NoError(t, repo.ImportTeams(other, []domain.Team{{
+		TeamName: "platform",
+		Members:  []domain.TeamMember{{UserID: ids[0], Username: "mallory", IsActive: true}},
+	}}))
+
+	user, err := repo.GetUserByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, domain.User{UserID: ids[0], Username: "alice", TeamName: "backend", IsActive: true}, *user)
+	user, err = repo.GetUserByID(other, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, domain.User{UserID: ids[0], Username: "mallory", TeamName: "platform", IsActive: true}, *user)
+
+	pr := newPR(ids[0], "Add search")
+	require.NoError(t, repo.CreatePR(ctx, pr, []uuid.UUID{ids[1]}))
+	foreign := *pr
+	foreign.PullRequestName = "Shadow"
+	require.NoError(t, repo.CreatePR(other, &foreign, nil))
+	assert.EqualError(t, repo.CreatePR(other, &foreign, nil), "PR_EXISTS")
+
+	got, err := repo.GetPRByID(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, "Add search", got.PullRequestName)
+	assert.Equal(t, []uuid.UUID{ids[1]}, got.AssignedReviewers)
+	got, err = repo.GetPRByID(other, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, "Shadow", got.PullRequestName)
+	assert.Empty(t, got.AssignedReviewers)
+
+	prs, err := repo.GetPRsByReviewer(other, ids[1])
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// SQLiteRepository реализация RepositoryInterface поверх SQLite.
+// Большинство запросов переносимы и берутся из Repository, переопределены
+// только методы с PostgreSQL-специфичным SQL.
+type SQLiteRepository struct {
+	*Repository
+}
+
+func NewSQLiteRepository(db *sql.DB) *SQLiteRepository {
+	return &SQLiteRepository{
+		Repository: &Repository{db: db, isUniqueViolation: isSQLiteUniqueViolation},
+	}
+}
+
+// isSQLiteUniqueViolation аналог проверки кода 23505 для SQLite.
+func isSQLiteUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+}
+
+// GetPRStats возвращает общую статистику по PR.
+// В SQLite нет EXTRACT(EPOCH ...), разница времени считается через julianday.
+func (r *SQLiteRepository) GetPRStats(ctx context.Context) (*domain.PRStats, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'open') as total_open,
+			COUNT(*) FILTER (WHERE status = 'merged') as total_merged,
+			COUNT(*) as total_prs,
+			COALESCE(AVG((julianday(merged_at) - julianday(created_at)) * 24) FILTER (WHERE merged_at IS NOT NULL), 0) as avg_merge_time_hours
+		FROM pull_requests
+		WHERE org_id = $1
+	`
+
+	var stats domain.PRStats
+	err := r.db.QueryRowContext(ctx, query, tenant.OrgID(ctx)).Scan(
+		&stats.TotalOpen,
+		&stats.TotalMerged,
+		&stats.TotalPRs,
+		&stats.AvgMergeTimeHours,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// ========================================
+// Compile-time interface check
+// ========================================
+
+var _ RepositoryInterface = (*SQLiteRepository)(nil)
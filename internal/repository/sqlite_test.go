@@ -0,0 +1,26 @@
+package repository_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/repository/repotest"
+)
+
+func TestSQLiteRepository_Contract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.RepositoryInterface {
+		cfg := &config.Config{DatabaseURL: "sqlite://" + filepath.Join(t.TempDir(), "test.db")}
+
+		db, err := cfg.ConnectDB()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		require.NoError(t, cfg.RunMigrations(db.DB))
+		repotest.SeedOrganizations(t, db.DB)
+		return repository.NewSQLiteRepository(db.DB)
+	})
+}
@@ -0,0 +1,300 @@
+package roster
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+)
+
+// Format формат файла состава команд.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+)
+
+// csvHeader колонки CSV. is_active необязательна, пустое значение — true;
+// seniority необязательна, пустое значение не меняет уровень. Правило
+// команды в CSV не задаётся.
+var csvHeader = []string{"team_name", "user_id", "username", "is_active", "seniority"}
+
+// ParseFormat разбирает имя формата из query параметра или флага.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatJSON, FormatYAML, FormatCSV:
+		return f, nil
+	case "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, must be json, yaml or csv", s)
+	}
+}
+
+// FormatFromContentType определяет формат по заголовку Content-Type.
+func FormatFromContentType(contentType string) (Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case "application/json":
+		return FormatJSON, true
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return FormatYAML, true
+	case "text/csv":
+		return FormatCSV, true
+	default:
+		return "", false
+	}
+}
+
+// ContentType возвращает MIME тип формата.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatYAML:
+		return "application/yaml"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+// Record одна строка состава — участник команды в том виде, в каком он
+// записан в файле. Проверяется в Service.Import.
+type Record struct {
+	// Location позиция в файле для сообщений об ошибках: "line 3" для CSV,
+	// "teams[0].members[2]" для JSON и YAML.
+	Location string
+	TeamName string
+	UserID   string
+	Username string
+	IsActive bool
+	// Seniority уровень, пустая строка не меняет заданный ранее.
+	Seniority string
+	// SeniorityRule правило команды записи, nil не меняет правило.
+	SeniorityRule *domain.SeniorityRule
+
+	// err ошибка разбора строки, например некорректный is_active.
+	err error
+	// noMembers команда в JSON/YAML объявлена без участников.
+	noMembers bool
+}
+
+// document структура JSON и YAML файлов, совпадает с телом POST /team/add.
+type document struct {
+	Teams []documentTeam `json:"teams" yaml:"teams"`
+}
+
+type documentTeam struct {
+	TeamName      string           `json:"team_name" yaml:"team_name"`
+	Members       []documentMember `json:"members" yaml:"members"`
+	SeniorityRule *documentRule    `json:"seniority_rule,omitempty" yaml:"seniority_rule,omitempty"`
+}
+
+type documentMember struct {
+	UserID   string `json:"user_id" yaml:"user_id"`
+	Username string `json:"username" yaml:"username"`
+	// IsActive по умолчанию true.
+	IsActive  *bool  `json:"is_active,omitempty" yaml:"is_active,omitempty"`
+	Seniority string `json:"seniority,omitempty" yaml:"seniority,omitempty"`
+}
+
+type documentRule struct {
+	Level string `json:"level" yaml:"level"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// Decode читает записи состава в заданном формате.
+func Decode(f Format, r io.Reader) ([]Record, error) {
+	switch f {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatYAML:
+		var doc document
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+		return doc.records(), nil
+	case FormatJSON:
+		var doc document
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return doc.records(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", f)
+	}
+}
+
+func (d document) records() []Record {
+	var records []Record
+	for i, team := range d.Teams {
+		var rule *domain.SeniorityRule
+		if team.SeniorityRule != nil {
+			rule = &domain.SeniorityRule{Level: team.SeniorityRule.Level, Count: team.SeniorityRule.Count}
+		}
+		if len(team.Members) == 0 {
+			records = append(records, Record{
+				Location:  fmt.Sprintf("teams[%d]", i),
+				TeamName:  team.TeamName,
+				noMembers: true,
+			})
+			continue
+		}
+
+		for j, m := range team.Members {
+			isActive := true
+			if m.IsActive != nil {
+				isActive = *m.IsActive
+			}
+			records = append(records, Record{
+				Location:      fmt.Sprintf("teams[%d].members[%d]", i, j),
+				TeamName:      team.TeamName,
+				UserID:        m.UserID,
+				Username:      m.Username,
+				IsActive:      isActive,
+				Seniority:     m.Seniority,
+				SeniorityRule: rule,
+			})
+		}
+	}
+	return records
+}
+
+func decodeCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvHeader[:3] {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("invalid CSV: missing column %q, header must be %s", name, strings.Join(csvHeader, ","))
+		}
+	}
+
+	var records []Record
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		rec := Record{
+			Location:  fmt.Sprintf("line %d", line),
+			TeamName:  field("team_name"),
+			UserID:    field("user_id"),
+			Username:  field("username"),
+			IsActive:  true,
+			Seniority: field("seniority"),
+		}
+		if v := field("is_active"); v != "" {
+			rec.IsActive, err = strconv.ParseBool(v)
+			if err != nil {
+				rec.err = fmt.Errorf("is_active must be true or false, got %q", v)
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// Encode записывает составы команд в заданном формате.
+func Encode(f Format, w io.Writer, teams []domain.Team) error {
+	switch f {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, team := range teams {
+			for _, m := range team.Members {
+				if err := cw.Write([]string{team.TeamName, m.UserID.String(), m.Username, strconv.FormatBool(m.IsActive), m.Seniority}); err != nil {
+					return err
+				}
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case FormatYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(newDocument(teams)); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+		_, err := w.Write(buf.Bytes())
+		return err
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(newDocument(teams))
+	default:
+		return fmt.Errorf("unknown format %q", f)
+	}
+}
+
+func newDocument(teams []domain.Team) document {
+	doc := document{Teams: make([]documentTeam, 0, len(teams))}
+	for _, team := range teams {
+		dt := documentTeam{TeamName: team.TeamName, Members: make([]documentMember, 0, len(team.Members))}
+		if team.SeniorityRule != nil {
+			dt.SeniorityRule = &documentRule{Level: team.SeniorityRule.Level, Count: team.SeniorityRule.Count}
+		}
+		for _, m := range team.Members {
+			isActive := m.IsActive
+			dt.Members = append(dt.Members, documentMember{
+				UserID:    m.UserID.String(),
+				Username:  m.Username,
+				IsActive:  &isActive,
+				Seniority: m.Seniority,
+			})
+		}
+		doc.Teams = append(doc.Teams, dt)
+	}
+	return doc
+}
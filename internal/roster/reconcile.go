@@ -0,0 +1,366 @@
+package roster
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+// Действия, которые добавляет только Reconciler.
+const (
+	// ActionReassignReview перенос открытого ревью удаляемого участника.
+	ActionReassignReview Action = "reassign_review"
+	// ActionKeepUser участник удалён из файла, но остаётся активным
+	// из-за открытых ревью.
+	ActionKeepUser Action = "keep_user"
+)
+
+// OpenReviewsPolicy что делать с участником, удалённым из файла,
+// у которого есть открытые ревью.
+type OpenReviewsPolicy string
+
+const (
+	// KeepWithOpenReviews оставить активным до закрытия ревью.
+	KeepWithOpenReviews OpenReviewsPolicy = "keep"
+	// ReassignOpenReviews переназначить ревью и деактивировать.
+	ReassignOpenReviews OpenReviewsPolicy = "reassign"
+)
+
+// ParseOpenReviewsPolicy разбирает значение флага или переменной окружения.
+func ParseOpenReviewsPolicy(s string) (OpenReviewsPolicy, error) {
+	switch p := OpenReviewsPolicy(s); p {
+	case KeepWithOpenReviews, ReassignOpenReviews:
+		return p, nil
+	default:
+		return "", fmt.Errorf("open reviews policy must be keep or reassign, got %q", s)
+	}
+}
+
+// Reconciler приводит составы команд к декларативному описанию.
+// В отличие от импорта, участники, которых нет в файле, деактивируются.
+// Команды, не упомянутые в файле, не затрагиваются.
+type Reconciler struct {
+	roster  *Service
+	repo    repository.RepositoryInterface
+	service service.ServiceInterface
+	policy  OpenReviewsPolicy
+	// heartbeat вызывается на каждой итерации Watch.
+	heartbeat func()
+}
+
+// ReconcilerOption настраивает необязательные зависимости Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithHeartbeat передаёт функцию, которую Watch вызывает на каждой итерации,
+// например для проверки готовности.
+func WithHeartbeat(beat func()) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.heartbeat = beat
+	}
+}
+
+func NewReconciler(repo repository.RepositoryInterface, svc service.ServiceInterface, policy OpenReviewsPolicy, opts ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
+		roster:    NewService(repo),
+		repo:      repo,
+		service:   svc,
+		policy:    policy,
+		heartbeat: func() {},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// removal участник, которого нет в желаемом составе.
+type removal struct {
+	teamName string
+	user     domain.User
+}
+
+// Reconcile строит план и, если dryRun=false, применяет его. Создания,
+// переезды и флаги из файла применяются одной транзакцией, затем
+// удалённые участники по одному: переназначение ревью и деактивация.
+func (r *Reconciler) Reconcile(ctx context.Context, records []Record, dryRun bool) (*Report, error) {
+	teams, rowErrs := r.roster.validate(records)
+	if len(rowErrs) > 0 {
+		return nil, &ValidationError{Rows: rowErrs}
+	}
+
+	report, err := r.roster.plan(ctx, teams)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = dryRun
+
+	removals, err := r.findRemovals(ctx, teams)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun && len(report.Changes) > 0 {
+		if err := r.repo.ImportTeams(ctx, teams); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, rm := range removals {
+		if err := r.remove(ctx, report, rm, dryRun); err != nil {
+			return nil, err
+		}
+	}
+
+	level := slog.LevelDebug
+	if len(report.Changes) > 0 {
+		level = slog.LevelInfo
+	}
+	slog.Log(ctx, level, "Roster reconciled",
+		"dry_run", dryRun,
+		"changes", len(report.Changes),
+		"users_deactivated", report.Summary.UsersDeactivated,
+		"users_kept", report.Summary.UsersKept,
+	)
+	return report, nil
+}
+
+// findRemovals ищет активных участников описанных команд, которых нет в файле.
+func (r *Reconciler) findRemovals(ctx context.Context, teams []domain.Team) ([]removal, error) {
+	desired := make(map[uuid.UUID]bool)
+	for _, team := range teams {
+		for _, m := range team.Members {
+			desired[m.UserID] = true
+		}
+	}
+
+	var removals []removal
+	for _, team := range teams {
+		members, err := r.repo.GetTeamMembers(ctx, team.TeamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get members of team %q: %w", team.TeamName, err)
+		}
+		for _, m := range members {
+			if !desired[m.UserID] && m.IsActive {
+				removals = append(removals, removal{teamName: team.TeamName, user: m})
+			}
+		}
+	}
+	return removals, nil
+}
+
+// remove переназначает открытые ревью участника и деактивирует его.
+// Открытые ревью читаются непосредственно перед действием: их могли
+// передать этому участнику при обработке предыдущего.
+func (r *Reconciler) remove(ctx context.Context, report *Report, rm removal, dryRun bool) error {
+	userID := rm.user.UserID
+	change := func(action Action, reason string) Change {
+		return Change{Action: action, TeamName: rm.teamName, UserID: &userID, Username: rm.user.Username, Reason: reason}
+	}
+
+	open, err := r.openReviews(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(open) > 0 && r.policy != ReassignOpenReviews {
+		report.add(change(ActionKeepUser, fmt.Sprintf("removed from roster but has %d open review(s)", len(open))))
+		return nil
+	}
+
+	var reassigned []Change
+	for _, prID := range open {
+		c := change(ActionReassignReview, "removed from roster")
+		c.PullRequestID = &prID
+
+		if !dryRun {
+			if _, _, err := r.service.ReassignReviewer(ctx, prID, userID); err != nil {
+				// Ревью остаётся за участником, поэтому деактивировать его нельзя.
+				slog.WarnContext(ctx, "Failed to reassign review of removed user",
+					"user_id", userID,
+					"pr_id", prID,
+					"error", err,
+				)
+				for _, done := range reassigned {
+					report.add(done)
+				}
+				report.add(change(ActionKeepUser, fmt.Sprintf("failed to reassign review %s: %v", prID, err)))
+				return nil
+			}
+		}
+		reassigned = append(reassigned, c)
+	}
+
+	if !dryRun {
+		if err := r.repo.SetUserActive(ctx, userID, false); err != nil {
+			return fmt.Errorf("failed to deactivate user %s: %w", userID, err)
+		}
+	}
+
+	for _, c := range reassigned {
+		report.add(c)
+	}
+	report.add(change(ActionDeactivateUser, "removed from roster"))
+	return nil
+}
+
+func (r *Reconciler) openReviews(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	prs, err := r.repo.GetPRsByReviewer(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews of user %s: %w", userID, err)
+	}
+
+	var open []uuid.UUID
+	for _, pr := range prs {
+		if pr.Status == domain.StatusOpen {
+			open = append(open, pr.PullRequestID)
+		}
+	}
+	return open, nil
+}
+
+// ReconcileFile читает файл (формат по расширению) и вызывает Reconcile.
+func (r *Reconciler) ReconcileFile(ctx context.Context, path string, dryRun bool) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roster file: %w", err)
+	}
+
+	records, err := decodeFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return r.Reconcile(ctx, records, dryRun)
+}
+
+// Watch периодически сверяет составы с файлом до отмены ctx. Сверка идёт
+// на каждом тике, а не только при изменении файла: так откатываются и
+// ручные изменения через API.
+func (r *Reconciler) Watch(ctx context.Context, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastSum [sha256.Size]byte
+	for {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to read roster file", "path", path, "error", err)
+		} else {
+			if sum := sha256.Sum256(data); sum != lastSum {
+				slog.InfoContext(ctx, "Roster file changed", "path", path)
+				lastSum = sum
+			}
+			r.reconcileData(ctx, path, data)
+		}
+		r.heartbeat()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reconciler) reconcileData(ctx context.Context, path string, data []byte) {
+	records, err := decodeFile(path, data)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid roster file", "path", path, "error", err)
+		return
+	}
+
+	report, err := r.Reconcile(ctx, records, false)
+	if err != nil {
+		slog.ErrorContext(ctx, "Roster reconciliation failed", "path", path, "error", err)
+		return
+	}
+
+	if len(report.Changes) > 0 {
+		var plan strings.Builder
+		_ = report.WritePlan(&plan)
+		slog.InfoContext(ctx, "Roster changes applied", "path", path, "plan", plan.String())
+	}
+}
+
+// decodeFile определяет формат по расширению, по умолчанию YAML.
+func decodeFile(path string, data []byte) ([]Record, error) {
+	format := FormatYAML
+	if ext := strings.TrimPrefix(filepath.Ext(path), "."); ext != "" {
+		if f, err := ParseFormat(ext); err == nil {
+			format = f
+		}
+	}
+
+	records, err := Decode(format, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return records, nil
+}
+
+// WritePlan выводит изменения в читаемом виде, по одному на строку.
+func (r *Report) WritePlan(w io.Writer) error {
+	if len(r.Changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	for _, c := range r.Changes {
+		var line string
+		switch c.Action {
+		case ActionCreateTeam:
+			line = fmt.Sprintf("+ create team %s", c.TeamName)
+		case ActionCreateUser:
+			line = fmt.Sprintf("+ create user %s (%s) in %s", c.Username, c.UserID, c.TeamName)
+		case ActionMoveUser:
+			line = fmt.Sprintf("~ move user %s (%s) %s -> %s", c.Username, c.UserID, c.FromTeam, c.TeamName)
+		case ActionRenameUser:
+			line = fmt.Sprintf("~ rename user (%s) %s -> %s", c.UserID, c.FromUsername, c.Username)
+		case ActionActivateUser:
+			line = fmt.Sprintf("~ activate user %s (%s) in %s", c.Username, c.UserID, c.TeamName)
+		case ActionDeactivateUser:
+			line = fmt.Sprintf("- deactivate user %s (%s) in %s", c.Username, c.UserID, c.TeamName)
+		case ActionSetSeniority:
+			line = fmt.Sprintf("~ set seniority of %s (%s) %s -> %s", c.Username, c.UserID, orNone(c.FromSeniority), c.Seniority)
+		case ActionSetSeniorityRule:
+			line = fmt.Sprintf("~ set seniority rule of %s: %d %s or above", c.TeamName, c.SeniorityRule.Count, c.SeniorityRule.Level)
+		case ActionReassignReview:
+			line = fmt.Sprintf("~ reassign review %s from %s (%s)", c.PullRequestID, c.Username, c.UserID)
+		case ActionKeepUser:
+			line = fmt.Sprintf("! keep user %s (%s) in %s", c.Username, c.UserID, c.TeamName)
+		default:
+			line = fmt.Sprintf("? %s %s", c.Action, c.TeamName)
+		}
+		if c.Reason != "" {
+			line += ": " + c.Reason
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d team(s) to create, %d user(s) to create, %d to move, %d to deactivate, %d review(s) to reassign, %d user(s) kept.\n",
+		r.Summary.TeamsCreated, r.Summary.UsersCreated, r.Summary.UsersMoved,
+		r.Summary.UsersDeactivated, r.Summary.ReviewsReassigned, r.Summary.UsersKept)
+	return err
+}
+
+// orNone подставляет "none" вместо пустого значения.
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,196 @@
+package roster
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+// reconcileFixture команда backend из четырёх участников и открытый PR
+// автора members[0] с двумя ревьюверами.
+func reconcileFixture(t *testing.T) (*repository.MemoryRepository, *service.ReviewerService, []domain.TeamMember, *domain.PullRequestWithReviewers) {
+	t.Helper()
+	ctx := context.Background()
+
+	repo := repository.NewMemoryRepository()
+	members := []domain.TeamMember{
+		{UserID: uuid.New(), Username: "alice", IsActive: true},
+		{UserID: uuid.New(), Username: "bob", IsActive: true},
+		{UserID: uuid.New(), Username: "carol", IsActive: true},
+		{UserID: uuid.New(), Username: "dave", IsActive: true},
+	}
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "backend", Members: members}))
+
+	svc := service.NewReviewerService(repo)
+	pr, err := svc.CreatePR(ctx, uuid.New(), "Add feature", members[0].UserID)
+	require.NoError(t, err)
+
+	return repo, svc, members, pr
+}
+
+func recordsFor(teamName string, members ...domain.TeamMember) []Record {
+	records := make([]Record, len(members))
+	for i, m := range members {
+		records[i] = Record{Location: "test", TeamName: teamName, UserID: m.UserID.String(), Username: m.Username, IsActive: m.IsActive}
+	}
+	return records
+}
+
+// split делит участников fixture на ревьюверов PR и остальных.
+func split(members []domain.TeamMember, pr *domain.PullRequestWithReviewers) (reviewers, others []domain.TeamMember) {
+	assigned := make(map[uuid.UUID]bool)
+	for _, id := range pr.AssignedReviewers {
+		assigned[id] = true
+	}
+	for _, m := range members {
+		if assigned[m.UserID] {
+			reviewers = append(reviewers, m)
+		} else {
+			others = append(others, m)
+		}
+	}
+	return reviewers, others
+}
+
+func TestReconcile_DeactivatesRemovedMembers(t *testing.T) {
+	ctx := context.Background()
+	repo, svc, members, pr := reconcileFixture(t)
+	reviewers, others := split(members, pr)
+	// others[0] автор, others[1] единственный участник без ревью.
+	idle := others[1]
+
+	r := NewReconciler(repo, svc, KeepWithOpenReviews)
+	records := recordsFor("backend", others[0], reviewers[0], reviewers[1])
+
+	report, err := r.Reconcile(ctx, records, true)
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 1)
+	assert.Equal(t, ActionDeactivateUser, report.Changes[0].Action)
+	assert.Equal(t, idle.UserID, *report.Changes[0].UserID)
+
+	user, err := repo.GetUserByID(ctx, idle.UserID)
+	require.NoError(t, err)
+	assert.True(t, user.IsActive, "dry run must not change anything")
+
+	_, err = r.Reconcile(ctx, records, false)
+	require.NoError(t, err)
+
+	user, err = repo.GetUserByID(ctx, idle.UserID)
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	report, err = r.Reconcile(ctx, records, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Changes, "reconcile converges")
+}
+
+func TestReconcile_KeepsMembersWithOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	repo, svc, members, pr := reconcileFixture(t)
+	reviewers, others := split(members, pr)
+
+	r := NewReconciler(repo, svc, KeepWithOpenReviews)
+	report, err := r.Reconcile(ctx, recordsFor("backend", others[0], others[1], reviewers[1]), false)
+	require.NoError(t, err)
+
+	require.Len(t, report.Changes, 1)
+	assert.Equal(t, ActionKeepUser, report.Changes[0].Action)
+	assert.Equal(t, reviewers[0].UserID, *report.Changes[0].UserID)
+	assert.Contains(t, report.Changes[0].Reason, "1 open review(s)")
+
+	user, err := repo.GetUserByID(ctx, reviewers[0].UserID)
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+}
+
+func TestReconcile_ReassignsOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	repo, svc, members, pr := reconcileFixture(t)
+	reviewers, others := split(members, pr)
+	removed, replacement := reviewers[0], others[1]
+
+	r := NewReconciler(repo, svc, ReassignOpenReviews)
+	records := recordsFor("backend", others[0], others[1], reviewers[1])
+
+	report, err := r.Reconcile(ctx, records, true)
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 2)
+	assert.Equal(t, ActionReassignReview, report.Changes[0].Action)
+	assert.Equal(t, pr.PullRequestID, *report.Changes[0].PullRequestID)
+	assert.Equal(t, ActionDeactivateUser, report.Changes[1].Action)
+
+	report, err = r.Reconcile(ctx, records, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Summary.ReviewsReassigned)
+	assert.Equal(t, 1, report.Summary.UsersDeactivated)
+
+	updated, err := repo.GetPRByID(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{reviewers[1].UserID, replacement.UserID}, updated.AssignedReviewers)
+
+	user, err := repo.GetUserByID(ctx, removed.UserID)
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+}
+
+func TestReconcile_KeepsMemberWhenNoCandidate(t *testing.T) {
+	ctx := context.Background()
+	repo, svc, members, pr := reconcileFixture(t)
+	_, others := split(members, pr)
+
+	// Удаляем обоих ревьюверов и единственного свободного участника:
+	// для второго ревью замены не останется.
+	r := NewReconciler(repo, svc, ReassignOpenReviews)
+	report, err := r.Reconcile(ctx, recordsFor("backend", others[0]), false)
+	require.NoError(t, err)
+
+	var kept []Change
+	for _, c := range report.Changes {
+		if c.Action == ActionKeepUser {
+			kept = append(kept, c)
+		}
+	}
+	require.NotEmpty(t, kept)
+	assert.Contains(t, kept[0].Reason, "NO_CANDIDATE")
+
+	// Ни один деактивированный участник не остался ревьювером открытого PR.
+	updated, err := repo.GetPRByID(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	for _, id := range updated.AssignedReviewers {
+		user, err := repo.GetUserByID(ctx, id)
+		require.NoError(t, err)
+		assert.True(t, user.IsActive, "reviewer %s was deactivated with an open review", user.Username)
+	}
+}
+
+func TestReconcileFile_PlanOutput(t *testing.T) {
+	ctx := context.Background()
+	repo, svc, members, _ := reconcileFixture(t)
+
+	path := filepath.Join(t.TempDir(), "teams.yaml")
+	content := "teams:\n  - team_name: platform\n    members:\n"
+	for _, m := range members {
+		content += "      - user_id: " + m.UserID.String() + "\n        username: " + m.Username + "\n"
+	}
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	r := NewReconciler(repo, svc, KeepWithOpenReviews)
+	report, err := r.ReconcileFile(ctx, path, true)
+	require.NoError(t, err)
+
+	var plan strings.Builder
+	require.NoError(t, report.WritePlan(&plan))
+	assert.Contains(t, plan.String(), "+ create team platform")
+	assert.Contains(t, plan.String(), "~ move user alice ("+members[0].UserID.String()+") backend -> platform")
+	assert.Contains(t, plan.String(), "1 team(s) to create, 0 user(s) to create, 4 to move")
+}
@@ -0,0 +1,333 @@
+// Package roster реализует массовый импорт и экспорт составов команд.
+// Импорт сначала строит план изменений (создание команд и пользователей,
+// переезды, смена активности и уровней), затем применяет его одной
+// транзакцией.
+package roster
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+)
+
+// Action тип изменения в плане импорта.
+type Action string
+
+const (
+	ActionCreateTeam     Action = "create_team"
+	ActionCreateUser     Action = "create_user"
+	ActionMoveUser       Action = "move_user"
+	ActionRenameUser     Action = "rename_user"
+	ActionActivateUser   Action = "activate_user"
+	ActionDeactivateUser Action = "deactivate_user"
+	// ActionSetSeniority смена уровня существующего пользователя.
+	ActionSetSeniority Action = "set_seniority"
+	// ActionSetSeniorityRule смена правила существующей команды.
+	ActionSetSeniorityRule Action = "set_seniority_rule"
+)
+
+// Change одно изменение плана.
+type Change struct {
+	Action   Action     `json:"action"`
+	TeamName string     `json:"team_name"`
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	Username string     `json:"username,omitempty"`
+	// FromTeam прежняя команда для move_user.
+	FromTeam string `json:"from_team,omitempty"`
+	// FromUsername прежнее имя для rename_user.
+	FromUsername string `json:"from_username,omitempty"`
+	// Seniority и FromSeniority новый и прежний уровень для set_seniority.
+	Seniority     string `json:"seniority,omitempty"`
+	FromSeniority string `json:"from_seniority,omitempty"`
+	// SeniorityRule новое правило для set_seniority_rule.
+	SeniorityRule *domain.SeniorityRule `json:"seniority_rule,omitempty"`
+	// PullRequestID ревью для reassign_review.
+	PullRequestID *uuid.UUID `json:"pull_request_id,omitempty"`
+	// Reason пояснение для изменений, которые делает Reconciler.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Summary количество изменений по типам.
+type Summary struct {
+	TeamsCreated     int `json:"teams_created"`
+	UsersCreated     int `json:"users_created"`
+	UsersMoved       int `json:"users_moved"`
+	UsersRenamed     int `json:"users_renamed"`
+	UsersActivated   int `json:"users_activated"`
+	UsersDeactivated int `json:"users_deactivated"`
+	UsersUnchanged   int `json:"users_unchanged"`
+	SeniorityChanged int `json:"seniority_changed"`
+	RulesChanged     int `json:"seniority_rules_changed"`
+	// ReviewsReassigned и UsersKept заполняет только Reconciler.
+	ReviewsReassigned int `json:"reviews_reassigned,omitempty"`
+	UsersKept         int `json:"users_kept,omitempty"`
+}
+
+// Report результат импорта. При dry-run изменения только перечисляются.
+type Report struct {
+	DryRun  bool     `json:"dry_run"`
+	Changes []Change `json:"changes"`
+	Summary Summary  `json:"summary"`
+}
+
+// RowError ошибка проверки одной строки файла.
+type RowError struct {
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+// ValidationError возвращается, если хотя бы одна строка не прошла проверку.
+// В этом случае ничего не применяется.
+type ValidationError struct {
+	Rows []RowError
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation error: %d invalid row(s), first at %s: %s",
+		len(e.Rows), e.Rows[0].Location, e.Rows[0].Message)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return domain.ErrValidation
+}
+
+// Service импорт и экспорт составов команд.
+type Service struct {
+	repo      repository.RepositoryInterface
+	validator *domain.Validator
+}
+
+func NewService(repo repository.RepositoryInterface) *Service {
+	return &Service{
+		repo:      repo,
+		validator: domain.NewValidator(),
+	}
+}
+
+// Import проверяет записи, строит план и, если dryRun=false, применяет его
+// одной транзакцией. Ошибки строк возвращаются как *ValidationError.
+func (s *Service) Import(ctx context.Context, records []Record, dryRun bool) (*Report, error) {
+	teams, rowErrs := s.validate(records)
+	if len(rowErrs) > 0 {
+		return nil, &ValidationError{Rows: rowErrs}
+	}
+
+	report, err := s.plan(ctx, teams)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = dryRun
+
+	if dryRun || len(report.Changes) == 0 {
+		slog.InfoContext(ctx, "Roster import planned",
+			"dry_run", dryRun,
+			"changes", len(report.Changes),
+		)
+		return report, nil
+	}
+
+	if err := s.repo.ImportTeams(ctx, teams); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Roster imported",
+		"teams_created", report.Summary.TeamsCreated,
+		"users_created", report.Summary.UsersCreated,
+		"users_moved", report.Summary.UsersMoved,
+		"users_deactivated", report.Summary.UsersDeactivated,
+	)
+	return report, nil
+}
+
+// Export возвращает текущие составы. Команды без участников пропускаются:
+// такой файл нельзя импортировать обратно.
+func (s *Service) Export(ctx context.Context) ([]domain.Team, error) {
+	teams, err := s.repo.ListTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nonEmpty := teams[:0]
+	for _, team := range teams {
+		if len(team.Members) > 0 {
+			nonEmpty = append(nonEmpty, team)
+		}
+	}
+	return nonEmpty, nil
+}
+
+// validate проверяет каждую запись через domain.Validator и группирует их
+// по командам в порядке первого появления.
+func (s *Service) validate(records []Record) ([]domain.Team, []RowError) {
+	var (
+		rowErrs []RowError
+		teams   []domain.Team
+	)
+	teamIndex := make(map[string]int)
+	seen := make(map[uuid.UUID]string)
+
+	fail := func(rec Record, err error) {
+		rowErrs = append(rowErrs, RowError{Location: rec.Location, Message: err.Error()})
+	}
+
+	if len(records) == 0 {
+		return nil, []RowError{{Location: "file", Message: "no teams to import"}}
+	}
+
+	for _, rec := range records {
+		if rec.err != nil {
+			fail(rec, rec.err)
+			continue
+		}
+		if rec.noMembers {
+			fail(rec, s.validator.ValidateTeam(&domain.Team{TeamName: rec.TeamName}))
+			continue
+		}
+
+		userID, err := s.validator.ValidateUUID(rec.UserID, "user_id")
+		if err != nil {
+			fail(rec, err)
+			continue
+		}
+
+		member := domain.TeamMember{UserID: userID, Username: rec.Username, IsActive: rec.IsActive, Seniority: rec.Seniority}
+		team := domain.Team{TeamName: rec.TeamName, Members: []domain.TeamMember{member}, SeniorityRule: rec.SeniorityRule}
+		if err := s.validator.ValidateTeam(&team); err != nil {
+			fail(rec, err)
+			continue
+		}
+
+		if first, ok := seen[userID]; ok {
+			fail(rec, fmt.Errorf("duplicate user_id %s, already listed at %s", userID, first))
+			continue
+		}
+		seen[userID] = rec.Location
+
+		i, ok := teamIndex[rec.TeamName]
+		if !ok {
+			i = len(teams)
+			teamIndex[rec.TeamName] = i
+			teams = append(teams, domain.Team{TeamName: rec.TeamName})
+		}
+		if rec.SeniorityRule != nil {
+			if prev := teams[i].SeniorityRule; prev != nil && *prev != *rec.SeniorityRule {
+				fail(rec, fmt.Errorf("conflicting seniority_rule for team %s", rec.TeamName))
+				continue
+			}
+			teams[i].SeniorityRule = rec.SeniorityRule
+		}
+		teams[i].Members = append(teams[i].Members, member)
+	}
+
+	return teams, rowErrs
+}
+
+// plan сравнивает желаемые составы с текущими.
+func (s *Service) plan(ctx context.Context, teams []domain.Team) (*Report, error) {
+	report := &Report{Changes: []Change{}}
+
+	for _, team := range teams {
+		exists, err := s.repo.TeamExists(ctx, team.TeamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team %q: %w", team.TeamName, err)
+		}
+		if !exists {
+			report.add(Change{Action: ActionCreateTeam, TeamName: team.TeamName})
+		} else if team.SeniorityRule != nil {
+			current, err := s.repo.GetSeniorityRule(ctx, team.TeamName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get seniority rule of team %q: %w", team.TeamName, err)
+			}
+			if current == nil || *current != *team.SeniorityRule {
+				report.add(Change{Action: ActionSetSeniorityRule, TeamName: team.TeamName, SeniorityRule: team.SeniorityRule})
+			}
+		}
+
+		for _, member := range team.Members {
+			changes, err := s.planMember(ctx, team.TeamName, member)
+			if err != nil {
+				return nil, err
+			}
+			if len(changes) == 0 {
+				report.Summary.UsersUnchanged++
+			}
+			for _, c := range changes {
+				report.add(c)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (s *Service) planMember(ctx context.Context, teamName string, member domain.TeamMember) ([]Change, error) {
+	userID := member.UserID
+	change := func(action Action) Change {
+		return Change{Action: action, TeamName: teamName, UserID: &userID, Username: member.Username}
+	}
+
+	current, err := s.repo.GetUserByID(ctx, member.UserID)
+	if err != nil {
+		if err.Error() == "USER_NOT_FOUND" {
+			return []Change{change(ActionCreateUser)}, nil
+		}
+		return nil, fmt.Errorf("failed to get user %s: %w", member.UserID, err)
+	}
+
+	var changes []Change
+	if current.TeamName != teamName {
+		c := change(ActionMoveUser)
+		c.FromTeam = current.TeamName
+		changes = append(changes, c)
+	}
+	if current.Username != member.Username {
+		c := change(ActionRenameUser)
+		c.FromUsername = current.Username
+		changes = append(changes, c)
+	}
+	switch {
+	case current.IsActive && !member.IsActive:
+		changes = append(changes, change(ActionDeactivateUser))
+	case !current.IsActive && member.IsActive:
+		changes = append(changes, change(ActionActivateUser))
+	}
+	if member.Seniority != "" && current.Seniority != member.Seniority {
+		c := change(ActionSetSeniority)
+		c.Seniority = member.Seniority
+		c.FromSeniority = current.Seniority
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+func (r *Report) add(c Change) {
+	r.Changes = append(r.Changes, c)
+
+	switch c.Action {
+	case ActionCreateTeam:
+		r.Summary.TeamsCreated++
+	case ActionCreateUser:
+		r.Summary.UsersCreated++
+	case ActionMoveUser:
+		r.Summary.UsersMoved++
+	case ActionRenameUser:
+		r.Summary.UsersRenamed++
+	case ActionActivateUser:
+		r.Summary.UsersActivated++
+	case ActionDeactivateUser:
+		r.Summary.UsersDeactivated++
+	case ActionSetSeniority:
+		r.Summary.SeniorityChanged++
+	case ActionSetSeniorityRule:
+		r.Summary.RulesChanged++
+	case ActionReassignReview:
+		r.Summary.ReviewsReassigned++
+	case ActionKeepUser:
+		r.Summary.UsersKept++
+	}
+}
@@ -0,0 +1,270 @@
+package roster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+)
+
+var (
+	aliceID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	bobID   = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	carolID = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+)
+
+func TestDecode_AllFormatsAgree(t *testing.T) {
+	inputs := map[Format]string{
+		FormatCSV: `team_name,user_id,username,is_active,seniority
+backend,11111111-1111-1111-1111-111111111111,alice,,
+backend,22222222-2222-2222-2222-222222222222,bob,false,senior
+`,
+		FormatYAML: `teams:
+  - team_name: backend
+    members:
+      - user_id: 11111111-1111-1111-1111-111111111111
+        username: alice
+      - user_id: 22222222-2222-2222-2222-222222222222
+        username: bob
+        is_active: false
+        seniority: senior
+`,
+		FormatJSON: `{"teams": [{"team_name": "backend", "members": [
+			{"user_id": "11111111-1111-1111-1111-111111111111", "username": "alice"},
+			{"user_id": "22222222-2222-2222-2222-222222222222", "username": "bob", "is_active": false, "seniority": "senior"}
+		]}]}`,
+	}
+
+	for format, input := range inputs {
+		t.Run(string(format), func(t *testing.T) {
+			records, err := Decode(format, strings.NewReader(input))
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+
+			assert.Equal(t, "backend", records[0].TeamName)
+			assert.Equal(t, aliceID.String(), records[0].UserID)
+			assert.True(t, records[0].IsActive, "is_active defaults to true")
+			assert.Equal(t, "bob", records[1].Username)
+			assert.False(t, records[1].IsActive)
+			assert.Empty(t, records[0].Seniority)
+			assert.Equal(t, domain.SenioritySenior, records[1].Seniority)
+		})
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	_, err := Decode(FormatCSV, strings.NewReader("team,user\nbackend,x\n"))
+	assert.ErrorContains(t, err, `missing column "team_name"`)
+
+	_, err = Decode(FormatJSON, strings.NewReader(`{"teams": [{"name": "backend"}]}`))
+	assert.ErrorContains(t, err, "invalid JSON")
+
+	_, err = Decode(FormatYAML, strings.NewReader("teams:\n  - team: backend\n"))
+	assert.ErrorContains(t, err, "invalid YAML")
+}
+
+func TestImport_RowErrors(t *testing.T) {
+	svc := NewService(repository.NewMemoryRepository())
+
+	records, err := Decode(FormatCSV, strings.NewReader(`team_name,user_id,username,is_active
+backend,not-a-uuid,alice,true
+,22222222-2222-2222-2222-222222222222,bob,true
+backend,33333333-3333-3333-3333-333333333333,,true
+backend,11111111-1111-1111-1111-111111111111,alice,maybe
+frontend,44444444-4444-4444-4444-444444444444,dave,true
+backend,44444444-4444-4444-4444-444444444444,dave,true
+`))
+	require.NoError(t, err)
+
+	_, err = svc.Import(context.Background(), records, false)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []RowError{
+		{Location: "line 2", Message: `user_id must be a valid UUID: invalid UUID length: 10`},
+		{Location: "line 3", Message: "team_name cannot be empty"},
+		{Location: "line 4", Message: "username cannot be empty"},
+		{Location: "line 5", Message: `is_active must be true or false, got "maybe"`},
+		{Location: "line 7", Message: "duplicate user_id 44444444-4444-4444-4444-444444444444, already listed at line 6"},
+	}, validationErr.Rows)
+
+	teams, err := svc.Export(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, teams, "nothing is applied when rows are invalid")
+}
+
+func TestImport_EmptyTeam(t *testing.T) {
+	svc := NewService(repository.NewMemoryRepository())
+
+	records, err := Decode(FormatYAML, strings.NewReader("teams:\n  - team_name: backend\n    members: []\n"))
+	require.NoError(t, err)
+
+	_, err = svc.Import(context.Background(), records, false)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []RowError{{Location: "teams[0]", Message: "team must have at least one member"}}, validationErr.Rows)
+}
+
+func TestImport_PlanDryRunAndApply(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: aliceID, Username: "alice", IsActive: true},
+			{UserID: bobID, Username: "bob", IsActive: true},
+		},
+	}))
+	svc := NewService(repo)
+
+	records := []Record{
+		{Location: "line 2", TeamName: "backend", UserID: aliceID.String(), Username: "alice", IsActive: true},
+		{Location: "line 3", TeamName: "platform", UserID: bobID.String(), Username: "robert", IsActive: false},
+		{Location: "line 4", TeamName: "platform", UserID: carolID.String(), Username: "carol", IsActive: true},
+	}
+
+	report, err := svc.Import(ctx, records, true)
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, Summary{
+		TeamsCreated:     1,
+		UsersCreated:     1,
+		UsersMoved:       1,
+		UsersRenamed:     1,
+		UsersDeactivated: 1,
+		UsersUnchanged:   1,
+	}, report.Summary)
+	assert.Equal(t, Change{Action: ActionCreateTeam, TeamName: "platform"}, report.Changes[0])
+	assert.Equal(t, Change{Action: ActionMoveUser, TeamName: "platform", UserID: &bobID, Username: "robert", FromTeam: "backend"}, report.Changes[1])
+
+	bob, err := repo.GetUserByID(ctx, bobID)
+	require.NoError(t, err)
+	assert.Equal(t, "backend", bob.TeamName, "dry run must not change anything")
+
+	report, err = svc.Import(ctx, records, false)
+	require.NoError(t, err)
+	assert.False(t, report.DryRun)
+	assert.Len(t, report.Changes, 5)
+
+	bob, err = repo.GetUserByID(ctx, bobID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.User{UserID: bobID, Username: "robert", TeamName: "platform", IsActive: false}, *bob)
+
+	report, err = svc.Import(ctx, records, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Changes, "second import is a no-op")
+	assert.Equal(t, 3, report.Summary.UsersUnchanged)
+}
+
+func TestImport_Seniority(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: aliceID, Username: "alice", IsActive: true, Seniority: domain.SeniorityMiddle},
+			{UserID: bobID, Username: "bob", IsActive: true},
+		},
+	}))
+	svc := NewService(repo)
+
+	rule := &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 1}
+	records := []Record{
+		{Location: "teams[0].members[0]", TeamName: "backend", UserID: aliceID.String(), Username: "alice", IsActive: true, Seniority: domain.SenioritySenior, SeniorityRule: rule},
+		{Location: "teams[0].members[1]", TeamName: "backend", UserID: bobID.String(), Username: "bob", IsActive: true, SeniorityRule: rule},
+	}
+
+	report, err := svc.Import(ctx, records, false)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Action: ActionSetSeniorityRule, TeamName: "backend", SeniorityRule: rule},
+		{Action: ActionSetSeniority, TeamName: "backend", UserID: &aliceID, Username: "alice", Seniority: domain.SenioritySenior, FromSeniority: domain.SeniorityMiddle},
+	}, report.Changes)
+	assert.Equal(t, 1, report.Summary.SeniorityChanged)
+	assert.Equal(t, 1, report.Summary.RulesChanged)
+	assert.Equal(t, 1, report.Summary.UsersUnchanged)
+
+	team, err := repo.GetTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, rule, team.SeniorityRule)
+	assert.Equal(t, domain.SenioritySenior, team.Members[0].Seniority)
+
+	report, err = svc.Import(ctx, records, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Changes, "second import is a no-op")
+
+	records[1].SeniorityRule = &domain.SeniorityRule{Level: domain.SeniorityMiddle, Count: 1}
+	_, err = svc.Import(ctx, records, false)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []RowError{{Location: "teams[0].members[1]", Message: "conflicting seniority_rule for team backend"}}, validationErr.Rows)
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{
+		TeamName:      "backend",
+		SeniorityRule: &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 1},
+		Members: []domain.TeamMember{
+			{UserID: aliceID, Username: "alice", IsActive: true, Seniority: domain.SenioritySenior},
+			{UserID: bobID, Username: "bob", IsActive: false},
+		},
+	}))
+	svc := NewService(repo)
+
+	teams, err := svc.Export(ctx)
+	require.NoError(t, err)
+
+	for _, format := range []Format{FormatCSV, FormatYAML, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Encode(format, &buf, teams))
+
+			records, err := Decode(format, bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+
+			report, err := svc.Import(ctx, records, true)
+			require.NoError(t, err)
+			assert.Empty(t, report.Changes)
+			assert.Equal(t, 2, report.Summary.UsersUnchanged)
+
+			// Файл восстанавливает уровни и правило в пустом хранилище;
+			// в CSV правила команды нет.
+			restored := NewService(repository.NewMemoryRepository())
+			_, err = restored.Import(ctx, records, false)
+			require.NoError(t, err)
+			got, err := restored.Export(ctx)
+			require.NoError(t, err)
+			want := teams
+			if format == FormatCSV {
+				want = []domain.Team{{TeamName: "backend", Members: teams[0].Members}}
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestFormatFromContentType(t *testing.T) {
+	tests := map[string]Format{
+		"text/csv; charset=utf-8": FormatCSV,
+		"application/x-yaml":      FormatYAML,
+		"application/json":        FormatJSON,
+	}
+	for contentType, want := range tests {
+		got, ok := FormatFromContentType(contentType)
+		assert.True(t, ok, contentType)
+		assert.Equal(t, want, got, contentType)
+	}
+
+	_, ok := FormatFromContentType("text/plain")
+	assert.False(t, ok)
+}
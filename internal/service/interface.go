@@ -20,5 +20,59 @@ type ServiceInterface interface {
 	GetStatistics(ctx context.Context) (*domain.Statistics, error)
 }
 
+// UserRemover удаляет пользователей с сохранением истории.
+type UserRemover interface {
+	RemoveUser(ctx context.Context, userID uuid.UUID) (*domain.UserRemoval, error)
+}
+
+// SeniorityManager задаёт уровни пользователей и правила команд
+// об уровне ревьюверов.
+type SeniorityManager interface {
+	SetUserSeniority(ctx context.Context, userID uuid.UUID, seniority string) (*domain.User, error)
+	// SetSeniorityRule задаёт правило команды, nil снимает его. Уже
+	// назначенные ревьюверы не меняются.
+	SetSeniorityRule(ctx context.Context, teamName string, rule *domain.SeniorityRule) (*domain.Team, error)
+}
+
 // Compile-time проверка.
-var _ ServiceInterface = (*ReviewerService)(nil)
+var (
+	_ ServiceInterface = (*ReviewerService)(nil)
+	_ UserRemover      = (*ReviewerService)(nil)
+	_ SeniorityManager = (*ReviewerService)(nil)
+)
+
+// MetricsRecorder принимает бизнес-события для экспорта метрик. Команды
+// разных организаций могут называться одинаково, поэтому события
+// передаются с организацией.
+type MetricsRecorder interface {
+	PRCreated(orgID uuid.UUID, team string)
+	PRMerged(orgID uuid.UUID, team string)
+	ReviewerReassigned(orgID uuid.UUID, team string)
+	NoCandidate(orgID uuid.UUID, team, operation string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) PRCreated(uuid.UUID, string)           {}
+func (noopMetrics) PRMerged(uuid.UUID, string)            {}
+func (noopMetrics) ReviewerReassigned(uuid.UUID, string)  {}
+func (noopMetrics) NoCandidate(uuid.UUID, string, string) {}
+
+// EventPublisher принимает события о назначениях для подписчиков.
+// Ошибки доставки не влияют на результат операции.
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...domain.ReviewEvent)
+}
+
+type noopEvents struct{}
+
+func (noopEvents) Publish(context.Context, ...domain.ReviewEvent) {}
+
+// multiEvents рассылает события нескольким получателям по порядку.
+type multiEvents []EventPublisher
+
+func (m multiEvents) Publish(ctx context.Context, events ...domain.ReviewEvent) {
+	for _, p := range m {
+		p.Publish(ctx, events...)
+	}
+}
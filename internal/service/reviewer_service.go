@@ -12,20 +12,58 @@ import (
 
 	"github.com/T1mof/pr-reviewer-service/internal/domain"
 	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
 )
 
+// assignAttempts ограничивает выбор ревьюверов, когда репозиторий отклоняет
+// неактивного кандидата из устаревшего состава команды.
+const assignAttempts = 2
+
 type ReviewerService struct {
 	repo      repository.RepositoryInterface
 	validator *domain.Validator
 	rand      *rand.Rand
+	metrics   MetricsRecorder
+	events    EventPublisher
+}
+
+// Option настраивает необязательные зависимости ReviewerService.
+type Option func(*ReviewerService)
+
+// WithMetrics подключает экспорт бизнес-метрик.
+func WithMetrics(m MetricsRecorder) Option {
+	return func(s *ReviewerService) {
+		s.metrics = m
+	}
 }
 
-func NewReviewerService(repo repository.RepositoryInterface) *ReviewerService {
-	return &ReviewerService{
+// WithEvents подключает публикацию событий о назначениях ревьюверов.
+// Повторный вызов добавляет ещё одного получателя.
+func WithEvents(p EventPublisher) Option {
+	return func(s *ReviewerService) {
+		switch events := s.events.(type) {
+		case noopEvents:
+			s.events = p
+		case multiEvents:
+			s.events = append(events, p)
+		default:
+			s.events = multiEvents{events, p}
+		}
+	}
+}
+
+func NewReviewerService(repo repository.RepositoryInterface, opts ...Option) *ReviewerService {
+	s := &ReviewerService{
 		repo:      repo,
 		validator: domain.NewValidator(),
 		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		metrics:   noopMetrics{},
+		events:    noopEvents{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // ========================================
@@ -34,37 +72,37 @@ func NewReviewerService(repo repository.RepositoryInterface) *ReviewerService {
 
 func (s *ReviewerService) CreateTeam(ctx context.Context, team *domain.Team) error {
 	if err := s.validator.ValidateTeam(team); err != nil {
-		slog.Warn("Team validation failed", "team_name", team.TeamName, "error", err)
-		return fmt.Errorf("validation error: %w", err)
+		slog.WarnContext(ctx, "Team validation failed", "team_name", team.TeamName, "error", err)
+		return fmt.Errorf("%w: %w", domain.ErrValidation, err)
 	}
 
 	exists, err := s.repo.TeamExists(ctx, team.TeamName)
 	if err != nil {
-		slog.Error("Failed to check team existence", "team_name", team.TeamName, "error", err)
+		slog.ErrorContext(ctx, "Failed to check team existence", "team_name", team.TeamName, "error", err)
 		return err
 	}
 	if exists {
-		slog.Warn("Team already exists", "team_name", team.TeamName)
+		slog.WarnContext(ctx, "Team already exists", "team_name", team.TeamName)
 		return errors.New("TEAM_EXISTS")
 	}
 
 	if err := s.repo.CreateTeam(ctx, team); err != nil {
-		slog.Error("Failed to create team", "team_name", team.TeamName, "error", err)
+		slog.ErrorContext(ctx, "Failed to create team", "team_name", team.TeamName, "error", err)
 		return err
 	}
 
-	slog.Info("Team created", "team_name", team.TeamName, "members_count", len(team.Members))
+	slog.InfoContext(ctx, "Team created", "team_name", team.TeamName, "members_count", len(team.Members))
 	return nil
 }
 
 func (s *ReviewerService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
 	if teamName == "" {
-		return nil, errors.New("team_name cannot be empty")
+		return nil, fmt.Errorf("%w: team_name cannot be empty", domain.ErrValidation)
 	}
 
 	team, err := s.repo.GetTeamByName(ctx, teamName)
 	if err != nil {
-		slog.Error("Failed to get team", "team_name", teamName, "error", err)
+		slog.ErrorContext(ctx, "Failed to get team", "team_name", teamName, "error", err)
 		return nil, err
 	}
 
@@ -77,25 +115,171 @@ func (s *ReviewerService) GetTeam(ctx context.Context, teamName string) (*domain
 
 func (s *ReviewerService) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool) (*domain.User, error) {
 	if userID == uuid.Nil {
-		return nil, errors.New("user_id cannot be nil UUID")
+		return nil, fmt.Errorf("%w: user_id cannot be nil UUID", domain.ErrValidation)
 	}
 
 	err := s.repo.SetUserActive(ctx, userID, isActive)
 	if err != nil {
-		slog.Error("Failed to set user active", "user_id", userID, "is_active", isActive, "error", err)
+		slog.ErrorContext(ctx, "Failed to set user active", "user_id", userID, "is_active", isActive, "error", err)
 		return nil, fmt.Errorf("failed to set user active: %w", err)
 	}
 
 	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
-		slog.Error("Failed to get user", "user_id", userID, "error", err)
+		slog.ErrorContext(ctx, "Failed to get user", "user_id", userID, "error", err)
 		return nil, err
 	}
 
-	slog.Info("User active status updated", "user_id", userID, "is_active", isActive)
+	slog.InfoContext(ctx, "User active status updated", "user_id", userID, "is_active", isActive)
 	return user, nil
 }
 
+func (s *ReviewerService) SetUserSeniority(ctx context.Context, userID uuid.UUID, seniority string) (*domain.User, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user_id cannot be nil UUID", domain.ErrValidation)
+	}
+	if err := s.validator.ValidateSeniority(seniority); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrValidation, err)
+	}
+
+	if err := s.repo.SetUserSeniority(ctx, userID, seniority); err != nil {
+		slog.ErrorContext(ctx, "Failed to set user seniority", "user_id", userID, "seniority", seniority, "error", err)
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get user", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "User seniority updated", "user_id", userID, "seniority", seniority)
+	return user, nil
+}
+
+func (s *ReviewerService) SetSeniorityRule(ctx context.Context, teamName string, rule *domain.SeniorityRule) (*domain.Team, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("%w: team_name cannot be empty", domain.ErrValidation)
+	}
+	if rule != nil {
+		if err := s.validator.ValidateSeniorityRule(rule); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrValidation, err)
+		}
+	}
+
+	if err := s.repo.SetSeniorityRule(ctx, teamName, rule); err != nil {
+		slog.ErrorContext(ctx, "Failed to set seniority rule", "team_name", teamName, "error", err)
+		return nil, err
+	}
+
+	team, err := s.repo.GetTeamByName(ctx, teamName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get team", "team_name", teamName, "error", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Seniority rule updated", "team_name", teamName, "rule", rule)
+	return team, nil
+}
+
+// RemoveUser переназначает открытые ревью пользователя и помечает его
+// удалённым. PR, где он автор или ревьювер, и статистика сохраняются.
+// Если какое-то ревью передать некому, пользователь не удаляется, а уже
+// переданные ревью остаются у новых ревьюверов: повторный вызов
+// продолжит с оставшихся. На время переназначения пользователь
+// деактивируется, при ошибке активность восстанавливается. Если ему всё же
+// назначили ревью и повторный обход не помог, возвращается
+// "USER_HAS_OPEN_REVIEWS".
+func (s *ReviewerService) RemoveUser(ctx context.Context, userID uuid.UUID) (*domain.UserRemoval, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user_id cannot be nil UUID", domain.ErrValidation)
+	}
+
+	// Кандидатов ищут в команде пользователя, поэтому он должен
+	// существовать до переназначения.
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get user", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	// Неактивному пользователю не назначают новые ревью, пока прежние
+	// переходят к другим участникам команды.
+	if user.IsActive {
+		if err := s.repo.SetUserActive(ctx, userID, false); err != nil {
+			slog.ErrorContext(ctx, "Failed to deactivate user before removal", "user_id", userID, "error", err)
+			return nil, err
+		}
+	}
+
+	removal := &domain.UserRemoval{Reassigned: []domain.ReviewerReplacement{}}
+	for attempt := 1; ; attempt++ {
+		reassigned, err := s.reassignOpenReviews(ctx, userID)
+		removal.Reassigned = append(removal.Reassigned, reassigned...)
+		if err == nil {
+			err = s.repo.RemoveUser(ctx, userID)
+		}
+		if err == nil {
+			break
+		}
+
+		// Ревью, назначенное до деактивации, могло сохраниться уже после
+		// переназначения: репозиторий тогда отказывает, и обход повторяется.
+		if err.Error() == "USER_HAS_OPEN_REVIEWS" && attempt < assignAttempts {
+			slog.WarnContext(ctx, "User got new reviews during removal", "user_id", userID, "attempt", attempt)
+			continue
+		}
+		slog.ErrorContext(ctx, "Failed to remove user", "user_id", userID, "error", err)
+		s.restoreActive(ctx, user)
+		return nil, err
+	}
+
+	user.IsActive = false
+	removal.User = *user
+
+	slog.InfoContext(ctx, "User removed", "user_id", userID, "reassigned_reviews", len(removal.Reassigned))
+	return removal, nil
+}
+
+// reassignOpenReviews передаёт открытые ревью пользователя другим участникам
+// команды и возвращает выполненные замены, в том числе при ошибке.
+func (s *ReviewerService) reassignOpenReviews(ctx context.Context, userID uuid.UUID) ([]domain.ReviewerReplacement, error) {
+	prs, err := s.repo.GetPRsByReviewer(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get user reviews", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+	}
+
+	var reassigned []domain.ReviewerReplacement
+	for _, pr := range prs {
+		if pr.Status != domain.StatusOpen {
+			continue
+		}
+
+		_, newReviewerID, err := s.ReassignReviewer(ctx, pr.PullRequestID, userID)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to reassign review of removed user", "user_id", userID, "pr_id", pr.PullRequestID, "error", err)
+			return reassigned, err
+		}
+		reassigned = append(reassigned, domain.ReviewerReplacement{
+			PullRequestID: pr.PullRequestID,
+			ReplacedBy:    newReviewerID,
+		})
+	}
+	return reassigned, nil
+}
+
+// restoreActive возвращает активность пользователю, которого не удалось
+// удалить. Ошибка только логируется: исходная важнее.
+func (s *ReviewerService) restoreActive(ctx context.Context, user *domain.User) {
+	if !user.IsActive {
+		return
+	}
+	if err := s.repo.SetUserActive(ctx, user.UserID, true); err != nil {
+		slog.ErrorContext(ctx, "Failed to reactivate user after failed removal", "user_id", user.UserID, "error", err)
+	}
+}
+
 // ========================================
 // PullRequest Methods
 // ========================================
@@ -109,89 +293,140 @@ func (s *ReviewerService) CreatePR(ctx context.Context, prID uuid.UUID, prName s
 	}
 
 	if err := s.validator.ValidatePullRequest(pr); err != nil {
-		slog.Warn("PR validation failed", "pr_id", prID, "error", err)
-		return nil, fmt.Errorf("validation error: %w", err)
+		slog.WarnContext(ctx, "PR validation failed", "pr_id", prID, "error", err)
+		return nil, fmt.Errorf("%w: %w", domain.ErrValidation, err)
 	}
 
 	exists, err := s.repo.PRExists(ctx, prID)
 	if err != nil {
-		slog.Error("Failed to check PR existence", "pr_id", prID, "error", err)
+		slog.ErrorContext(ctx, "Failed to check PR existence", "pr_id", prID, "error", err)
 		return nil, err
 	}
 	if exists {
-		slog.Warn("PR already exists", "pr_id", prID)
+		slog.WarnContext(ctx, "PR already exists", "pr_id", prID)
 		return nil, errors.New("PR_EXISTS")
 	}
 
 	author, err := s.repo.GetUserByID(ctx, authorID)
 	if err != nil {
-		slog.Error("Failed to get author", "author_id", authorID, "error", err)
+		slog.ErrorContext(ctx, "Failed to get author", "author_id", authorID, "error", err)
 		return nil, fmt.Errorf("failed to get author: %w", err)
 	}
 
-	members, err := s.repo.GetTeamMembers(ctx, author.TeamName)
+	for attempt := 1; ; attempt++ {
+		err = s.createWithReviewers(ctx, pr, author.TeamName)
+		if err == nil || err.Error() != "REVIEWER_INACTIVE" {
+			break
+		}
+		slog.WarnContext(ctx, "Selected reviewer is inactive", "pr_id", prID, "attempt", attempt)
+		if attempt == assignAttempts {
+			return nil, fmt.Errorf("failed to create PR: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.PRCreated(tenant.OrgID(ctx), author.TeamName)
+
+	created, err := s.repo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, reviewEvents(ctx, domain.EventReviewAssigned, created, created.AssignedReviewers...)...)
+	return created, nil
+}
+
+// createWithReviewers выбирает ревьюверов из команды автора и создаёт PR.
+// Ошибка "REVIEWER_INACTIVE" возвращается без обёртки: состав команды
+// мог устареть в кэше, и выбор стоит повторить.
+func (s *ReviewerService) createWithReviewers(ctx context.Context, pr *domain.PullRequest, teamName string) error {
+	members, err := s.repo.GetTeamMembers(ctx, teamName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get team members", "team_name", teamName, "error", err)
+		return fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	rule, err := s.repo.GetSeniorityRule(ctx, teamName)
 	if err != nil {
-		slog.Error("Failed to get team members", "team_name", author.TeamName, "error", err)
-		return nil, fmt.Errorf("failed to get team members: %w", err)
+		slog.ErrorContext(ctx, "Failed to get seniority rule", "team_name", teamName, "error", err)
+		return fmt.Errorf("failed to get seniority rule: %w", err)
 	}
 
-	reviewers := s.selectReviewers(members, authorID, 2)
-	slog.Info("Reviewers selected", "pr_id", prID, "count", len(reviewers))
+	reviewers, seniors := s.selectReviewers(ctx, members, pr.AuthorID, domain.MaxReviewers, rule)
+	slog.InfoContext(ctx, "Reviewers selected", "pr_id", pr.PullRequestID, "count", len(reviewers))
 
 	if err := s.validator.ValidateReviewersCount(reviewers); err != nil {
-		slog.Warn("Reviewers count validation failed", "pr_id", prID, "count", len(reviewers), "error", err)
-		return nil, fmt.Errorf("validation error: %w", err)
+		slog.WarnContext(ctx, "Reviewers count validation failed", "pr_id", pr.PullRequestID, "count", len(reviewers), "error", err)
+		s.metrics.NoCandidate(tenant.OrgID(ctx), teamName, "create")
+		return fmt.Errorf("%w: %w", domain.ErrValidation, err)
+	}
+	if rule != nil && seniors < rule.Count {
+		slog.WarnContext(ctx, "Not enough senior candidates", "pr_id", pr.PullRequestID, "team", teamName, "level", rule.Level, "required", rule.Count, "found", seniors)
+		s.metrics.NoCandidate(tenant.OrgID(ctx), teamName, "create")
+		return errors.New("NO_SENIOR_CANDIDATE")
 	}
 
 	err = s.repo.CreatePR(ctx, pr, reviewers)
 	if err != nil {
-		slog.Error("Failed to create PR", "pr_id", prID, "error", err)
-		return nil, fmt.Errorf("failed to create PR: %w", err)
+		slog.ErrorContext(ctx, "Failed to create PR", "pr_id", pr.PullRequestID, "error", err)
+		if err.Error() == "REVIEWER_INACTIVE" {
+			return err
+		}
+		return fmt.Errorf("failed to create PR: %w", err)
 	}
-
-	return s.repo.GetPRByID(ctx, prID)
+	return nil
 }
 
 func (s *ReviewerService) MergePR(ctx context.Context, prID uuid.UUID) (*domain.PullRequestWithReviewers, error) {
 	if prID == uuid.Nil {
-		return nil, errors.New("pull_request_id cannot be nil UUID")
+		return nil, fmt.Errorf("%w: pull_request_id cannot be nil UUID", domain.ErrValidation)
 	}
 
 	pr, err := s.repo.GetPRByID(ctx, prID)
 	if err != nil {
-		slog.Error("Failed to get PR", "pr_id", prID, "error", err)
+		slog.ErrorContext(ctx, "Failed to get PR", "pr_id", prID, "error", err)
 		return nil, fmt.Errorf("failed to get PR: %w", err)
 	}
 
 	if pr.Status == domain.StatusMerged {
-		slog.Info("PR already merged", "pr_id", prID)
+		slog.InfoContext(ctx, "PR already merged", "pr_id", prID)
 		return pr, nil
 	}
 
 	now := time.Now()
 	err = s.repo.UpdatePRStatus(ctx, prID, domain.StatusMerged, &now)
 	if err != nil {
-		slog.Error("Failed to merge PR", "pr_id", prID, "error", err)
+		slog.ErrorContext(ctx, "Failed to merge PR", "pr_id", prID, "error", err)
 		return nil, fmt.Errorf("failed to merge PR: %w", err)
 	}
 
-	slog.Info("PR merged", "pr_id", prID)
-	return s.repo.GetPRByID(ctx, prID)
+	s.metrics.PRMerged(tenant.OrgID(ctx), s.authorTeam(ctx, pr.AuthorID))
+	slog.InfoContext(ctx, "PR merged", "pr_id", prID)
+
+	merged, err := s.repo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, reviewEvents(ctx, domain.EventPRMerged, merged, merged.AssignedReviewers...)...)
+	return merged, nil
 }
 
 func (s *ReviewerService) ReassignReviewer(ctx context.Context, prID, oldUserID uuid.UUID) (*domain.PullRequestWithReviewers, uuid.UUID, error) {
 	if prID == uuid.Nil || oldUserID == uuid.Nil {
-		return nil, uuid.Nil, errors.New("IDs cannot be nil UUID")
+		return nil, uuid.Nil, fmt.Errorf("%w: IDs cannot be nil UUID", domain.ErrValidation)
 	}
 
 	pr, err := s.repo.GetPRByID(ctx, prID)
 	if err != nil {
-		slog.Error("Failed to get PR", "pr_id", prID, "error", err)
+		slog.ErrorContext(ctx, "Failed to get PR", "pr_id", prID, "error", err)
 		return nil, uuid.Nil, fmt.Errorf("failed to get PR: %w", err)
 	}
 
 	if pr.Status == domain.StatusMerged {
-		slog.Warn("Cannot reassign on merged PR", "pr_id", prID)
+		slog.WarnContext(ctx, "Cannot reassign on merged PR", "pr_id", prID)
 		return nil, uuid.Nil, errors.New("PR_MERGED")
 	}
 
@@ -203,20 +438,55 @@ func (s *ReviewerService) ReassignReviewer(ctx context.Context, prID, oldUserID
 		}
 	}
 	if !isAssigned {
-		slog.Warn("Reviewer not assigned", "pr_id", prID, "reviewer", oldUserID)
+		slog.WarnContext(ctx, "Reviewer not assigned", "pr_id", prID, "reviewer", oldUserID)
 		return nil, uuid.Nil, errors.New("NOT_ASSIGNED")
 	}
 
 	oldUser, err := s.repo.GetUserByID(ctx, oldUserID)
 	if err != nil {
-		slog.Error("Failed to get old user", "user_id", oldUserID, "error", err)
+		slog.ErrorContext(ctx, "Failed to get old user", "user_id", oldUserID, "error", err)
 		return nil, uuid.Nil, fmt.Errorf("failed to get old user: %w", err)
 	}
 
-	members, err := s.repo.GetTeamMembers(ctx, oldUser.TeamName)
+	var newReviewerID uuid.UUID
+	for attempt := 1; ; attempt++ {
+		newReviewerID, err = s.replaceWithCandidate(ctx, pr, oldUserID, oldUser.TeamName)
+		if err == nil || err.Error() != "REVIEWER_INACTIVE" {
+			break
+		}
+		slog.WarnContext(ctx, "Selected reviewer is inactive", "pr_id", prID, "attempt", attempt)
+		if attempt == assignAttempts {
+			return nil, uuid.Nil, fmt.Errorf("failed to replace reviewer: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	s.metrics.ReviewerReassigned(tenant.OrgID(ctx), oldUser.TeamName)
+
+	updatedPR, err := s.repo.GetPRByID(ctx, prID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get updated PR", "pr_id", prID, "error", err)
+		return nil, uuid.Nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+
+	events := reviewEvents(ctx, domain.EventReviewUnassigned, updatedPR, oldUserID)
+	events = append(events, reviewEvents(ctx, domain.EventReviewAssigned, updatedPR, newReviewerID)...)
+	s.events.Publish(ctx, events...)
+
+	return updatedPR, newReviewerID, nil
+}
+
+// replaceWithCandidate выбирает замену oldUserID из команды teamName и
+// сохраняет её. "REVIEWER_INACTIVE" возвращается без обёртки, как в
+// createWithReviewers.
+func (s *ReviewerService) replaceWithCandidate(ctx context.Context, pr *domain.PullRequestWithReviewers, oldUserID uuid.UUID, teamName string) (uuid.UUID, error) {
+	prID := pr.PullRequestID
+	members, err := s.repo.GetTeamMembers(ctx, teamName)
 	if err != nil {
-		slog.Error("Failed to get team members", "team_name", oldUser.TeamName, "error", err)
-		return nil, uuid.Nil, fmt.Errorf("failed to get team members: %w", err)
+		slog.ErrorContext(ctx, "Failed to get team members", "team_name", teamName, "error", err)
+		return uuid.Nil, fmt.Errorf("failed to get team members: %w", err)
 	}
 
 	excludeIDs := make(map[uuid.UUID]bool)
@@ -233,36 +503,62 @@ func (s *ReviewerService) ReassignReviewer(ctx context.Context, prID, oldUserID
 	}
 
 	if len(candidates) == 0 {
-		slog.Warn("No candidates for reassignment", "pr_id", prID, "team", oldUser.TeamName)
-		return nil, uuid.Nil, errors.New("NO_CANDIDATE")
+		slog.WarnContext(ctx, "No candidates for reassignment", "pr_id", prID, "team", teamName)
+		s.metrics.NoCandidate(tenant.OrgID(ctx), teamName, "reassign")
+		return uuid.Nil, errors.New("NO_CANDIDATE")
 	}
 
-	newReviewer := candidates[s.rand.Intn(len(candidates))]
-	slog.Info("New reviewer selected", "pr_id", prID, "old", oldUserID, "new", newReviewer.UserID)
-
-	err = s.repo.ReplaceReviewer(ctx, prID, oldUserID, newReviewer.UserID)
+	rule, err := s.repo.GetSeniorityRule(ctx, teamName)
 	if err != nil {
-		slog.Error("Failed to replace reviewer", "pr_id", prID, "error", err)
-		return nil, uuid.Nil, fmt.Errorf("failed to replace reviewer: %w", err)
+		slog.ErrorContext(ctx, "Failed to get seniority rule", "team_name", teamName, "error", err)
+		return uuid.Nil, fmt.Errorf("failed to get seniority rule: %w", err)
+	}
+	remaining := 0
+	if rule != nil {
+		if remaining, err = s.countSeniors(ctx, rule, pr.AssignedReviewers, oldUserID, members); err != nil {
+			slog.ErrorContext(ctx, "Failed to count senior reviewers", "pr_id", prID, "error", err)
+			return uuid.Nil, err
+		}
+	}
+	if rule != nil && remaining < rule.Count {
+		// Без замены правило нарушится, поэтому заменить можно только
+		// ревьювером нужного уровня.
+		var seniors []domain.User
+		for _, c := range candidates {
+			if rule.Satisfies(c.Seniority) {
+				seniors = append(seniors, c)
+			}
+		}
+		if len(seniors) == 0 {
+			slog.WarnContext(ctx, "No senior candidates for reassignment", "pr_id", prID, "team", teamName, "level", rule.Level)
+			s.metrics.NoCandidate(tenant.OrgID(ctx), teamName, "reassign")
+			return uuid.Nil, errors.New("NO_SENIOR_CANDIDATE")
+		}
+		candidates = seniors
 	}
 
-	updatedPR, err := s.repo.GetPRByID(ctx, prID)
+	newReviewer := candidates[s.rand.Intn(len(candidates))]
+	slog.InfoContext(ctx, "New reviewer selected", "pr_id", prID, "old", oldUserID, "new", newReviewer.UserID)
+
+	err = s.repo.ReplaceReviewer(ctx, prID, oldUserID, newReviewer.UserID)
 	if err != nil {
-		slog.Error("Failed to get updated PR", "pr_id", prID, "error", err)
-		return nil, uuid.Nil, fmt.Errorf("failed to get updated PR: %w", err)
+		slog.ErrorContext(ctx, "Failed to replace reviewer", "pr_id", prID, "error", err)
+		if err.Error() == "REVIEWER_INACTIVE" {
+			return uuid.Nil, err
+		}
+		return uuid.Nil, fmt.Errorf("failed to replace reviewer: %w", err)
 	}
-
-	return updatedPR, newReviewer.UserID, nil
+	return newReviewer.UserID, nil
 }
 
 func (s *ReviewerService) GetUserReviews(ctx context.Context, userID uuid.UUID) ([]domain.PullRequestShort, error) {
 	if userID == uuid.Nil {
-		return nil, errors.New("user_id cannot be nil UUID")
+		return nil, fmt.Errorf("%w: user_id cannot be nil UUID", domain.ErrValidation)
 	}
 
 	prs, err := s.repo.GetPRsByReviewer(ctx, userID)
 	if err != nil {
-		slog.Error("Failed to get user reviews", "user_id", userID, "error", err)
+		slog.ErrorContext(ctx, "Failed to get user reviews", "user_id", userID, "error", err)
 		return nil, err
 	}
 
@@ -273,31 +569,31 @@ func (s *ReviewerService) GetUserReviews(ctx context.Context, userID uuid.UUID)
 func (s *ReviewerService) GetStatistics(ctx context.Context) (*domain.Statistics, error) {
 	prStats, err := s.repo.GetPRStats(ctx)
 	if err != nil {
-		slog.Error("Failed to get PR stats", "error", err)
+		slog.ErrorContext(ctx, "Failed to get PR stats", "error", err)
 		return nil, fmt.Errorf("failed to get PR stats: %w", err)
 	}
 
 	userStats, err := s.repo.GetUserAssignmentStats(ctx)
 	if err != nil {
-		slog.Error("Failed to get user assignment stats", "error", err)
+		slog.ErrorContext(ctx, "Failed to get user assignment stats", "error", err)
 		return nil, fmt.Errorf("failed to get user stats: %w", err)
 	}
 
 	totalUsers, err := s.repo.GetTotalUsers(ctx)
 	if err != nil {
-		slog.Error("Failed to get total users", "error", err)
+		slog.ErrorContext(ctx, "Failed to get total users", "error", err)
 		return nil, fmt.Errorf("failed to get total users: %w", err)
 	}
 
 	totalTeams, err := s.repo.GetTotalTeams(ctx)
 	if err != nil {
-		slog.Error("Failed to get total teams", "error", err)
+		slog.ErrorContext(ctx, "Failed to get total teams", "error", err)
 		return nil, fmt.Errorf("failed to get total teams: %w", err)
 	}
 
 	activeUsers, err := s.repo.GetActiveUsers(ctx)
 	if err != nil {
-		slog.Error("Failed to get active users", "error", err)
+		slog.ErrorContext(ctx, "Failed to get active users", "error", err)
 		return nil, fmt.Errorf("failed to get active users: %w", err)
 	}
 
@@ -309,7 +605,7 @@ func (s *ReviewerService) GetStatistics(ctx context.Context) (*domain.Statistics
 		ActiveUsers: activeUsers,
 	}
 
-	slog.Info("Statistics retrieved",
+	slog.InfoContext(ctx, "Statistics retrieved",
 		"total_prs", stats.PRStats.TotalPRs,
 		"total_users", stats.TotalUsers,
 		"total_teams", stats.TotalTeams,
@@ -322,7 +618,22 @@ func (s *ReviewerService) GetStatistics(ctx context.Context) (*domain.Statistics
 // Helper Methods
 // ========================================
 
-func (s *ReviewerService) selectReviewers(members []domain.User, excludeID uuid.UUID, maxCount int) []uuid.UUID {
+// authorTeam команда автора PR для метрик. PR уже смержен, поэтому ошибка
+// не возвращается: удалённый автор или сбой чтения дают пустую метку.
+func (s *ReviewerService) authorTeam(ctx context.Context, authorID uuid.UUID) string {
+	author, err := s.repo.GetUserByID(ctx, authorID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to get PR author team for metrics", "author_id", authorID, "error", err)
+		return ""
+	}
+	return author.TeamName
+}
+
+// selectReviewers выбирает до maxCount случайных активных участников.
+// Если задано правило, первые места достаются кандидатам нужного уровня,
+// остальные — любым. Возвращает выбранных и число подходящих под правило
+// среди них.
+func (s *ReviewerService) selectReviewers(ctx context.Context, members []domain.User, excludeID uuid.UUID, maxCount int, rule *domain.SeniorityRule) ([]uuid.UUID, int) {
 	var candidates []domain.User
 	for _, m := range members {
 		if m.UserID != excludeID && m.IsActive {
@@ -332,19 +643,84 @@ func (s *ReviewerService) selectReviewers(members []domain.User, excludeID uuid.
 
 	count := minInt(maxCount, len(candidates))
 	if count == 0 {
-		slog.Warn("No active candidates", "exclude_id", excludeID, "total", len(members))
-		return []uuid.UUID{}
+		slog.WarnContext(ctx, "No active candidates", "exclude_id", excludeID, "total", len(members))
+		return []uuid.UUID{}, 0
 	}
 
 	s.rand.Shuffle(len(candidates), func(i, j int) {
 		candidates[i], candidates[j] = candidates[j], candidates[i]
 	})
 
+	if rule != nil {
+		// Подходящие кандидаты переносятся в начало; перед ними стоят только
+		// уже перенесённые, поэтому обмен никого не теряет.
+		placed := 0
+		for i := range candidates {
+			if placed == rule.Count {
+				break
+			}
+			if rule.Satisfies(candidates[i].Seniority) {
+				candidates[placed], candidates[i] = candidates[i], candidates[placed]
+				placed++
+			}
+		}
+	}
+
 	result := make([]uuid.UUID, count)
+	seniors := 0
 	for i := 0; i < count; i++ {
 		result[i] = candidates[i].UserID
+		if rule != nil && rule.Satisfies(candidates[i].Seniority) {
+			seniors++
+		}
+	}
+	return result, seniors
+}
+
+// countSeniors считает ревьюверов PR, кроме exceptID, подходящих под
+// правило. Уровни берутся из состава команды; ревьювер из другой команды
+// читается отдельно, и ошибка чтения возвращается: без уровня нельзя
+// решить, нарушит ли замена правило.
+func (s *ReviewerService) countSeniors(ctx context.Context, rule *domain.SeniorityRule, reviewers []uuid.UUID, exceptID uuid.UUID, members []domain.User) (int, error) {
+	seniority := make(map[uuid.UUID]string, len(members))
+	for _, m := range members {
+		seniority[m.UserID] = m.Seniority
+	}
+
+	count := 0
+	for _, id := range reviewers {
+		if id == exceptID {
+			continue
+		}
+		level, ok := seniority[id]
+		if !ok {
+			user, err := s.repo.GetUserByID(ctx, id)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get reviewer %s: %w", id, err)
+			}
+			level = user.Seniority
+		}
+		if rule.Satisfies(level) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// reviewEvents создаёт события одного типа по PR для каждого пользователя.
+func reviewEvents(ctx context.Context, eventType string, pr *domain.PullRequestWithReviewers, userIDs ...uuid.UUID) []domain.ReviewEvent {
+	events := make([]domain.ReviewEvent, len(userIDs))
+	for i, userID := range userIDs {
+		events[i] = domain.ReviewEvent{
+			Type:            eventType,
+			UserID:          userID,
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			OrgID:           tenant.OrgID(ctx),
+		}
 	}
-	return result
+	return events
 }
 
 func minInt(a, b int) int {
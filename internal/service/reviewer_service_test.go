@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
 )
 
 // ========================================
@@ -41,6 +44,32 @@ func (m *MockRepository) TeamExists(ctx context.Context, teamName string) (bool,
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockRepository) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Team), args.Error(1)
+}
+
+func (m *MockRepository) ImportTeams(ctx context.Context, teams []domain.Team) error {
+	args := m.Called(ctx, teams)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetSeniorityRule(ctx context.Context, teamName string) (*domain.SeniorityRule, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SeniorityRule), args.Error(1)
+}
+
+func (m *MockRepository) SetSeniorityRule(ctx context.Context, teamName string, rule *domain.SeniorityRule) error {
+	args := m.Called(ctx, teamName, rule)
+	return args.Error(0)
+}
+
 // UserRepository methods.
 func (m *MockRepository) UpsertUser(ctx context.Context, user *domain.User) error {
 	args := m.Called(ctx, user)
@@ -68,6 +97,16 @@ func (m *MockRepository) SetUserActive(ctx context.Context, userID uuid.UUID, is
 	return args.Error(0)
 }
 
+func (m *MockRepository) RemoveUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SetUserSeniority(ctx context.Context, userID uuid.UUID, seniority string) error {
+	args := m.Called(ctx, userID, seniority)
+	return args.Error(0)
+}
+
 // PullRequestRepository methods.
 func (m *MockRepository) CreatePR(ctx context.Context, pr *domain.PullRequest, reviewers []uuid.UUID) error {
 	args := m.Called(ctx, pr, reviewers)
@@ -113,6 +152,25 @@ func (m *MockRepository) GetPRsByReviewer(ctx context.Context, userID uuid.UUID)
 	return args.Get(0).([]domain.PullRequestShort), args.Error(1)
 }
 
+// PersonalDataRepository methods.
+func (m *MockRepository) GetUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserData), args.Error(1)
+}
+
+func (m *MockRepository) AnonymousID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) AnonymizeUser(ctx context.Context, userID, newID uuid.UUID, pseudonym string) error {
+	args := m.Called(ctx, userID, newID, pseudonym)
+	return args.Error(0)
+}
+
 // ========================================
 // Tests
 // ========================================
@@ -182,7 +240,7 @@ func TestCreateTeam_EmptyTeamName(t *testing.T) {
 	err := service.CreateTeam(context.Background(), team)
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "validation error")
+	assert.ErrorIs(t, err, domain.ErrValidation)
 }
 
 func TestCreateTeam_EmptyMembers(t *testing.T) {
@@ -197,7 +255,7 @@ func TestCreateTeam_EmptyMembers(t *testing.T) {
 	err := service.CreateTeam(context.Background(), team)
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "validation error")
+	assert.ErrorIs(t, err, domain.ErrValidation)
 }
 
 func TestGetTeam_Success(t *testing.T) {
@@ -265,6 +323,8 @@ func TestSelectReviewers_Success(t *testing.T) {
 		repo:      mockRepo,
 		validator: domain.NewValidator(),
 		rand:      rand.New(rand.NewSource(1)),
+		metrics:   noopMetrics{},
+		events:    noopEvents{},
 	}
 
 	members := []domain.User{
@@ -276,7 +336,7 @@ func TestSelectReviewers_Success(t *testing.T) {
 
 	excludeID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
 
-	reviewers := service.selectReviewers(members, excludeID, 2)
+	reviewers, _ := service.selectReviewers(context.Background(), members, excludeID, 2, nil)
 
 	assert.Len(t, reviewers, 2)
 	assert.NotContains(t, reviewers, excludeID)
@@ -291,6 +351,8 @@ func TestSelectReviewers_NoActiveCandidates(t *testing.T) {
 		repo:      mockRepo,
 		validator: domain.NewValidator(),
 		rand:      rand.New(rand.NewSource(1)),
+		metrics:   noopMetrics{},
+		events:    noopEvents{},
 	}
 
 	members := []domain.User{
@@ -300,7 +362,7 @@ func TestSelectReviewers_NoActiveCandidates(t *testing.T) {
 
 	excludeID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
 
-	reviewers := service.selectReviewers(members, excludeID, 2)
+	reviewers, _ := service.selectReviewers(context.Background(), members, excludeID, 2, nil)
 
 	assert.Len(t, reviewers, 0)
 }
@@ -311,6 +373,8 @@ func TestSelectReviewers_OnlyOneCandidate(t *testing.T) {
 		repo:      mockRepo,
 		validator: domain.NewValidator(),
 		rand:      rand.New(rand.NewSource(1)),
+		metrics:   noopMetrics{},
+		events:    noopEvents{},
 	}
 
 	members := []domain.User{
@@ -320,7 +384,7 @@ func TestSelectReviewers_OnlyOneCandidate(t *testing.T) {
 
 	excludeID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
 
-	reviewers := service.selectReviewers(members, excludeID, 2)
+	reviewers, _ := service.selectReviewers(context.Background(), members, excludeID, 2, nil)
 
 	assert.Len(t, reviewers, 1)
 	assert.Contains(t, reviewers, uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"))
@@ -359,6 +423,7 @@ func TestCreatePR_Success(t *testing.T) {
 	mockRepo.On("PRExists", mock.Anything, prID).Return(false, nil)
 	mockRepo.On("GetUserByID", mock.Anything, authorID).Return(author, nil)
 	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(nil, nil)
 	mockRepo.On("CreatePR", mock.Anything, mock.AnythingOfType("*domain.PullRequest"), mock.AnythingOfType("[]uuid.UUID")).Return(nil)
 	mockRepo.On("GetPRByID", mock.Anything, prID).Return(expectedPR, nil)
 
@@ -376,9 +441,11 @@ func TestMergePR_Success(t *testing.T) {
 	service := NewReviewerService(mockRepo)
 
 	prID := uuid.New()
+	authorID := uuid.New()
 	existingPR := &domain.PullRequestWithReviewers{
 		PullRequestID:   prID,
 		PullRequestName: "Add new feature",
+		AuthorID:        authorID,
 		Status:          "open",
 	}
 
@@ -390,6 +457,7 @@ func TestMergePR_Success(t *testing.T) {
 
 	mockRepo.On("GetPRByID", mock.Anything, prID).Return(existingPR, nil).Once()
 	mockRepo.On("UpdatePRStatus", mock.Anything, prID, "merged", mock.AnythingOfType("*time.Time")).Return(nil)
+	mockRepo.On("GetUserByID", mock.Anything, authorID).Return(&domain.User{UserID: authorID, TeamName: "backend"}, nil)
 	mockRepo.On("GetPRByID", mock.Anything, prID).Return(mergedPR, nil).Once()
 
 	pr, err := service.MergePR(context.Background(), prID)
@@ -563,12 +631,13 @@ func TestCreatePR_NotEnoughReviewers(t *testing.T) {
 	mockRepo.On("PRExists", mock.Anything, prID).Return(false, nil)
 	mockRepo.On("GetUserByID", mock.Anything, authorID).Return(author, nil)
 	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(nil, nil)
 
 	pr, err := service.CreatePR(context.Background(), prID, "Feature", authorID)
 
 	assert.Error(t, err)
 	assert.Nil(t, pr)
-	assert.Contains(t, err.Error(), "validation error")
+	assert.ErrorIs(t, err, domain.ErrValidation)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -583,7 +652,7 @@ func TestCreatePR_EmptyName(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Nil(t, pr)
-	assert.Contains(t, err.Error(), "validation error")
+	assert.ErrorIs(t, err, domain.ErrValidation)
 }
 
 func TestCreatePR_NilUUID(t *testing.T) {
@@ -623,6 +692,54 @@ func TestMergePR_PRNotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCreatePR_RetriesWhenReviewerInactive(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	prID := uuid.New()
+	authorID := uuid.New()
+	stale := uuid.New()
+	steady := uuid.New()
+	fresh := uuid.New()
+
+	author := &domain.User{UserID: authorID, Username: "Alice", TeamName: "backend", IsActive: true}
+	cached := []domain.User{
+		*author,
+		{UserID: stale, Username: "Bob", IsActive: true, TeamName: "backend"},
+		{UserID: steady, Username: "Dave", IsActive: true, TeamName: "backend"},
+	}
+	actual := []domain.User{
+		*author,
+		{UserID: stale, Username: "Bob", IsActive: false, TeamName: "backend"},
+		{UserID: steady, Username: "Dave", IsActive: true, TeamName: "backend"},
+		{UserID: fresh, Username: "Carol", IsActive: true, TeamName: "backend"},
+	}
+
+	mockRepo.On("PRExists", mock.Anything, prID).Return(false, nil)
+	mockRepo.On("GetUserByID", mock.Anything, authorID).Return(author, nil)
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(cached, nil).Once()
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(actual, nil).Once()
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(nil, nil)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything, mock.MatchedBy(func(ids []uuid.UUID) bool {
+		return slices.Contains(ids, stale)
+	})).Return(errors.New("REVIEWER_INACTIVE")).Once()
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything, mock.MatchedBy(func(ids []uuid.UUID) bool {
+		return !slices.Contains(ids, stale)
+	})).Return(nil).Once()
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID:     prID,
+		AuthorID:          authorID,
+		Status:            domain.StatusOpen,
+		AssignedReviewers: []uuid.UUID{steady, fresh},
+	}, nil)
+
+	pr, err := service.CreatePR(context.Background(), prID, "Add new feature", authorID)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{steady, fresh}, pr.AssignedReviewers)
+	mockRepo.AssertExpectations(t)
+}
+
 // ========== ReassignReviewer Tests ==========
 
 func TestReassignReviewer_Success(t *testing.T) {
@@ -631,6 +748,8 @@ func TestReassignReviewer_Success(t *testing.T) {
 		repo:      mockRepo,
 		validator: domain.NewValidator(),
 		rand:      rand.New(rand.NewSource(1)),
+		metrics:   noopMetrics{},
+		events:    noopEvents{},
 	}
 
 	prID := uuid.New()
@@ -670,6 +789,7 @@ func TestReassignReviewer_Success(t *testing.T) {
 	mockRepo.On("GetPRByID", mock.Anything, prID).Return(pr, nil).Once()
 	mockRepo.On("GetUserByID", mock.Anything, oldReviewerID).Return(oldUser, nil)
 	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(nil, nil)
 	mockRepo.On("ReplaceReviewer", mock.Anything, prID, oldReviewerID, newReviewerID).Return(nil)
 	mockRepo.On("GetPRByID", mock.Anything, prID).Return(updatedPR, nil).Once()
 
@@ -822,3 +942,519 @@ func (m *MockRepository) GetActiveUsers(ctx context.Context) (int, error) {
 	args := m.Called(ctx)
 	return args.Int(0), args.Error(1)
 }
+
+// ========== Metrics Tests ==========
+
+type recordingMetrics struct {
+	orgs        []uuid.UUID
+	created     []string
+	merged      []string
+	reassigned  []string
+	noCandidate []string
+}
+
+func (r *recordingMetrics) PRCreated(orgID uuid.UUID, team string) {
+	r.orgs = append(r.orgs, orgID)
+	r.created = append(r.created, team)
+}
+
+func (r *recordingMetrics) PRMerged(orgID uuid.UUID, team string) {
+	r.orgs = append(r.orgs, orgID)
+	r.merged = append(r.merged, team)
+}
+
+func (r *recordingMetrics) ReviewerReassigned(orgID uuid.UUID, team string) {
+	r.orgs = append(r.orgs, orgID)
+	r.reassigned = append(r.reassigned, team)
+}
+
+func (r *recordingMetrics) NoCandidate(orgID uuid.UUID, team, operation string) {
+	r.orgs = append(r.orgs, orgID)
+	r.noCandidate = append(r.noCandidate, team+"/"+operation)
+}
+
+func TestMetrics_CreatePRRecordsTeam(t *testing.T) {
+	mockRepo := new(MockRepository)
+	rec := &recordingMetrics{}
+	service := NewReviewerService(mockRepo, WithMetrics(rec))
+
+	prID := uuid.New()
+	authorID := uuid.New()
+	members := []domain.User{
+		{UserID: authorID, Username: "Alice", IsActive: true, TeamName: "backend"},
+		{UserID: uuid.New(), Username: "Bob", IsActive: true, TeamName: "backend"},
+		{UserID: uuid.New(), Username: "Dave", IsActive: true, TeamName: "backend"},
+	}
+
+	mockRepo.On("PRExists", mock.Anything, prID).Return(false, nil)
+	mockRepo.On("GetUserByID", mock.Anything, authorID).Return(&members[0], nil)
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(nil, nil)
+	mockRepo.On("CreatePR", mock.Anything, mock.AnythingOfType("*domain.PullRequest"), mock.AnythingOfType("[]uuid.UUID")).Return(nil)
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{PullRequestID: prID}, nil)
+
+	orgID := uuid.New()
+	_, err := service.CreatePR(tenant.WithOrg(context.Background(), orgID), prID, "Feature", authorID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backend"}, rec.created)
+	assert.Equal(t, []uuid.UUID{orgID}, rec.orgs)
+	assert.Empty(t, rec.noCandidate)
+}
+
+func TestMetrics_MergePRRecordsAuthorTeam(t *testing.T) {
+	mockRepo := new(MockRepository)
+	rec := &recordingMetrics{}
+	service := NewReviewerService(mockRepo, WithMetrics(rec))
+
+	prID := uuid.New()
+	authorID := uuid.New()
+	pr := &domain.PullRequestWithReviewers{PullRequestID: prID, AuthorID: authorID, Status: domain.StatusOpen}
+
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(pr, nil)
+	mockRepo.On("UpdatePRStatus", mock.Anything, prID, domain.StatusMerged, mock.AnythingOfType("*time.Time")).Return(nil)
+	mockRepo.On("GetUserByID", mock.Anything, authorID).Return(&domain.User{UserID: authorID, TeamName: "backend"}, nil)
+
+	_, err := service.MergePR(context.Background(), prID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backend"}, rec.merged)
+}
+
+func TestMetrics_ReassignNoCandidate(t *testing.T) {
+	mockRepo := new(MockRepository)
+	rec := &recordingMetrics{}
+	service := NewReviewerService(mockRepo, WithMetrics(rec))
+
+	prID := uuid.New()
+	authorID := uuid.New()
+	reviewerID := uuid.New()
+
+	pr := &domain.PullRequestWithReviewers{
+		PullRequestID:     prID,
+		AuthorID:          authorID,
+		Status:            "open",
+		AssignedReviewers: []uuid.UUID{reviewerID},
+	}
+	members := []domain.User{
+		{UserID: authorID, Username: "Alice", IsActive: true, TeamName: "backend"},
+		{UserID: reviewerID, Username: "Bob", IsActive: true, TeamName: "backend"},
+	}
+
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(pr, nil)
+	mockRepo.On("GetUserByID", mock.Anything, reviewerID).Return(&members[1], nil)
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+
+	_, _, err := service.ReassignReviewer(context.Background(), prID, reviewerID)
+
+	assert.EqualError(t, err, "NO_CANDIDATE")
+	assert.Equal(t, []string{"backend/reassign"}, rec.noCandidate)
+	assert.Empty(t, rec.reassigned)
+}
+
+// ========== Events Tests ==========
+
+type recordingEvents struct {
+	events []domain.ReviewEvent
+}
+
+func (r *recordingEvents) Publish(_ context.Context, events ...domain.ReviewEvent) {
+	r.events = append(r.events, events...)
+}
+
+func TestEvents_Reassign(t *testing.T) {
+	mockRepo := new(MockRepository)
+	rec := &recordingEvents{}
+	service := NewReviewerService(mockRepo, WithEvents(rec))
+
+	prID := uuid.New()
+	authorID := uuid.New()
+	oldReviewerID := uuid.New()
+	newReviewerID := uuid.New()
+
+	members := []domain.User{
+		{UserID: authorID, Username: "Alice", IsActive: true, TeamName: "backend"},
+		{UserID: oldReviewerID, Username: "Bob", IsActive: true, TeamName: "backend"},
+		{UserID: newReviewerID, Username: "Dave", IsActive: true, TeamName: "backend"},
+	}
+
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: prID, PullRequestName: "Feature", AuthorID: authorID,
+		Status: "open", AssignedReviewers: []uuid.UUID{oldReviewerID},
+	}, nil).Once()
+	mockRepo.On("GetUserByID", mock.Anything, oldReviewerID).Return(&members[1], nil)
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(nil, nil)
+	mockRepo.On("ReplaceReviewer", mock.Anything, prID, oldReviewerID, newReviewerID).Return(nil)
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: prID, PullRequestName: "Feature", AuthorID: authorID,
+		Status: "open", AssignedReviewers: []uuid.UUID{newReviewerID},
+	}, nil).Once()
+
+	_, _, err := service.ReassignReviewer(context.Background(), prID, oldReviewerID)
+
+	require.NoError(t, err)
+	require.Len(t, rec.events, 2)
+	assert.Equal(t, domain.EventReviewUnassigned, rec.events[0].Type)
+	assert.Equal(t, oldReviewerID, rec.events[0].UserID)
+	assert.Equal(t, domain.EventReviewAssigned, rec.events[1].Type)
+	assert.Equal(t, newReviewerID, rec.events[1].UserID)
+	assert.Equal(t, "Feature", rec.events[1].PullRequestName)
+	assert.Equal(t, authorID, rec.events[1].AuthorID)
+}
+
+func TestEvents_MergeNotifiesReviewersOnce(t *testing.T) {
+	mockRepo := new(MockRepository)
+	rec := &recordingEvents{}
+	service := NewReviewerService(mockRepo, WithEvents(rec))
+
+	prID := uuid.New()
+	reviewers := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: prID, Status: domain.StatusOpen, AssignedReviewers: reviewers,
+	}, nil).Once()
+	mockRepo.On("UpdatePRStatus", mock.Anything, prID, domain.StatusMerged, mock.AnythingOfType("*time.Time")).Return(nil)
+	mockRepo.On("GetUserByID", mock.Anything, uuid.Nil).Return(nil, errors.New("USER_NOT_FOUND"))
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: prID, Status: domain.StatusMerged, AssignedReviewers: reviewers,
+	}, nil)
+
+	_, err := service.MergePR(context.Background(), prID)
+	require.NoError(t, err)
+	_, err = service.MergePR(context.Background(), prID)
+	require.NoError(t, err)
+
+	require.Len(t, rec.events, 2)
+	for i, e := range rec.events {
+		assert.Equal(t, domain.EventPRMerged, e.Type)
+		assert.Equal(t, reviewers[i], e.UserID)
+	}
+}
+
+func TestEvents_MultiplePublishers(t *testing.T) {
+	first, second, third := &recordingEvents{}, &recordingEvents{}, &recordingEvents{}
+	service := NewReviewerService(new(MockRepository), WithEvents(first), WithEvents(second), WithEvents(third))
+
+	event := domain.ReviewEvent{Type: domain.EventReviewAssigned, UserID: uuid.New()}
+	service.events.Publish(context.Background(), event)
+
+	for _, rec := range []*recordingEvents{first, second, third} {
+		assert.Equal(t, []domain.ReviewEvent{event}, rec.events)
+	}
+}
+
+func TestRemoveUser_ReassignsOpenReviews(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	authorID, userID, newReviewerID := uuid.New(), uuid.New(), uuid.New()
+	openID, mergedID := uuid.New(), uuid.New()
+
+	user := &domain.User{UserID: userID, Username: "Bob", TeamName: "backend", IsActive: true}
+	members := []domain.User{
+		{UserID: authorID, Username: "Alice", IsActive: true, TeamName: "backend"},
+		{UserID: userID, Username: "Bob", IsActive: true, TeamName: "backend"},
+		{UserID: newReviewerID, Username: "Dave", IsActive: true, TeamName: "backend"},
+	}
+
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+	mockRepo.On("GetPRsByReviewer", mock.Anything, userID).Return([]domain.PullRequestShort{
+		{PullRequestID: openID, AuthorID: authorID, Status: domain.StatusOpen},
+		{PullRequestID: mergedID, AuthorID: authorID, Status: domain.StatusMerged},
+	}, nil)
+	mockRepo.On("GetPRByID", mock.Anything, openID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: openID, AuthorID: authorID, Status: domain.StatusOpen, AssignedReviewers: []uuid.UUID{userID},
+	}, nil).Once()
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(nil, nil)
+	mockRepo.On("ReplaceReviewer", mock.Anything, openID, userID, newReviewerID).Return(nil)
+	mockRepo.On("GetPRByID", mock.Anything, openID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: openID, AuthorID: authorID, Status: domain.StatusOpen, AssignedReviewers: []uuid.UUID{newReviewerID},
+	}, nil).Once()
+	mockRepo.On("SetUserActive", mock.Anything, userID, false).Return(nil)
+	mockRepo.On("RemoveUser", mock.Anything, userID).Return(nil)
+
+	removal, err := service.RemoveUser(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, userID, removal.User.UserID)
+	assert.False(t, removal.User.IsActive)
+	assert.Equal(t, []domain.ReviewerReplacement{{PullRequestID: openID, ReplacedBy: newReviewerID}}, removal.Reassigned)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRemoveUser_NoCandidateKeepsUser(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	authorID, userID, prID := uuid.New(), uuid.New(), uuid.New()
+	user := &domain.User{UserID: userID, Username: "Bob", TeamName: "backend", IsActive: true}
+
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+	mockRepo.On("GetPRsByReviewer", mock.Anything, userID).Return([]domain.PullRequestShort{
+		{PullRequestID: prID, AuthorID: authorID, Status: domain.StatusOpen},
+	}, nil)
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: prID, AuthorID: authorID, Status: domain.StatusOpen, AssignedReviewers: []uuid.UUID{userID},
+	}, nil)
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return([]domain.User{
+		{UserID: authorID, Username: "Alice", IsActive: true, TeamName: "backend"},
+		*user,
+	}, nil)
+
+	mockRepo.On("SetUserActive", mock.Anything, userID, false).Return(nil).Once()
+	mockRepo.On("SetUserActive", mock.Anything, userID, true).Return(nil).Once()
+
+	removal, err := service.RemoveUser(context.Background(), userID)
+
+	assert.EqualError(t, err, "NO_CANDIDATE")
+	assert.Nil(t, removal)
+	mockRepo.AssertNotCalled(t, "RemoveUser", mock.Anything, userID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRemoveUser_RetriesReviewsAssignedDuringRemoval(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	authorID, userID, newReviewerID, prID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	user := &domain.User{UserID: userID, Username: "Bob", TeamName: "backend", IsActive: true}
+
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+	mockRepo.On("SetUserActive", mock.Anything, userID, false).Return(nil)
+	// Первый обход не видит PR, назначенного параллельно с деактивацией.
+	mockRepo.On("GetPRsByReviewer", mock.Anything, userID).Return([]domain.PullRequestShort{}, nil).Once()
+	mockRepo.On("RemoveUser", mock.Anything, userID).Return(errors.New("USER_HAS_OPEN_REVIEWS")).Once()
+	mockRepo.On("GetPRsByReviewer", mock.Anything, userID).Return([]domain.PullRequestShort{
+		{PullRequestID: prID, AuthorID: authorID, Status: domain.StatusOpen},
+	}, nil).Once()
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: prID, AuthorID: authorID, Status: domain.StatusOpen, AssignedReviewers: []uuid.UUID{userID},
+	}, nil).Once()
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return([]domain.User{
+		{UserID: authorID, Username: "Alice", IsActive: true, TeamName: "backend"},
+		{UserID: userID, Username: "Bob", IsActive: false, TeamName: "backend"},
+		{UserID: newReviewerID, Username: "Dave", IsActive: true, TeamName: "backend"},
+	}, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(nil, nil)
+	mockRepo.On("ReplaceReviewer", mock.Anything, prID, userID, newReviewerID).Return(nil)
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID: prID, AuthorID: authorID, Status: domain.StatusOpen, AssignedReviewers: []uuid.UUID{newReviewerID},
+	}, nil).Once()
+	mockRepo.On("RemoveUser", mock.Anything, userID).Return(nil).Once()
+
+	removal, err := service.RemoveUser(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewerReplacement{{PullRequestID: prID, ReplacedBy: newReviewerID}}, removal.Reassigned)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRemoveUser_GivesUpWhenReviewsKeepArriving(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	userID := uuid.New()
+	user := &domain.User{UserID: userID, Username: "Bob", TeamName: "backend", IsActive: true}
+
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+	mockRepo.On("SetUserActive", mock.Anything, userID, false).Return(nil).Once()
+	mockRepo.On("GetPRsByReviewer", mock.Anything, userID).Return([]domain.PullRequestShort{}, nil)
+	mockRepo.On("RemoveUser", mock.Anything, userID).Return(errors.New("USER_HAS_OPEN_REVIEWS"))
+	mockRepo.On("SetUserActive", mock.Anything, userID, true).Return(nil).Once()
+
+	removal, err := service.RemoveUser(context.Background(), userID)
+
+	assert.EqualError(t, err, "USER_HAS_OPEN_REVIEWS")
+	assert.Nil(t, removal)
+	mockRepo.AssertNumberOfCalls(t, "RemoveUser", assignAttempts)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRemoveUser_UserNotFound(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	userID := uuid.New()
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(nil, errors.New("USER_NOT_FOUND"))
+
+	_, err := service.RemoveUser(context.Background(), userID)
+	assert.EqualError(t, err, "USER_NOT_FOUND")
+
+	_, err = service.RemoveUser(context.Background(), uuid.Nil)
+	assert.Error(t, err)
+}
+
+// ========================================
+// Seniority
+// ========================================
+
+var seniorRule = &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 1}
+
+func TestSelectReviewers_SeniorityRule(t *testing.T) {
+	authorID, seniorID := uuid.New(), uuid.New()
+	members := []domain.User{
+		{UserID: authorID, Username: "Alice", IsActive: true, Seniority: domain.SenioritySenior},
+		{UserID: uuid.New(), Username: "Bob", IsActive: true, Seniority: domain.SeniorityJunior},
+		{UserID: uuid.New(), Username: "Carol", IsActive: true},
+		{UserID: uuid.New(), Username: "Dave", IsActive: true, Seniority: domain.SeniorityMiddle},
+		{UserID: seniorID, Username: "Eve", IsActive: true, Seniority: domain.SenioritySenior},
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		service := &ReviewerService{validator: domain.NewValidator(), rand: rand.New(rand.NewSource(seed))}
+
+		reviewers, seniors := service.selectReviewers(context.Background(), members, authorID, 2, seniorRule)
+		require.Len(t, reviewers, 2)
+		assert.Contains(t, reviewers, seniorID, "seed %d", seed)
+		assert.Equal(t, 1, seniors)
+	}
+}
+
+func TestCreatePR_NoSeniorCandidate(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	prID := uuid.New()
+	author := &domain.User{UserID: uuid.New(), Username: "Alice", TeamName: "backend", IsActive: true}
+	members := []domain.User{
+		*author,
+		{UserID: uuid.New(), Username: "Bob", IsActive: true, TeamName: "backend", Seniority: domain.SeniorityJunior},
+		{UserID: uuid.New(), Username: "Carol", IsActive: true, TeamName: "backend", Seniority: domain.SeniorityMiddle},
+		{UserID: uuid.New(), Username: "Dave", IsActive: false, TeamName: "backend", Seniority: domain.SenioritySenior},
+	}
+
+	mockRepo.On("PRExists", mock.Anything, prID).Return(false, nil)
+	mockRepo.On("GetUserByID", mock.Anything, author.UserID).Return(author, nil)
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(seniorRule, nil)
+
+	pr, err := service.CreatePR(context.Background(), prID, "Feature", author.UserID)
+
+	assert.EqualError(t, err, "NO_SENIOR_CANDIDATE")
+	assert.Nil(t, pr)
+	mockRepo.AssertNotCalled(t, "CreatePR", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// reassignFixture PR с ревьюверами old и other; в команде ещё junior и senior.
+func reassignFixture(mockRepo *MockRepository, oldLevel, otherLevel string, extra ...domain.User) (prID, oldID uuid.UUID) {
+	prID, oldID = uuid.New(), uuid.New()
+	authorID, otherID := uuid.New(), uuid.New()
+
+	oldUser := &domain.User{UserID: oldID, Username: "Bob", TeamName: "backend", IsActive: true, Seniority: oldLevel}
+	members := append([]domain.User{
+		{UserID: authorID, Username: "Alice", TeamName: "backend", IsActive: true},
+		*oldUser,
+		{UserID: otherID, Username: "Carol", TeamName: "backend", IsActive: true, Seniority: otherLevel},
+	}, extra...)
+
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID:     prID,
+		AuthorID:          authorID,
+		Status:            domain.StatusOpen,
+		AssignedReviewers: []uuid.UUID{oldID, otherID},
+	}, nil)
+	mockRepo.On("GetUserByID", mock.Anything, oldID).Return(oldUser, nil)
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(seniorRule, nil)
+	mockRepo.On("ReplaceReviewer", mock.Anything, prID, oldID, mock.Anything).Return(nil)
+	return prID, oldID
+}
+
+func TestReassignReviewer_ReplacesOnlySeniorWithSenior(t *testing.T) {
+	junior := domain.User{UserID: uuid.New(), Username: "Dave", TeamName: "backend", IsActive: true, Seniority: domain.SeniorityJunior}
+	senior := domain.User{UserID: uuid.New(), Username: "Eve", TeamName: "backend", IsActive: true, Seniority: domain.SenioritySenior}
+
+	for seed := int64(0); seed < 20; seed++ {
+		mockRepo := new(MockRepository)
+		service := NewReviewerService(mockRepo)
+		service.rand = rand.New(rand.NewSource(seed))
+		prID, oldID := reassignFixture(mockRepo, domain.SenioritySenior, domain.SeniorityJunior, junior, senior)
+
+		_, newID, err := service.ReassignReviewer(context.Background(), prID, oldID)
+		require.NoError(t, err)
+		assert.Equal(t, senior.UserID, newID, "seed %d", seed)
+	}
+}
+
+func TestReassignReviewer_NoSeniorCandidate(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+	junior := domain.User{UserID: uuid.New(), Username: "Dave", TeamName: "backend", IsActive: true, Seniority: domain.SeniorityJunior}
+	prID, oldID := reassignFixture(mockRepo, domain.SenioritySenior, domain.SeniorityMiddle, junior)
+
+	_, _, err := service.ReassignReviewer(context.Background(), prID, oldID)
+
+	assert.EqualError(t, err, "NO_SENIOR_CANDIDATE")
+	mockRepo.AssertNotCalled(t, "ReplaceReviewer", mock.Anything, prID, oldID, mock.Anything)
+}
+
+func TestReassignReviewer_RuleKeptByOtherReviewer(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+	junior := domain.User{UserID: uuid.New(), Username: "Dave", TeamName: "backend", IsActive: true, Seniority: domain.SeniorityJunior}
+	prID, oldID := reassignFixture(mockRepo, domain.SeniorityJunior, domain.SenioritySenior, junior)
+
+	_, newID, err := service.ReassignReviewer(context.Background(), prID, oldID)
+
+	require.NoError(t, err)
+	assert.Equal(t, junior.UserID, newID)
+}
+
+func TestReassignReviewer_OutsideReviewerLookupFails(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	prID, authorID, oldID, outsiderID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	oldUser := &domain.User{UserID: oldID, Username: "Bob", TeamName: "backend", IsActive: true, Seniority: domain.SenioritySenior}
+	members := []domain.User{
+		{UserID: authorID, Username: "Alice", TeamName: "backend", IsActive: true},
+		*oldUser,
+		{UserID: uuid.New(), Username: "Dave", TeamName: "backend", IsActive: true, Seniority: domain.SeniorityJunior},
+	}
+
+	mockRepo.On("GetPRByID", mock.Anything, prID).Return(&domain.PullRequestWithReviewers{
+		PullRequestID:     prID,
+		AuthorID:          authorID,
+		Status:            domain.StatusOpen,
+		AssignedReviewers: []uuid.UUID{oldID, outsiderID},
+	}, nil)
+	mockRepo.On("GetUserByID", mock.Anything, oldID).Return(oldUser, nil)
+	mockRepo.On("GetUserByID", mock.Anything, outsiderID).Return(nil, errors.New("connection refused"))
+	mockRepo.On("GetTeamMembers", mock.Anything, "backend").Return(members, nil)
+	mockRepo.On("GetSeniorityRule", mock.Anything, "backend").Return(seniorRule, nil)
+
+	_, _, err := service.ReassignReviewer(context.Background(), prID, oldID)
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "connection refused")
+	mockRepo.AssertNotCalled(t, "ReplaceReviewer", mock.Anything, prID, oldID, mock.Anything)
+}
+
+func TestSetUserSeniority(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	userID := uuid.New()
+	user := &domain.User{UserID: userID, Username: "Alice", TeamName: "backend", IsActive: true, Seniority: domain.SenioritySenior}
+	mockRepo.On("SetUserSeniority", mock.Anything, userID, domain.SenioritySenior).Return(nil)
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+
+	got, err := service.SetUserSeniority(context.Background(), userID, domain.SenioritySenior)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SenioritySenior, got.Seniority)
+
+	_, err = service.SetUserSeniority(context.Background(), userID, "principal")
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	mockRepo.AssertNumberOfCalls(t, "SetUserSeniority", 1)
+}
+
+func TestSetSeniorityRule_Validation(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewReviewerService(mockRepo)
+
+	_, err := service.SetSeniorityRule(context.Background(), "backend", &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 3})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	mockRepo.AssertNotCalled(t, "SetSeniorityRule", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,151 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Store хранит организации и хеши их токенов.
+type Store interface {
+	// Create сохраняет организацию или возвращает "ORG_EXISTS", если имя занято.
+	Create(ctx context.Context, org Organization, tokenHash string) error
+	// GetByTokenHash возвращает организацию или "ORG_NOT_FOUND".
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Organization, error)
+	// List возвращает организации, отсортированные по имени.
+	List(ctx context.Context) ([]Organization, error)
+}
+
+// MemoryStore организации в памяти процесса. Организация по умолчанию
+// существует сразу, как и после миграций в БД.
+type MemoryStore struct {
+	mu     sync.Mutex
+	orgs   map[uuid.UUID]Organization
+	tokens map[string]uuid.UUID
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		orgs: map[uuid.UUID]Organization{
+			DefaultOrgID: {OrgID: DefaultOrgID, Name: DefaultOrgName, CreatedAt: time.Now().UTC()},
+		},
+		tokens: make(map[string]uuid.UUID),
+	}
+}
+
+// Create реализует Store.
+func (s *MemoryStore) Create(_ context.Context, org Organization, tokenHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.orgs {
+		if existing.Name == org.Name {
+			return errors.New("ORG_EXISTS")
+		}
+	}
+	s.orgs[org.OrgID] = org
+	s.tokens[tokenHash] = org.OrgID
+	return nil
+}
+
+// GetByTokenHash реализует Store.
+func (s *MemoryStore) GetByTokenHash(_ context.Context, tokenHash string) (*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orgID, ok := s.tokens[tokenHash]
+	if !ok {
+		return nil, errors.New("ORG_NOT_FOUND")
+	}
+	org := s.orgs[orgID]
+	return &org, nil
+}
+
+// List реализует Store.
+func (s *MemoryStore) List(_ context.Context) ([]Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]Organization, 0, len(s.orgs))
+	for _, org := range s.orgs {
+		list = append(list, org)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// SQLStore организации в таблице organizations (PostgreSQL или SQLite).
+type SQLStore struct {
+	db *sql.DB
+}
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+// Create реализует Store.
+func (s *SQLStore) Create(ctx context.Context, org Organization, tokenHash string) error {
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO organizations (org_id, name, token_hash, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO NOTHING
+	`, org.OrgID.String(), org.Name, tokenHash, org.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	if n == 0 {
+		return errors.New("ORG_EXISTS")
+	}
+	return nil
+}
+
+// GetByTokenHash реализует Store.
+func (s *SQLStore) GetByTokenHash(ctx context.Context, tokenHash string) (*Organization, error) {
+	var org Organization
+	err := s.db.QueryRowContext(ctx, `
+		SELECT org_id, name, created_at
+		FROM organizations
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&org.OrgID, &org.Name, &org.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ORG_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &org, nil
+}
+
+// List реализует Store.
+func (s *SQLStore) List(ctx context.Context) ([]Organization, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT org_id, name, created_at
+		FROM organizations
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var list []Organization
+	for rows.Next() {
+		var org Organization
+		if err := rows.Scan(&org.OrgID, &org.Name, &org.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		list = append(list, org)
+	}
+	return list, rows.Err()
+}
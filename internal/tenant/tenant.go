@@ -0,0 +1,140 @@
+// Package tenant описывает организации, между которыми разделены данные
+// сервиса: команды, пользователи, PR и статистика. Организация запроса
+// передаётся через context и фильтрует каждый запрос репозитория.
+package tenant
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DefaultOrgID организация по умолчанию. В неё попадают данные,
+// созданные до появления организаций, все запросы при выключенных
+// организациях, запросы без токена с WithDefaultFallback, webhook'и,
+// команды Slack и сверка составов.
+var DefaultOrgID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// DefaultOrgName имя организации по умолчанию.
+const DefaultOrgName = "default"
+
+// tokenPrefix отличает токены организаций от прочих секретов в логах и конфигах.
+const tokenPrefix = "org_"
+
+// Organization изолированное пространство команд, пользователей и PR.
+type Organization struct {
+	OrgID     uuid.UUID `json:"org_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type orgKey struct{}
+
+// WithOrg возвращает контекст, запросы в котором относятся к организации orgID.
+func WithOrg(ctx context.Context, orgID uuid.UUID) context.Context {
+	return context.WithValue(ctx, orgKey{}, orgID)
+}
+
+// WithDefault возвращает контекст организации по умолчанию. Точки входа,
+// которые по замыслу работают с DefaultOrgID, задают её явно.
+func WithDefault(ctx context.Context) context.Context {
+	return WithOrg(ctx, DefaultOrgID)
+}
+
+// OrgID возвращает организацию из контекста. Контекст без организации —
+// ошибка точки входа: OrgID пишет предупреждение и возвращает DefaultOrgID,
+// чтобы пропуск не ломал запрос, но был виден в логах.
+func OrgID(ctx context.Context) uuid.UUID {
+	if orgID, ok := ctx.Value(orgKey{}).(uuid.UUID); ok {
+		return orgID
+	}
+	slog.WarnContext(ctx, "Organization is not set in context, using default", "org_id", DefaultOrgID)
+	return DefaultOrgID
+}
+
+// Registry создаёт организации и находит их по токену.
+type Registry struct {
+	store           Store
+	defaultFallback bool
+}
+
+// RegistryOption настраивает Registry.
+type RegistryOption func(*Registry)
+
+// WithDefaultFallback относит запросы без токена к организации default.
+// Без него такие запросы отклоняются, чтобы забытый токен не открывал
+// данные default.
+func WithDefaultFallback() RegistryOption {
+	return func(r *Registry) {
+		r.defaultFallback = true
+	}
+}
+
+func NewRegistry(store Store, opts ...RegistryOption) *Registry {
+	r := &Registry{store: store}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// DefaultFallback сообщает, относятся ли запросы без токена к организации default.
+func (r *Registry) DefaultFallback() bool {
+	return r.defaultFallback
+}
+
+// Create создаёт организацию и возвращает её токен. Хранится только хеш
+// токена, поэтому показать его повторно нельзя.
+// Ошибки: "INVALID_NAME", "ORG_EXISTS".
+func (r *Registry) Create(ctx context.Context, name string) (*Organization, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > 255 {
+		return nil, "", errors.New("INVALID_NAME")
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	org := Organization{OrgID: uuid.New(), Name: name, CreatedAt: time.Now().UTC()}
+	if err := r.store.Create(ctx, org, hashToken(token)); err != nil {
+		return nil, "", err
+	}
+	return &org, token, nil
+}
+
+// Authenticate возвращает организацию по токену или "ORG_NOT_FOUND".
+func (r *Registry) Authenticate(ctx context.Context, token string) (*Organization, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, errors.New("ORG_NOT_FOUND")
+	}
+	return r.store.GetByTokenHash(ctx, hashToken(token))
+}
+
+// List возвращает организации, отсортированные по имени.
+func (r *Registry) List(ctx context.Context) ([]Organization, error) {
+	return r.store.List(ctx)
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate organization token: %w", err)
+	}
+	return tokenPrefix + hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
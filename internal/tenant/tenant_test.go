@@ -0,0 +1,78 @@
+package tenant_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+func TestOrgID(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, tenant.DefaultOrgID, tenant.OrgID(ctx))
+
+	orgID := uuid.New()
+	assert.Equal(t, orgID, tenant.OrgID(tenant.WithOrg(ctx, orgID)))
+	assert.Equal(t, tenant.DefaultOrgID, tenant.OrgID(tenant.WithDefault(tenant.WithOrg(ctx, orgID))))
+}
+
+func TestRegistry(t *testing.T) {
+	stores := map[string]func(t *testing.T) tenant.Store{
+		"memory": func(*testing.T) tenant.Store {
+			return tenant.NewMemoryStore()
+		},
+		"sqlite": func(t *testing.T) tenant.Store {
+			cfg := &config.Config{DatabaseURL: "sqlite://" + filepath.Join(t.TempDir(), "test.db")}
+
+			db, err := cfg.ConnectDB()
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+
+			require.NoError(t, cfg.RunMigrations(db.DB))
+			return tenant.NewSQLStore(db.DB)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			registry := tenant.NewRegistry(newStore(t))
+
+			org, token, err := registry.Create(ctx, "  acme ")
+			require.NoError(t, err)
+			assert.Equal(t, "acme", org.Name)
+			assert.True(t, strings.HasPrefix(token, "org_"))
+
+			got, err := registry.Authenticate(ctx, token)
+			require.NoError(t, err)
+			assert.Equal(t, org.OrgID, got.OrgID)
+			assert.Equal(t, "acme", got.Name)
+
+			for _, bad := range []string{"", "org_", token + "x", strings.TrimPrefix(token, "org_")} {
+				_, err = registry.Authenticate(ctx, bad)
+				assert.EqualError(t, err, "ORG_NOT_FOUND", bad)
+			}
+
+			_, _, err = registry.Create(ctx, "acme")
+			assert.EqualError(t, err, "ORG_EXISTS")
+			_, _, err = registry.Create(ctx, " ")
+			assert.EqualError(t, err, "INVALID_NAME")
+			_, _, err = registry.Create(ctx, strings.Repeat("я", 256))
+			assert.EqualError(t, err, "INVALID_NAME")
+
+			list, err := registry.List(ctx)
+			require.NoError(t, err)
+			require.Len(t, list, 2)
+			assert.Equal(t, org.OrgID, list[0].OrgID)
+			assert.Equal(t, tenant.DefaultOrgID, list[1].OrgID)
+			assert.Equal(t, tenant.DefaultOrgName, list[1].Name)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogHandler добавляет request_id и trace_id/span_id из контекста в каждую запись slog.
+type LogHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler оборачивает slog.Handler.
+func NewLogHandler(next slog.Handler) *LogHandler {
+	return &LogHandler{Handler: next}
+}
+
+// Handle реализует slog.Handler.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if tc, ok := TraceContextFromContext(ctx); ok {
+		r.AddAttrs(slog.String("trace_id", tc.TraceID), slog.String("span_id", tc.SpanID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs реализует slog.Handler.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup реализует slog.Handler.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTraceparent_Valid(t *testing.T) {
+	tc, ok := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	assert.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tc.TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", tc.SpanID)
+	assert.Equal(t, "01", tc.Flags)
+}
+
+func TestParseTraceparent_Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"garbage",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+	}
+
+	for _, header := range cases {
+		_, ok := ParseTraceparent(header)
+		assert.False(t, ok, header)
+	}
+}
+
+func TestTraceContext_ChildKeepsTrace(t *testing.T) {
+	parent := NewTraceContext()
+	child := parent.Child()
+
+	assert.Equal(t, parent.TraceID, child.TraceID)
+	assert.Equal(t, parent.SpanID, child.ParentSpanID)
+	assert.NotEqual(t, parent.SpanID, child.SpanID)
+
+	parsed, ok := ParseTraceparent(child.Traceparent())
+	assert.True(t, ok)
+	assert.Equal(t, child.SpanID, parsed.SpanID)
+}
+
+func TestLogHandler_AddsContextAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil)))
+
+	tc := NewTraceContext()
+	ctx := WithTraceContext(WithRequestID(context.Background(), "req-1"), tc)
+	logger.InfoContext(ctx, "hello")
+
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.Equal(t, tc.TraceID, entry["trace_id"])
+	assert.Equal(t, tc.SpanID, entry["span_id"])
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// Deliveries учёт обработанных доставок. Хостинги повторяют доставку при
+// таймауте или ошибке, а оператор может переотправить её вручную; по ID
+// доставки повтор обрабатывается один раз. ID доставок учитываются
+// отдельно для каждой организации из ctx.
+type Deliveries interface {
+	// Claim отмечает доставку как обрабатываемую. Возвращает false, если
+	// доставка с таким ID уже была.
+	Claim(ctx context.Context, provider, deliveryID string) (bool, error)
+	// Release снимает отметку, если обработка не удалась и доставку нужно
+	// принять повторно.
+	Release(ctx context.Context, provider, deliveryID string) error
+	// Cleanup удаляет отметки всех организаций старше olderThan.
+	Cleanup(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// MemoryDeliveries учёт в памяти процесса.
+type MemoryDeliveries struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func NewMemoryDeliveries() *MemoryDeliveries {
+	return &MemoryDeliveries{seen: make(map[string]time.Time)}
+}
+
+// Claim реализует Deliveries.
+func (d *MemoryDeliveries) Claim(ctx context.Context, provider, deliveryID string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := deliveryKey(ctx, provider, deliveryID)
+	if _, ok := d.seen[key]; ok {
+		return false, nil
+	}
+	d.seen[key] = time.Now()
+	return true, nil
+}
+
+// Release реализует Deliveries.
+func (d *MemoryDeliveries) Release(ctx context.Context, provider, deliveryID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.seen, deliveryKey(ctx, provider, deliveryID))
+	return nil
+}
+
+// deliveryKey ключ доставки в MemoryDeliveries.
+func deliveryKey(ctx context.Context, provider, deliveryID string) string {
+	return tenant.OrgID(ctx).String() + "/" + provider + "/" + deliveryID
+}
+
+// Cleanup реализует Deliveries.
+func (d *MemoryDeliveries) Cleanup(_ context.Context, olderThan time.Duration) (int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	var deleted int64
+	for key, at := range d.seen {
+		if at.Before(cutoff) {
+			delete(d.seen, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// SQLDeliveries учёт в таблице webhook_deliveries (PostgreSQL или SQLite),
+// общий для всех реплик.
+type SQLDeliveries struct {
+	db *sql.DB
+}
+
+func NewSQLDeliveries(db *sql.DB) *SQLDeliveries {
+	return &SQLDeliveries{db: db}
+}
+
+// Claim реализует Deliveries.
+func (d *SQLDeliveries) Claim(ctx context.Context, provider, deliveryID string) (bool, error) {
+	res, err := d.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (provider, delivery_id, received_at, org_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_id, provider, delivery_id) DO NOTHING
+	`, provider, deliveryID, time.Now().UTC(), tenant.OrgID(ctx).String())
+	if err != nil {
+		return false, fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	return n == 1, nil
+}
+
+// Release реализует Deliveries.
+func (d *SQLDeliveries) Release(ctx context.Context, provider, deliveryID string) error {
+	_, err := d.db.ExecContext(ctx, `
+		DELETE FROM webhook_deliveries WHERE provider = $1 AND delivery_id = $2 AND org_id = $3
+	`, provider, deliveryID, tenant.OrgID(ctx).String())
+	if err != nil {
+		return fmt.Errorf("failed to release webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// Cleanup реализует Deliveries.
+func (d *SQLDeliveries) Cleanup(ctx context.Context, olderThan time.Duration) (int64, error) {
+	res, err := d.db.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE received_at < $1`, time.Now().UTC().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup webhook deliveries: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// CleanupDeliveries периодически удаляет отметки старше retention, пока
+// не отменён ctx. Повтор доставки старше retention будет обработан снова.
+func CleanupDeliveries(ctx context.Context, d Deliveries, retention time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := d.Cleanup(ctx, retention)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to cleanup webhook deliveries", "error", err)
+			continue
+		}
+		slog.DebugContext(ctx, "Webhook deliveries cleaned up", "deleted", deleted)
+	}
+}
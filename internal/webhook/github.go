@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/T1mof/pr-reviewer-service/internal/codehost"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// ProviderGitHub имя хостинга в журнале доставок и ID pull request'ов.
+const ProviderGitHub = "github"
+
+// GitHub обрабатывает webhook'и pull_request GitHub:
+//   - opened (кроме draft) и ready_for_review → CreatePR;
+//   - closed с merged=true → MergePR.
+//
+// Остальные события и действия подтверждаются без изменений.
+type GitHub struct {
+	service    service.ServiceInterface
+	secret     []byte
+	mapping    GitHubMapping
+	accounts   Accounts
+	deliveries Deliveries
+	links      codehost.Links
+}
+
+func NewGitHub(svc service.ServiceInterface, secret string, mapping *Mapping, deliveries Deliveries, opts ...Option) *GitHub {
+	o := newOptions(opts)
+	return &GitHub{
+		service:    svc,
+		secret:     []byte(secret),
+		mapping:    mapping.GitHub,
+		accounts:   mapping.Accounts(ProviderGitHub),
+		deliveries: deliveries,
+		links:      o.links,
+	}
+}
+
+// VerifySignature проверяет заголовок X-Hub-Signature-256 — HMAC-SHA256
+// тела запроса с секретом webhook'а.
+func (g *GitHub) VerifySignature(body []byte, signature string) error {
+	sig, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return errors.New("INVALID_SIGNATURE")
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return errors.New("INVALID_SIGNATURE")
+	}
+
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("INVALID_SIGNATURE")
+	}
+	return nil
+}
+
+// gitHubPullRequestEvent поля события pull_request, которые использует сервис.
+type gitHubPullRequestEvent struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		ID     int64  `json:"id"`
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		ID       int64  `json:"id"`
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// Handle обрабатывает доставку с проверенной подписью. event — заголовок
+// X-GitHub-Event, deliveryID — X-GitHub-Delivery.
+func (g *GitHub) Handle(ctx context.Context, deliveryID, event string, body []byte) (*Result, error) {
+	// Токен хостинга не связан с организацией: webhook'и работают с
+	// организацией по умолчанию.
+	ctx = tenant.WithDefault(ctx)
+
+	switch event {
+	case "ping":
+		return &Result{Action: ActionIgnored, Reason: "ping"}, nil
+	case "pull_request":
+	default:
+		return &Result{Action: ActionIgnored, Reason: fmt.Sprintf("event %q is not handled", event)}, nil
+	}
+
+	var payload gitHubPullRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		slog.WarnContext(ctx, "Invalid GitHub webhook payload", "delivery_id", deliveryID, "error", err)
+		return nil, errors.New("INVALID_PAYLOAD")
+	}
+	if payload.PullRequest.ID == 0 || payload.Repository.ID == 0 {
+		return nil, errors.New("INVALID_PAYLOAD")
+	}
+
+	result, err := deliver(ctx, g.deliveries, ProviderGitHub, deliveryID, func() (*Result, error) {
+		return g.handlePullRequest(ctx, &payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "GitHub webhook processed",
+		"delivery_id", deliveryID,
+		"repository", payload.Repository.FullName,
+		"number", payload.PullRequest.Number,
+		"action", payload.Action,
+		"result", result.Action,
+		"reason", result.Reason,
+	)
+	return result, nil
+}
+
+func (g *GitHub) handlePullRequest(ctx context.Context, p *gitHubPullRequestEvent) (*Result, error) {
+	team, ok := g.mapping.Repositories[p.Repository.ID]
+	if !ok {
+		return ignored("repository %s (%d) is not mapped", p.Repository.FullName, p.Repository.ID), nil
+	}
+
+	prID := PullRequestID(ProviderGitHub, strconv.FormatInt(p.PullRequest.ID, 10))
+
+	switch {
+	case p.Action == "opened" && p.PullRequest.Draft:
+		return ignored("draft pull request"), nil
+
+	case p.Action == "opened" || p.Action == "ready_for_review":
+		authorID, ok := g.accounts.UserID(p.PullRequest.User.Login)
+		if !ok {
+			return ignored("author %s is not mapped", p.PullRequest.User.Login), nil
+		}
+		if res, err := checkTeam(ctx, g.service, team, authorID); res != nil || err != nil {
+			return res, err
+		}
+		link := codehost.Link{Provider: ProviderGitHub, Repository: p.Repository.FullName, Number: int64(p.PullRequest.Number)}
+		if err := g.links.Save(ctx, prID, link); err != nil {
+			return nil, err
+		}
+		return createPR(ctx, g.service, prID, p.PullRequest.Title, authorID, team)
+
+	case p.Action == "closed" && p.PullRequest.Merged:
+		return mergePR(ctx, g.service, prID)
+
+	case p.Action == "closed":
+		return ignored("closed without merge"), nil
+
+	default:
+		return ignored("action %q is not handled", p.Action), nil
+	}
+}
@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/codehost"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newTestGitHub(t *testing.T, opts ...Option) (*GitHub, repository.RepositoryInterface) {
+	t.Helper()
+
+	repo := repository.NewMemoryRepository()
+	svc := service.NewReviewerService(repo)
+	require.NoError(t, svc.CreateTeam(context.Background(), &domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: octocat, Username: "octocat", IsActive: true},
+			{UserID: hubot, Username: "hubot", IsActive: true},
+			{UserID: monalisa, Username: "monalisa", IsActive: true},
+		},
+	}))
+
+	mapping := &Mapping{GitHub: GitHubMapping{
+		Users:        Users{"octocat": octocat},
+		Repositories: map[int64]string{1296269: "backend"},
+	}}
+	return NewGitHub(svc, "secret", mapping, NewMemoryDeliveries(), opts...), repo
+}
+
+func TestGitHub_VerifySignature(t *testing.T) {
+	g, _ := newTestGitHub(t)
+	body := fixture(t, "github/pull_request_opened.json")
+
+	assert.NoError(t, g.VerifySignature(body, sign("secret", body)))
+	assert.EqualError(t, g.VerifySignature(body, sign("other", body)), "INVALID_SIGNATURE")
+	assert.EqualError(t, g.VerifySignature(body, ""), "INVALID_SIGNATURE")
+	assert.EqualError(t, g.VerifySignature(body, "sha256=zz"), "INVALID_SIGNATURE")
+	assert.EqualError(t, g.VerifySignature(append(body, ' '), sign("secret", body)), "INVALID_SIGNATURE")
+}
+
+func TestGitHub_PullRequestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	g, repo := newTestGitHub(t)
+	prID := PullRequestID(ProviderGitHub, "1")
+
+	res, err := g.Handle(ctx, "d-1", "pull_request", fixture(t, "github/pull_request_opened.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionCreated, res.Action)
+	assert.Equal(t, prID, *res.PullRequestID)
+
+	pr, err := repo.GetPRByID(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, "Amazing new feature", pr.PullRequestName)
+	assert.Equal(t, octocat, pr.AuthorID)
+	assert.ElementsMatch(t, []uuid.UUID{hubot, monalisa}, pr.AssignedReviewers)
+
+	// Повтор той же доставки.
+	res, err = g.Handle(ctx, "d-1", "pull_request", fixture(t, "github/pull_request_opened.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionDuplicate, res.Action)
+
+	// ready_for_review после opened: PR уже есть.
+	res, err = g.Handle(ctx, "d-2", "pull_request", fixture(t, "github/pull_request_ready_for_review.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, res.Action)
+	assert.Equal(t, "pull request already exists", res.Reason)
+
+	res, err = g.Handle(ctx, "d-3", "pull_request", fixture(t, "github/pull_request_closed_merged.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionMerged, res.Action)
+
+	pr, err = repo.GetPRByID(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusMerged, pr.Status)
+}
+
+func TestGitHub_DraftThenReadyForReview(t *testing.T) {
+	ctx := context.Background()
+	g, repo := newTestGitHub(t)
+
+	res, err := g.Handle(ctx, "d-1", "pull_request", fixture(t, "github/pull_request_opened_draft.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, res.Action)
+	assert.Equal(t, "draft pull request", res.Reason)
+
+	res, err = g.Handle(ctx, "d-2", "pull_request", fixture(t, "github/pull_request_ready_for_review.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionCreated, res.Action)
+
+	_, err = repo.GetPRByID(ctx, PullRequestID(ProviderGitHub, "1"))
+	assert.NoError(t, err)
+}
+
+func TestGitHub_Ignored(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		fixture string
+		reason  string
+	}{
+		{name: "ping", event: "ping", fixture: "ping.json", reason: "ping"},
+		{name: "other event", event: "issues", fixture: "ping.json", reason: `event "issues" is not handled`},
+		{name: "closed without merge", event: "pull_request", fixture: "pull_request_closed.json", reason: "closed without merge"},
+		{name: "unmapped repository", event: "pull_request", fixture: "pull_request_opened_unmapped_repo.json", reason: "repository octocat/linguist (64778136) is not mapped"},
+		{name: "merge of untracked PR", event: "pull_request", fixture: "pull_request_closed_merged.json", reason: "pull request is not tracked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := newTestGitHub(t)
+
+			res, err := g.Handle(context.Background(), "d-1", tt.event, fixture(t, "github/"+tt.fixture))
+			require.NoError(t, err)
+			assert.Equal(t, ActionIgnored, res.Action)
+			assert.Equal(t, tt.reason, res.Reason)
+		})
+	}
+}
+
+func TestGitHub_UnmappedAuthor(t *testing.T) {
+	g, _ := newTestGitHub(t)
+	delete(g.mapping.Users, "octocat")
+
+	res, err := g.Handle(context.Background(), "d-1", "pull_request", fixture(t, "github/pull_request_opened.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, res.Action)
+	assert.Equal(t, "author octocat is not mapped", res.Reason)
+}
+
+func TestGitHub_AuthorOutsideMappedTeam(t *testing.T) {
+	ctx := context.Background()
+	g, repo := newTestGitHub(t)
+
+	g.mapping.Repositories[1296269] = "frontend"
+	res, err := g.Handle(ctx, "d-1", "pull_request", fixture(t, "github/pull_request_opened.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, res.Action)
+	assert.Equal(t, "team frontend not found", res.Reason)
+
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{
+		TeamName: "frontend",
+		Members:  []domain.TeamMember{{UserID: uuid.New(), Username: "alice", IsActive: true}},
+	}))
+	res, err = g.Handle(ctx, "d-2", "pull_request", fixture(t, "github/pull_request_opened.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, res.Action)
+	assert.Equal(t, "author "+octocat.String()+" is not a member of team frontend", res.Reason)
+
+	_, err = repo.GetPRByID(ctx, PullRequestID(ProviderGitHub, "1"))
+	assert.EqualError(t, err, "PR_NOT_FOUND")
+}
+
+func TestGitHub_NoSeniorCandidate(t *testing.T) {
+	ctx := context.Background()
+	g, repo := newTestGitHub(t)
+	require.NoError(t, repo.SetSeniorityRule(ctx, "backend", &domain.SeniorityRule{Level: domain.SenioritySenior, Count: 1}))
+
+	res, err := g.Handle(ctx, "d-1", "pull_request", fixture(t, "github/pull_request_opened.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, res.Action)
+	assert.Equal(t, "NO_SENIOR_CANDIDATE: no reviewers available in team backend", res.Reason)
+
+	_, err = repo.GetPRByID(ctx, PullRequestID(ProviderGitHub, "1"))
+	assert.EqualError(t, err, "PR_NOT_FOUND")
+}
+
+func TestGitHub_InvalidPayload(t *testing.T) {
+	g, _ := newTestGitHub(t)
+
+	_, err := g.Handle(context.Background(), "d-1", "pull_request", []byte(`{"action":`))
+	assert.EqualError(t, err, "INVALID_PAYLOAD")
+
+	_, err = g.Handle(context.Background(), "d-2", "pull_request", []byte(`{"action":"opened"}`))
+	assert.EqualError(t, err, "INVALID_PAYLOAD")
+}
+
+func TestGitHub_SavesLink(t *testing.T) {
+	ctx := context.Background()
+	links := codehost.NewMemoryLinks()
+	g, _ := newTestGitHub(t, WithLinks(links))
+
+	_, err := g.Handle(ctx, "d-1", "pull_request", fixture(t, "github/pull_request_opened.json"))
+	require.NoError(t, err)
+
+	link, err := links.Get(ctx, PullRequestID(ProviderGitHub, "1"))
+	require.NoError(t, err)
+	assert.Equal(t, codehost.Link{Provider: ProviderGitHub, Repository: "octocat/Hello-World", Number: 1347}, *link)
+}
@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/T1mof/pr-reviewer-service/internal/codehost"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+	"github.com/T1mof/pr-reviewer-service/internal/tenant"
+)
+
+// ProviderGitLab имя хостинга в журнале доставок и ID pull request'ов.
+const ProviderGitLab = "gitlab"
+
+// GitLab обрабатывает webhook'и Merge Request Hook GitLab:
+//   - open, reopen и update (кроме draft) → CreatePR; повтор для уже
+//     созданного MR ничего не меняет, поэтому MR, открытые до подключения
+//     webhook'а, подхватываются первым обновлением;
+//   - merge → MergePR.
+//
+// close подтверждается без изменений: закрытия без merge в сервисе нет.
+type GitLab struct {
+	service    service.ServiceInterface
+	token      []byte
+	mapping    GitLabMapping
+	accounts   Accounts
+	deliveries Deliveries
+	links      codehost.Links
+}
+
+func NewGitLab(svc service.ServiceInterface, token string, mapping *Mapping, deliveries Deliveries, opts ...Option) *GitLab {
+	o := newOptions(opts)
+	return &GitLab{
+		service:    svc,
+		token:      []byte(token),
+		mapping:    mapping.GitLab,
+		accounts:   mapping.Accounts(ProviderGitLab),
+		deliveries: deliveries,
+		links:      o.links,
+	}
+}
+
+// VerifyToken проверяет заголовок X-Gitlab-Token — секретный токен,
+// заданный в настройках webhook'а.
+func (g *GitLab) VerifyToken(token string) error {
+	if subtle.ConstantTimeCompare([]byte(token), g.token) != 1 {
+		return errors.New("INVALID_SIGNATURE")
+	}
+	return nil
+}
+
+// GitLabMergeRequestID ключ MR для PullRequestID: путь проекта и IID.
+func GitLabMergeRequestID(projectPath string, iid int64) string {
+	return fmt.Sprintf("%s!%d", strings.ToLower(projectPath), iid)
+}
+
+// gitLabMergeRequestEvent поля события merge_request, которые использует сервис.
+type gitLabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	// User кто вызвал событие, не обязательно автор MR.
+	User struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		ID                int64  `json:"id"`
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID            int64  `json:"iid"`
+		Title          string `json:"title"`
+		State          string `json:"state"`
+		Action         string `json:"action"`
+		AuthorID       int64  `json:"author_id"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+}
+
+// Handle обрабатывает доставку с проверенным токеном. event — заголовок
+// X-Gitlab-Event, deliveryID — X-Gitlab-Event-UUID (старые версии GitLab
+// его не отправляют).
+func (g *GitLab) Handle(ctx context.Context, deliveryID, event string, body []byte) (*Result, error) {
+	// Токен хостинга не связан с организацией: webhook'и работают с
+	// организацией по умолчанию.
+	ctx = tenant.WithDefault(ctx)
+
+	if event != "Merge Request Hook" {
+		return ignored("event %q is not handled", event), nil
+	}
+
+	var payload gitLabMergeRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		slog.WarnContext(ctx, "Invalid GitLab webhook payload", "delivery_id", deliveryID, "error", err)
+		return nil, errors.New("INVALID_PAYLOAD")
+	}
+	if payload.ObjectKind != "merge_request" || payload.ObjectAttributes.IID == 0 || payload.Project.PathWithNamespace == "" {
+		return nil, errors.New("INVALID_PAYLOAD")
+	}
+
+	result, err := deliver(ctx, g.deliveries, ProviderGitLab, deliveryID, func() (*Result, error) {
+		return g.handleMergeRequest(ctx, &payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "GitLab webhook processed",
+		"delivery_id", deliveryID,
+		"project", payload.Project.PathWithNamespace,
+		"iid", payload.ObjectAttributes.IID,
+		"action", payload.ObjectAttributes.Action,
+		"result", result.Action,
+		"reason", result.Reason,
+	)
+	return result, nil
+}
+
+func (g *GitLab) handleMergeRequest(ctx context.Context, p *gitLabMergeRequestEvent) (*Result, error) {
+	mr := &p.ObjectAttributes
+	team, ok := g.mapping.Projects[strings.ToLower(p.Project.PathWithNamespace)]
+	if !ok {
+		return ignored("project %s is not mapped", p.Project.PathWithNamespace), nil
+	}
+
+	prID := PullRequestID(ProviderGitLab, GitLabMergeRequestID(p.Project.PathWithNamespace, mr.IID))
+
+	switch mr.Action {
+	case "open", "reopen", "update":
+		if mr.State != "opened" {
+			return ignored("merge request is %s", mr.State), nil
+		}
+		if mr.Draft || mr.WorkInProgress {
+			return ignored("draft merge request"), nil
+		}
+
+		// В событии есть только ID автора; логин известен, если событие
+		// вызвал сам автор.
+		if p.User.ID != mr.AuthorID {
+			return ignored("author %d is not the event user %s", mr.AuthorID, p.User.Username), nil
+		}
+		authorID, ok := g.accounts.UserID(p.User.Username)
+		if !ok {
+			return ignored("author %s is not mapped", p.User.Username), nil
+		}
+		if res, err := checkTeam(ctx, g.service, team, authorID); res != nil || err != nil {
+			return res, err
+		}
+		link := codehost.Link{Provider: ProviderGitLab, Repository: p.Project.PathWithNamespace, Number: mr.IID}
+		if err := g.links.Save(ctx, prID, link); err != nil {
+			return nil, err
+		}
+		return createPR(ctx, g.service, prID, mr.Title, authorID, team)
+
+	case "merge":
+		return mergePR(ctx, g.service, prID)
+
+	case "close":
+		return ignored("closed without merge"), nil
+
+	default:
+		return ignored("action %q is not handled", mr.Action), nil
+	}
+}
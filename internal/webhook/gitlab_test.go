@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/codehost"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+func newTestGitLab(t *testing.T, opts ...Option) (*GitLab, repository.RepositoryInterface) {
+	t.Helper()
+
+	repo := repository.NewMemoryRepository()
+	svc := service.NewReviewerService(repo)
+	require.NoError(t, svc.CreateTeam(context.Background(), &domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: octocat, Username: "root", IsActive: true},
+			{UserID: hubot, Username: "jsmith", IsActive: true},
+			{UserID: monalisa, Username: "monalisa", IsActive: true},
+		},
+	}))
+
+	mapping := &Mapping{GitLab: GitLabMapping{
+		Users:    Users{"root": octocat, "jsmith": hubot},
+		Projects: map[string]string{"gitlabhq/gitlab-test": "backend"},
+	}}
+	return NewGitLab(svc, "token", mapping, NewMemoryDeliveries(), opts...), repo
+}
+
+func TestGitLab_VerifyToken(t *testing.T) {
+	g, _ := newTestGitLab(t)
+
+	assert.NoError(t, g.VerifyToken("token"))
+	assert.EqualError(t, g.VerifyToken("other"), "INVALID_SIGNATURE")
+	assert.EqualError(t, g.VerifyToken(""), "INVALID_SIGNATURE")
+}
+
+func TestGitLab_MergeRequestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	g, repo := newTestGitLab(t)
+	prID := PullRequestID(ProviderGitLab, "gitlabhq/gitlab-test!1")
+
+	res, err := g.Handle(ctx, "e-1", "Merge Request Hook", fixture(t, "gitlab/merge_request_open.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionCreated, res.Action)
+	assert.Equal(t, prID, *res.PullRequestID)
+
+	pr, err := repo.GetPRByID(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, "MS-Viewport", pr.PullRequestName)
+	assert.Equal(t, octocat, pr.AuthorID)
+	assert.ElementsMatch(t, []uuid.UUID{hubot, monalisa}, pr.AssignedReviewers)
+
+	// Повтор доставки и обновление MR без повторной доставки.
+	res, err = g.Handle(ctx, "e-1", "Merge Request Hook", fixture(t, "gitlab/merge_request_open.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionDuplicate, res.Action)
+
+	res, err = g.Handle(ctx, "", "Merge Request Hook", fixture(t, "gitlab/merge_request_update_ready.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, res.Action)
+	assert.Equal(t, "pull request already exists", res.Reason)
+
+	res, err = g.Handle(ctx, "e-2", "Merge Request Hook", fixture(t, "gitlab/merge_request_merge.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionMerged, res.Action)
+
+	pr, err = repo.GetPRByID(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusMerged, pr.Status)
+
+	// Без X-Gitlab-Event-UUID повтор безопасен: merge идемпотентен.
+	res, err = g.Handle(ctx, "", "Merge Request Hook", fixture(t, "gitlab/merge_request_merge.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionMerged, res.Action)
+}
+
+func TestGitLab_DraftThenReady(t *testing.T) {
+	ctx := context.Background()
+	g, _ := newTestGitLab(t)
+
+	res, err := g.Handle(ctx, "e-1", "Merge Request Hook", fixture(t, "gitlab/merge_request_open_draft.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, res.Action)
+	assert.Equal(t, "draft merge request", res.Reason)
+
+	res, err = g.Handle(ctx, "e-2", "Merge Request Hook", fixture(t, "gitlab/merge_request_update_ready.json"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionCreated, res.Action)
+}
+
+func TestGitLab_Ignored(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		fixture string
+		reason  string
+	}{
+		{name: "other event", event: "Push Hook", fixture: "merge_request_open.json", reason: `event "Push Hook" is not handled`},
+		{name: "close", event: "Merge Request Hook", fixture: "merge_request_close.json", reason: "closed without merge"},
+		{name: "unmapped project", event: "Merge Request Hook", fixture: "merge_request_open_unmapped_project.json", reason: "project gitlabhq/gitlab-shell is not mapped"},
+		{name: "update by other user", event: "Merge Request Hook", fixture: "merge_request_update_by_other_user.json", reason: "author 1 is not the event user jsmith"},
+		{name: "merge of untracked MR", event: "Merge Request Hook", fixture: "merge_request_merge.json", reason: "pull request is not tracked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := newTestGitLab(t)
+
+			res, err := g.Handle(context.Background(), "e-1", tt.event, fixture(t, "gitlab/"+tt.fixture))
+			require.NoError(t, err)
+			assert.Equal(t, ActionIgnored, res.Action)
+			assert.Equal(t, tt.reason, res.Reason)
+		})
+	}
+}
+
+func TestGitLab_InvalidPayload(t *testing.T) {
+	g, _ := newTestGitLab(t)
+
+	_, err := g.Handle(context.Background(), "e-1", "Merge Request Hook", []byte(`{"object_kind":`))
+	assert.EqualError(t, err, "INVALID_PAYLOAD")
+
+	_, err = g.Handle(context.Background(), "e-2", "Merge Request Hook", fixture(t, "github/pull_request_opened.json"))
+	assert.EqualError(t, err, "INVALID_PAYLOAD")
+}
+
+func TestGitLabMergeRequestID(t *testing.T) {
+	// Путь проекта без учёта регистра: одно и то же событие даёт один PR.
+	assert.Equal(t,
+		PullRequestID(ProviderGitLab, GitLabMergeRequestID("GitLabHQ/GitLab-Test", 1)),
+		PullRequestID(ProviderGitLab, GitLabMergeRequestID("gitlabhq/gitlab-test", 1)),
+	)
+	assert.NotEqual(t,
+		PullRequestID(ProviderGitLab, GitLabMergeRequestID("gitlabhq/gitlab-test", 1)),
+		PullRequestID(ProviderGitLab, GitLabMergeRequestID("gitlabhq/gitlab-test", 2)),
+	)
+}
+
+func TestGitLab_SavesLink(t *testing.T) {
+	ctx := context.Background()
+	links := codehost.NewMemoryLinks()
+	g, _ := newTestGitLab(t, WithLinks(links))
+
+	_, err := g.Handle(ctx, "e-1", "Merge Request Hook", fixture(t, "gitlab/merge_request_open.json"))
+	require.NoError(t, err)
+
+	link, err := links.Get(ctx, PullRequestID(ProviderGitLab, "gitlabhq/gitlab-test!1"))
+	require.NoError(t, err)
+	assert.Equal(t, codehost.Link{Provider: ProviderGitLab, Repository: "gitlabhq/gitlab-test", Number: 1}, *link)
+}
@@ -0,0 +1,329 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// Mapping сопоставление учётных записей и репозиториев хостингов
+// пользователям и командам сервиса. Пример файла:
+//
+//	github:
+//	  users:
+//	    octocat: 550e8400-e29b-41d4-a716-446655440001
+//	  repositories:
+//	    1296269: backend
+//	gitlab:
+//	  users:
+//	    jdoe: 550e8400-e29b-41d4-a716-446655440002
+//	  projects:
+//	    platform/billing: payments
+//	slack:
+//	  users:
+//	    U012AB3CD: 550e8400-e29b-41d4-a716-446655440001
+//
+// Логины читаются через Accounts и Logins; Forget удаляет их и из памяти,
+// и из файла, из которого сопоставление загружено.
+type Mapping struct {
+	GitHub GitHubMapping `yaml:"github"`
+	GitLab GitLabMapping `yaml:"gitlab"`
+	Slack  SlackMapping  `yaml:"slack"`
+
+	// path файл, из которого загружено сопоставление; пустой, если оно
+	// создано в коде.
+	path string
+	mu   sync.RWMutex
+}
+
+// ProviderSlack имя Slack в Accounts и Logins.
+const ProviderSlack = "slack"
+
+// Users логин (без учёта регистра) → user_id.
+type Users map[string]uuid.UUID
+
+// GitHubMapping сопоставление для GitHub.
+type GitHubMapping struct {
+	Users Users `yaml:"users"`
+	// Repositories числовой ID репозитория → команда. События репозиториев,
+	// которых нет в списке, игнорируются, как и PR авторов не из этой
+	// команды.
+	Repositories map[int64]string `yaml:"repositories"`
+}
+
+// GitLabMapping сопоставление для GitLab.
+type GitLabMapping struct {
+	Users Users `yaml:"users"`
+	// Projects путь проекта с группой (path_with_namespace, без учёта
+	// регистра) → команда. События проектов, которых нет в списке,
+	// игнорируются, как и MR авторов не из этой команды.
+	Projects map[string]string `yaml:"projects"`
+}
+
+// SlackMapping сопоставление для slash-команд Slack.
+type SlackMapping struct {
+	// Users ID пользователя Slack (U012AB3CD) → user_id.
+	Users Users `yaml:"users"`
+}
+
+// LoadMapping читает файл сопоставления в YAML (или JSON).
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var m Mapping
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", path, err)
+	}
+
+	if err := m.normalize(); err != nil {
+		return nil, fmt.Errorf("invalid mapping file %s: %w", path, err)
+	}
+	m.path = path
+	return &m, nil
+}
+
+// normalize приводит логины и пути к нижнему регистру и проверяет значения.
+func (m *Mapping) normalize() error {
+	var err error
+	if m.GitHub.Users, err = m.GitHub.Users.normalize("github"); err != nil {
+		return err
+	}
+	for id, team := range m.GitHub.Repositories {
+		if strings.TrimSpace(team) == "" {
+			return fmt.Errorf("github.repositories.%d: team is required", id)
+		}
+	}
+
+	if m.GitLab.Users, err = m.GitLab.Users.normalize("gitlab"); err != nil {
+		return err
+	}
+	projects := make(map[string]string, len(m.GitLab.Projects))
+	for path, team := range m.GitLab.Projects {
+		if strings.TrimSpace(team) == "" {
+			return fmt.Errorf("gitlab.projects.%s: team is required", path)
+		}
+		projects[strings.ToLower(path)] = team
+	}
+	m.GitLab.Projects = projects
+
+	if m.Slack.Users, err = m.Slack.Users.normalize("slack"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u Users) normalize(provider string) (Users, error) {
+	users := make(Users, len(u))
+	for login, userID := range u {
+		key := strings.ToLower(login)
+		if userID == uuid.Nil {
+			return nil, fmt.Errorf("%s.users.%s: user_id is required", provider, login)
+		}
+		if _, dup := users[key]; dup {
+			return nil, fmt.Errorf("%s.users.%s: duplicate login", provider, login)
+		}
+		users[key] = userID
+	}
+	return users, nil
+}
+
+// Accounts логины одного провайдера из Mapping.
+type Accounts struct {
+	mapping  *Mapping
+	provider string
+}
+
+// Accounts логины провайдера: ProviderGitHub, ProviderGitLab или
+// ProviderSlack.
+func (m *Mapping) Accounts(provider string) Accounts {
+	return Accounts{mapping: m, provider: provider}
+}
+
+// UserID возвращает user_id по логину без учёта регистра.
+func (a Accounts) UserID(login string) (uuid.UUID, bool) {
+	if a.mapping == nil {
+		return uuid.Nil, false
+	}
+
+	a.mapping.mu.RLock()
+	defer a.mapping.mu.RUnlock()
+	return a.mapping.users(a.provider).lookup(login)
+}
+
+// Login возвращает логин пользователя в нижнем регистре.
+func (a Accounts) Login(userID uuid.UUID) (string, bool) {
+	if a.mapping == nil {
+		return "", false
+	}
+
+	a.mapping.mu.RLock()
+	defer a.mapping.mu.RUnlock()
+	for login, id := range a.mapping.users(a.provider) {
+		if id == userID {
+			return login, true
+		}
+	}
+	return "", false
+}
+
+// Logins логины пользователя по провайдерам, отсортированные.
+func (m *Mapping) Logins(userID uuid.UUID) map[string][]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	logins := map[string][]string{}
+	for _, provider := range []string{ProviderGitHub, ProviderGitLab, ProviderSlack} {
+		for login, id := range m.users(provider) {
+			if id == userID {
+				logins[provider] = append(logins[provider], login)
+			}
+		}
+		sort.Strings(logins[provider])
+	}
+	return logins
+}
+
+// Forget удаляет логины пользователя у всех провайдеров и возвращает их
+// число. Если сопоставление загружено из файла, логины сначала удаляются
+// из него, чтобы не вернуться после перезапуска: файл переписывается с
+// сохранением комментариев. Если записать его не удалось, логины остаются
+// на месте и возвращается ошибка.
+func (m *Mapping) Forget(userID uuid.UUID) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var removed int
+	for _, provider := range []string{ProviderGitHub, ProviderGitLab, ProviderSlack} {
+		for _, id := range m.users(provider) {
+			if id == userID {
+				removed++
+			}
+		}
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+
+	if m.path != "" {
+		if err := forgetInFile(m.path, userID); err != nil {
+			return 0, fmt.Errorf("failed to remove logins from mapping file %s: %w", m.path, err)
+		}
+	}
+
+	for _, provider := range []string{ProviderGitHub, ProviderGitLab, ProviderSlack} {
+		users := m.users(provider)
+		for login, id := range users {
+			if id == userID {
+				delete(users, login)
+			}
+		}
+	}
+	return removed, nil
+}
+
+// forgetInFile удаляет из секций users файла сопоставления логины с
+// userID. Файл заменяется атомарно через временный файл рядом с ним.
+func forgetInFile(path string, userID uuid.UUID) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	for _, provider := range []string{ProviderGitHub, ProviderGitLab, ProviderSlack} {
+		users := mappingValue(mappingValue(doc.Content[0], provider), "users")
+		if users == nil {
+			continue
+		}
+		kept := users.Content[:0]
+		for i := 0; i+1 < len(users.Content); i += 2 {
+			if id, err := uuid.Parse(users.Content[i+1].Value); err == nil && id == userID {
+				continue
+			}
+			kept = append(kept, users.Content[i], users.Content[i+1])
+		}
+		users.Content = kept
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// mappingValue значение ключа key узла-словаря или nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func (m *Mapping) users(provider string) Users {
+	switch provider {
+	case ProviderGitHub:
+		return m.GitHub.Users
+	case ProviderGitLab:
+		return m.GitLab.Users
+	case ProviderSlack:
+		return m.Slack.Users
+	default:
+		return nil
+	}
+}
+
+// lookup возвращает user_id по логину.
+func (u Users) lookup(login string) (uuid.UUID, bool) {
+	userID, ok := u[strings.ToLower(login)]
+	return userID, ok
+}
@@ -0,0 +1,184 @@
+// Package webhook принимает события code review хостингов и переводит их
+// в операции сервиса: открытие PR создаёт его с назначением ревьюверов,
+// merge закрывает.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/codehost"
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+// Option настраивает необязательные зависимости обработчиков webhook'ов.
+type Option func(*options)
+
+type options struct {
+	links codehost.Links
+}
+
+// WithLinks записывает связь создаваемого PR с pull/merge request'ом
+// хостинга, чтобы назначенные ревьюверы можно было отправить обратно.
+func WithLinks(links codehost.Links) Option {
+	return func(o *options) {
+		o.links = links
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{links: noopLinks{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+type noopLinks struct{}
+
+func (noopLinks) Save(context.Context, uuid.UUID, codehost.Link) error { return nil }
+
+func (noopLinks) Get(context.Context, uuid.UUID) (*codehost.Link, error) {
+	return nil, errors.New("LINK_NOT_FOUND")
+}
+
+// Итоги обработки доставки.
+const (
+	ActionCreated   = "created"
+	ActionMerged    = "merged"
+	ActionIgnored   = "ignored"
+	ActionDuplicate = "duplicate"
+)
+
+// Result итог обработки доставки, возвращается хостингу в теле ответа
+// и виден в истории доставок.
+type Result struct {
+	Action        string     `json:"action"`
+	PullRequestID *uuid.UUID `json:"pull_request_id,omitempty"`
+	Reason        string     `json:"reason,omitempty"`
+}
+
+// pullRequestNamespace пространство имён UUIDv5 для ID pull request'ов хостингов.
+var pullRequestNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/T1mof/pr-reviewer-service/webhook"))
+
+// PullRequestID детерминированно выводит pull_request_id сервиса из ключа
+// pull request'а на хостинге, поэтому открытие и merge одного PR
+// попадают в одну запись без хранения соответствия.
+func PullRequestID(provider, key string) uuid.UUID {
+	return uuid.NewSHA1(pullRequestNamespace, []byte(fmt.Sprintf("%s:pull_request:%s", provider, key)))
+}
+
+// deliver обрабатывает доставку один раз. Без deliveryID повтор не
+// отсекается, и идемпотентность обеспечивают сами операции. Если обработка
+// не удалась, доставка не считается принятой и может быть повторена.
+func deliver(ctx context.Context, deliveries Deliveries, provider, deliveryID string, handle func() (*Result, error)) (*Result, error) {
+	if deliveryID != "" {
+		claimed, err := deliveries.Claim(ctx, provider, deliveryID)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			slog.InfoContext(ctx, "Duplicate webhook delivery", "provider", provider, "delivery_id", deliveryID)
+			return &Result{Action: ActionDuplicate}, nil
+		}
+	}
+
+	result, err := handle()
+	if err != nil && deliveryID != "" {
+		if releaseErr := deliveries.Release(ctx, provider, deliveryID); releaseErr != nil {
+			slog.ErrorContext(ctx, "Failed to release webhook delivery", "provider", provider, "delivery_id", deliveryID, "error", releaseErr)
+		}
+	}
+	return result, err
+}
+
+// checkTeam проверяет, что автор состоит в команде, сопоставленной
+// репозиторию: ревьюверы выбираются из команды автора, и без проверки PR
+// из чужого репозитория получил бы ревьюверов другой команды. Возвращает
+// итог ignored, если автор не в команде или команды нет, и nil, если PR
+// можно создавать.
+func checkTeam(ctx context.Context, svc service.ServiceInterface, team string, authorID uuid.UUID) (*Result, error) {
+	t, err := svc.GetTeam(ctx, team)
+	if err != nil {
+		if errorCode(err) == "TEAM_NOT_FOUND" {
+			return ignored("team %s not found", team), nil
+		}
+		return nil, err
+	}
+
+	for _, m := range t.Members {
+		if m.UserID == authorID {
+			return nil, nil
+		}
+	}
+	return ignored("author %s is not a member of team %s", authorID, team), nil
+}
+
+// createPR создаёт PR. Отказы сервиса (PR уже есть, автор не найден, нет
+// кандидатов) — итог обработки, а не ошибка: повтор доставки их не исправит.
+func createPR(ctx context.Context, svc service.ServiceInterface, prID uuid.UUID, title string, authorID uuid.UUID, team string) (*Result, error) {
+	pr, err := svc.CreatePR(ctx, prID, truncate(title, maxTitleLength), authorID)
+	if err != nil {
+		switch code := errorCode(err); {
+		case code == "PR_EXISTS":
+			return &Result{Action: ActionIgnored, PullRequestID: &prID, Reason: "pull request already exists"}, nil
+		case code == "USER_NOT_FOUND":
+			return ignored("author %s not found", authorID), nil
+		case code == "NO_CANDIDATE", code == "NO_SENIOR_CANDIDATE":
+			// Повтор доставки не поможет: нужно поменять состав или правило команды.
+			return &Result{Action: ActionIgnored, PullRequestID: &prID, Reason: fmt.Sprintf("%s: no reviewers available in team %s", code, team)}, nil
+		case errors.Is(err, domain.ErrValidation):
+			return &Result{Action: ActionIgnored, PullRequestID: &prID, Reason: err.Error()}, nil
+		default:
+			return nil, err
+		}
+	}
+
+	slog.InfoContext(ctx, "PR created from webhook", "pr_id", prID, "team", team, "reviewers_count", len(pr.AssignedReviewers))
+	return &Result{Action: ActionCreated, PullRequestID: &prID}, nil
+}
+
+// mergePR отмечает PR смёрженным. PR, открытые до подключения webhook'а,
+// сервису неизвестны и пропускаются.
+func mergePR(ctx context.Context, svc service.ServiceInterface, prID uuid.UUID) (*Result, error) {
+	if _, err := svc.MergePR(ctx, prID); err != nil {
+		if errorCode(err) == "PR_NOT_FOUND" {
+			return &Result{Action: ActionIgnored, PullRequestID: &prID, Reason: "pull request is not tracked"}, nil
+		}
+		return nil, err
+	}
+	return &Result{Action: ActionMerged, PullRequestID: &prID}, nil
+}
+
+// maxTitleLength ограничение pull_request_name в сервисе, в байтах.
+const maxTitleLength = 255
+
+// truncate обрезает s до n байт, не разрывая символы UTF-8.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func ignored(format string, args ...any) *Result {
+	return &Result{Action: ActionIgnored, Reason: fmt.Sprintf(format, args...)}
+}
+
+// errorCode возвращает код ошибки сервиса или репозитория ("PR_EXISTS",
+// "USER_NOT_FOUND"), в том числе обёрнутой через fmt.Errorf.
+func errorCode(err error) string {
+	for errors.Unwrap(err) != nil {
+		err = errors.Unwrap(err)
+	}
+	return err.Error()
+}
@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/config"
+)
+
+var (
+	octocat  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	hubot    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	monalisa = uuid.MustParse("550e8400-e29b-41d4-a716-446655440003")
+)
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 5))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	// "ж" занимает 2 байта и не разрывается.
+	assert.Equal(t, "aж", truncate("aжж", 4))
+	assert.LessOrEqual(t, len(truncate(strings.Repeat("ж", 200), maxTitleLength)), maxTitleLength)
+}
+
+func TestLoadMapping(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "mapping.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	m, err := LoadMapping(write(`
+github:
+  users:
+    OctoCat: 550e8400-e29b-41d4-a716-446655440001
+  repositories:
+    1296269: backend
+gitlab:
+  users:
+    jsmith: 550e8400-e29b-41d4-a716-446655440002
+  projects:
+    GitLabHQ/GitLab-Test: backend
+slack:
+  users:
+    U012AB3CD: 550e8400-e29b-41d4-a716-446655440003
+`))
+	require.NoError(t, err)
+	userID, ok := m.GitHub.Users.lookup("octocat")
+	assert.True(t, ok)
+	assert.Equal(t, octocat, userID)
+	assert.Equal(t, "backend", m.GitHub.Repositories[1296269])
+	assert.Equal(t, hubot, m.GitLab.Users["jsmith"])
+	assert.Equal(t, "backend", m.GitLab.Projects["gitlabhq/gitlab-test"])
+	assert.Equal(t, monalisa, m.Slack.Users["u012ab3cd"])
+
+	_, err = LoadMapping(write("github:\n  teams: {}\n"))
+	assert.ErrorContains(t, err, "field teams not found")
+
+	_, err = LoadMapping(write("github:\n  users:\n    octocat: not-a-uuid\n"))
+	assert.Error(t, err)
+
+	_, err = LoadMapping(write("github:\n  repositories:\n    1: \"\"\n"))
+	assert.ErrorContains(t, err, "github.repositories.1: team is required")
+}
+
+func TestMapping_Forget(t *testing.T) {
+	m := &Mapping{
+		GitHub: GitHubMapping{Users: Users{"octocat": octocat, "hubot": hubot}},
+		Slack:  SlackMapping{Users: Users{"u012ab3cd": octocat}},
+	}
+
+	userID, ok := m.Accounts(ProviderGitHub).UserID("OctoCat")
+	assert.True(t, ok)
+	assert.Equal(t, octocat, userID)
+	login, ok := m.Accounts(ProviderSlack).Login(octocat)
+	assert.True(t, ok)
+	assert.Equal(t, "u012ab3cd", login)
+	assert.Equal(t, map[string][]string{"github": {"octocat"}, "slack": {"u012ab3cd"}}, m.Logins(octocat))
+
+	n, err := m.Forget(octocat)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	_, ok = m.Accounts(ProviderGitHub).UserID("octocat")
+	assert.False(t, ok)
+	_, ok = m.Accounts(ProviderSlack).Login(octocat)
+	assert.False(t, ok)
+	assert.Empty(t, m.Logins(octocat))
+	assert.Equal(t, map[string][]string{"github": {"hubot"}}, m.Logins(hubot))
+	n, err = m.Forget(octocat)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestMapping_ForgetRewritesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`# Сопоставление логинов
+github:
+  users:
+    OctoCat: 550e8400-e29b-41d4-a716-446655440001 # уволился
+    hubot: 550e8400-e29b-41d4-a716-446655440002
+  repositories:
+    1296269: backend
+slack:
+  users:
+    U012AB3CD: 550e8400-e29b-41d4-a716-446655440001
+`), 0o640))
+
+	m, err := LoadMapping(path)
+	require.NoError(t, err)
+	n, err := m.Forget(octocat)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), octocat.String())
+	assert.Contains(t, string(data), "# Сопоставление логинов")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	// После перезапуска логины не возвращаются.
+	reloaded, err := LoadMapping(path)
+	require.NoError(t, err)
+	assert.Empty(t, reloaded.Logins(octocat))
+	assert.Equal(t, map[string][]string{"github": {"hubot"}}, reloaded.Logins(hubot))
+	assert.Equal(t, "backend", reloaded.GitHub.Repositories[1296269])
+}
+
+func TestMapping_ForgetKeepsLoginsWhenFileFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("github:\n  users:\n    octocat: 550e8400-e29b-41d4-a716-446655440001\n"), 0o600))
+	m, err := LoadMapping(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(path))
+
+	_, err = m.Forget(octocat)
+	assert.ErrorContains(t, err, "failed to remove logins from mapping file")
+	_, ok := m.Accounts(ProviderGitHub).UserID("octocat")
+	assert.True(t, ok)
+}
+
+func TestDeliveries(t *testing.T) {
+	stores := map[string]func(t *testing.T) Deliveries{
+		"memory": func(*testing.T) Deliveries {
+			return NewMemoryDeliveries()
+		},
+		"sqlite": func(t *testing.T) Deliveries {
+			cfg := &config.Config{DatabaseURL: "sqlite://" + filepath.Join(t.TempDir(), "test.db")}
+
+			db, err := cfg.ConnectDB()
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+
+			require.NoError(t, cfg.RunMigrations(db.DB))
+			return NewSQLDeliveries(db.DB)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			d := newStore(t)
+
+			claimed, err := d.Claim(ctx, ProviderGitHub, "d-1")
+			require.NoError(t, err)
+			assert.True(t, claimed)
+
+			claimed, err = d.Claim(ctx, ProviderGitHub, "d-1")
+			require.NoError(t, err)
+			assert.False(t, claimed)
+
+			// Тот же ID другого хостинга — другая доставка.
+			claimed, err = d.Claim(ctx, "gitlab", "d-1")
+			require.NoError(t, err)
+			assert.True(t, claimed)
+
+			require.NoError(t, d.Release(ctx, ProviderGitHub, "d-1"))
+			claimed, err = d.Claim(ctx, ProviderGitHub, "d-1")
+			require.NoError(t, err)
+			assert.True(t, claimed)
+
+			deleted, err := d.Cleanup(ctx, 0)
+			require.NoError(t, err)
+			assert.EqualValues(t, 2, deleted)
+		})
+	}
+}
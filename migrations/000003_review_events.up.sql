@@ -0,0 +1,13 @@
+-- Журнал событий для потока /users/reviewStream и возобновления по Last-Event-ID
+CREATE TABLE IF NOT EXISTS review_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(32) NOT NULL,
+    user_id UUID NOT NULL,
+    pull_request_id UUID NOT NULL,
+    pull_request_name VARCHAR(255) NOT NULL,
+    author_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_review_events_user ON review_events(user_id, id);
+CREATE INDEX idx_review_events_created ON review_events(created_at);
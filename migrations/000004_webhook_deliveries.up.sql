@@ -0,0 +1,9 @@
+-- Обработанные доставки webhook'ов: повторная доставка с тем же ID пропускается
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    provider VARCHAR(32) NOT NULL,
+    delivery_id VARCHAR(255) NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, delivery_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_received ON webhook_deliveries(received_at);
@@ -0,0 +1,9 @@
+-- Связь PR сервиса с pull/merge request'ом на хостинге кода: по ней
+-- назначенные ревьюверы отправляются обратно на хостинг
+CREATE TABLE IF NOT EXISTS pull_request_links (
+    pull_request_id UUID PRIMARY KEY,
+    provider VARCHAR(32) NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    number BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
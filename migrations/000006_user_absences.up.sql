@@ -0,0 +1,8 @@
+-- Отсутствия пользователей, заданные через /review away until: по
+-- наступлении away_until пользователь снова становится активным
+CREATE TABLE IF NOT EXISTS user_absences (
+    user_id UUID PRIMARY KEY,
+    away_until TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_user_absences_until ON user_absences(away_until);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_digests;
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,14 @@
+-- Настройки email-уведомлений пользователей
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    assignments BOOLEAN NOT NULL DEFAULT TRUE,
+    digest BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Отправленные ежедневные сводки: за день сводку отправляет одна реплика
+CREATE TABLE IF NOT EXISTS notification_digests (
+    digest_date DATE PRIMARY KEY,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,9 @@
+-- Не применится, если имена команд в разных организациях совпадают
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS org_id;
+ALTER TABLE review_events DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS org_id;
+ALTER TABLE users DROP COLUMN IF EXISTS org_id;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_org_team_name_key;
+ALTER TABLE teams DROP COLUMN IF EXISTS org_id;
+ALTER TABLE teams ADD CONSTRAINT teams_team_name_key UNIQUE (team_name);
+DROP TABLE IF EXISTS organizations;
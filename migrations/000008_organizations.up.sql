@@ -0,0 +1,33 @@
+-- Организации: команды, пользователи, PR и события разделены между ними.
+-- Существующие данные попадают в организацию по умолчанию.
+CREATE TABLE IF NOT EXISTS organizations (
+    org_id UUID PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    -- SHA-256 токена организации; у организации по умолчанию токена нет
+    token_hash VARCHAR(64) UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO organizations (org_id, name) VALUES ('00000000-0000-0000-0000-000000000001', 'default');
+
+-- Имя команды уникально в пределах организации
+ALTER TABLE teams ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(org_id);
+ALTER TABLE teams DROP CONSTRAINT teams_team_name_key;
+ALTER TABLE teams ADD CONSTRAINT teams_org_team_name_key UNIQUE (org_id, team_name);
+
+ALTER TABLE users ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(org_id);
+CREATE INDEX idx_users_org ON users(org_id);
+
+ALTER TABLE pull_requests ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(org_id);
+CREATE INDEX idx_pr_org ON pull_requests(org_id);
+
+ALTER TABLE review_events ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE notification_preferences ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+
+-- Значение по умолчанию нужно только для существующих строк: сервис
+-- всегда указывает организацию явно
+ALTER TABLE teams ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE review_events ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE notification_preferences ALTER COLUMN org_id DROP DEFAULT;
@@ -0,0 +1,3 @@
+-- Удалённые (offboarded) пользователи: строка остаётся ради истории PR
+-- и статистики, но не попадает в команды и кандидаты на ревью
+ALTER TABLE users ADD COLUMN removed_at TIMESTAMP;
@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS seniority_count;
+ALTER TABLE teams DROP COLUMN IF EXISTS seniority_level;
+ALTER TABLE users DROP COLUMN IF EXISTS seniority;
@@ -0,0 +1,7 @@
+-- Уровень пользователя (пустая строка — не задан) и требование команды:
+-- не меньше seniority_count ревьюверов уровня seniority_level или выше
+ALTER TABLE users ADD COLUMN seniority VARCHAR(16) NOT NULL DEFAULT ''
+    CHECK (seniority IN ('', 'junior', 'middle', 'senior'));
+ALTER TABLE teams ADD COLUMN seniority_level VARCHAR(16)
+    CHECK (seniority_level IN ('junior', 'middle', 'senior'));
+ALTER TABLE teams ADD COLUMN seniority_count INTEGER NOT NULL DEFAULT 0;
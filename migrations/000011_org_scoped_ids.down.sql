@@ -0,0 +1,51 @@
+-- Не применится, если ID пользователей, PR или доставок в разных
+-- организациях совпадают
+ALTER TABLE pull_request_links DROP CONSTRAINT pull_request_links_pkey;
+ALTER TABLE pull_request_links DROP COLUMN org_id;
+ALTER TABLE pull_request_links ADD PRIMARY KEY (pull_request_id);
+
+ALTER TABLE webhook_deliveries DROP CONSTRAINT webhook_deliveries_pkey;
+ALTER TABLE webhook_deliveries DROP COLUMN org_id;
+ALTER TABLE webhook_deliveries ADD PRIMARY KEY (provider, delivery_id);
+
+ALTER TABLE user_absences DROP CONSTRAINT user_absences_pkey;
+ALTER TABLE user_absences DROP COLUMN org_id;
+ALTER TABLE user_absences ADD PRIMARY KEY (user_id);
+
+ALTER TABLE notification_preferences DROP CONSTRAINT notification_preferences_pkey;
+ALTER TABLE notification_preferences ADD PRIMARY KEY (user_id);
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_user_id_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_pull_request_id_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_author_id_fkey;
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_pkey;
+ALTER TABLE pr_reviewers ADD PRIMARY KEY (pull_request_id, user_id);
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey;
+ALTER TABLE pull_requests ADD PRIMARY KEY (pull_request_id);
+ALTER TABLE users DROP CONSTRAINT users_pkey;
+ALTER TABLE users ADD PRIMARY KEY (user_id);
+
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES users(user_id);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pull_request_id_fkey
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE;
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(user_id);
+
+DROP VIEW user_assignment_stats;
+CREATE VIEW user_assignment_stats AS
+SELECT
+    u.user_id,
+    u.username,
+    t.team_name,
+    COUNT(pr.pull_request_id) as total_assignments,
+    COUNT(CASE WHEN pr_main.status = 'open' THEN 1 END) as open_assignments,
+    COUNT(CASE WHEN pr_main.status = 'merged' THEN 1 END) as merged_assignments
+FROM users u
+JOIN teams t ON u.team_id = t.team_id
+LEFT JOIN pr_reviewers pr ON u.user_id = pr.user_id
+LEFT JOIN pull_requests pr_main ON pr.pull_request_id = pr_main.pull_request_id
+GROUP BY u.user_id, u.username, t.team_name;
+
+ALTER TABLE pr_reviewers DROP COLUMN org_id;
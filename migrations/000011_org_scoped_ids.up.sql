@@ -0,0 +1,64 @@
+-- ID пользователей и PR уникальны в пределах организации: с глобальными
+-- ключами попытка занять чужой ID давала USER_EXISTS/PR_EXISTS и выдавала,
+-- что он есть в другой организации. Таблицы, ссылающиеся на пользователей
+-- и PR, тоже получают org_id; существующие строки попадают в организацию
+-- по умолчанию.
+ALTER TABLE pr_reviewers ADD COLUMN org_id UUID;
+UPDATE pr_reviewers prr SET org_id = pr.org_id
+FROM pull_requests pr
+WHERE pr.pull_request_id = prr.pull_request_id;
+ALTER TABLE pr_reviewers ALTER COLUMN org_id SET NOT NULL;
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_pull_request_id_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_user_id_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_author_id_fkey;
+
+ALTER TABLE users DROP CONSTRAINT users_pkey;
+ALTER TABLE users ADD PRIMARY KEY (org_id, user_id);
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey;
+ALTER TABLE pull_requests ADD PRIMARY KEY (org_id, pull_request_id);
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_pkey;
+ALTER TABLE pr_reviewers ADD PRIMARY KEY (org_id, pull_request_id, user_id);
+
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (org_id, author_id) REFERENCES users(org_id, user_id);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pull_request_id_fkey
+    FOREIGN KEY (org_id, pull_request_id) REFERENCES pull_requests(org_id, pull_request_id) ON DELETE CASCADE;
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_user_id_fkey
+    FOREIGN KEY (org_id, user_id) REFERENCES users(org_id, user_id);
+
+-- Новую первую колонку CREATE OR REPLACE добавить не может.
+DROP VIEW user_assignment_stats;
+CREATE VIEW user_assignment_stats AS
+SELECT
+    u.org_id,
+    u.user_id,
+    u.username,
+    t.team_name,
+    COUNT(pr.pull_request_id) as total_assignments,
+    COUNT(CASE WHEN pr_main.status = 'open' THEN 1 END) as open_assignments,
+    COUNT(CASE WHEN pr_main.status = 'merged' THEN 1 END) as merged_assignments
+FROM users u
+JOIN teams t ON u.org_id = t.org_id AND u.team_id = t.team_id
+LEFT JOIN pr_reviewers pr ON u.org_id = pr.org_id AND u.user_id = pr.user_id
+LEFT JOIN pull_requests pr_main ON pr.org_id = pr_main.org_id AND pr.pull_request_id = pr_main.pull_request_id
+GROUP BY u.org_id, u.user_id, u.username, t.team_name;
+
+ALTER TABLE notification_preferences DROP CONSTRAINT notification_preferences_pkey;
+ALTER TABLE notification_preferences ADD PRIMARY KEY (org_id, user_id);
+
+ALTER TABLE user_absences ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE user_absences DROP CONSTRAINT user_absences_pkey;
+ALTER TABLE user_absences ADD PRIMARY KEY (org_id, user_id);
+
+ALTER TABLE webhook_deliveries ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE webhook_deliveries DROP CONSTRAINT webhook_deliveries_pkey;
+ALTER TABLE webhook_deliveries ADD PRIMARY KEY (org_id, provider, delivery_id);
+
+ALTER TABLE pull_request_links ADD COLUMN org_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE pull_request_links DROP CONSTRAINT pull_request_links_pkey;
+ALTER TABLE pull_request_links ADD PRIMARY KEY (org_id, pull_request_id);
+
+ALTER TABLE user_absences ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE webhook_deliveries ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pull_request_links ALTER COLUMN org_id DROP DEFAULT;
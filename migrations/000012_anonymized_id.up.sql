@@ -0,0 +1,5 @@
+-- ID, под которым пользователь будет обезличен. Выбирается при первой
+-- попытке анонимизации, чтобы повторная попытка после сбоя переписала
+-- оставшиеся данные на тот же ID. Строка с ним удаляется вместе с
+-- исходным user_id.
+ALTER TABLE users ADD COLUMN anonymized_id UUID;
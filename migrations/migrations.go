@@ -0,0 +1,16 @@
+// Package migrations содержит SQL миграции, встроенные в бинарник.
+// Миграции PostgreSQL лежат в корне, SQLite — в каталоге sqlite.
+package migrations
+
+import "embed"
+
+// FS встроенные файлы миграций.
+//
+//go:embed *.sql sqlite/*.sql
+var FS embed.FS
+
+// Каталоги миграций в FS для каждого хранилища.
+const (
+	PostgresDir = "."
+	SQLiteDir   = "sqlite"
+)
@@ -0,0 +1,7 @@
+-- Удаляем представления и таблицы в обратном порядке (из-за foreign keys)
+DROP VIEW IF EXISTS pr_stats;
+DROP VIEW IF EXISTS user_assignment_stats;
+DROP TABLE IF EXISTS pr_reviewers;
+DROP TABLE IF EXISTS pull_requests;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS teams;
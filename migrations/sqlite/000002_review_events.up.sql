@@ -0,0 +1,13 @@
+-- Журнал событий для потока /users/reviewStream, повторяет migrations/000003_review_events.up.sql.
+CREATE TABLE IF NOT EXISTS review_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type VARCHAR(32) NOT NULL,
+    user_id TEXT NOT NULL,
+    pull_request_id TEXT NOT NULL,
+    pull_request_name VARCHAR(255) NOT NULL,
+    author_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+CREATE INDEX idx_review_events_user ON review_events(user_id, id);
+CREATE INDEX idx_review_events_created ON review_events(created_at);
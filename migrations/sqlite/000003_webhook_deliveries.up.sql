@@ -0,0 +1,9 @@
+-- Обработанные доставки webhook'ов, повторяет migrations/000004_webhook_deliveries.up.sql.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    provider VARCHAR(32) NOT NULL,
+    delivery_id VARCHAR(255) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    PRIMARY KEY (provider, delivery_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_received ON webhook_deliveries(received_at);
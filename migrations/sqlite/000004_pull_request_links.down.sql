@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pull_request_links;
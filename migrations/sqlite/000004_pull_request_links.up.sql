@@ -0,0 +1,8 @@
+-- Связь PR с хостингом кода, повторяет migrations/000005_pull_request_links.up.sql.
+CREATE TABLE IF NOT EXISTS pull_request_links (
+    pull_request_id TEXT PRIMARY KEY,
+    provider VARCHAR(32) NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    number BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
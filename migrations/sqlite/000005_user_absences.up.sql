@@ -0,0 +1,7 @@
+-- Отсутствия пользователей, повторяет migrations/000006_user_absences.up.sql.
+CREATE TABLE IF NOT EXISTS user_absences (
+    user_id TEXT PRIMARY KEY,
+    away_until TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_user_absences_until ON user_absences(away_until);
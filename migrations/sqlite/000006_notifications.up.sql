@@ -0,0 +1,13 @@
+-- Настройки уведомлений, повторяет migrations/000007_notifications.up.sql.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id TEXT PRIMARY KEY,
+    email TEXT NOT NULL DEFAULT '',
+    assignments BOOLEAN NOT NULL DEFAULT 1,
+    digest BOOLEAN NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS notification_digests (
+    digest_date TEXT PRIMARY KEY,
+    sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,97 @@
+-- Откат migrations/sqlite/000007_organizations.up.sql тем же пересозданием
+-- таблиц. Не применится, если имена команд в разных организациях совпадают.
+ALTER TABLE notification_preferences DROP COLUMN org_id;
+ALTER TABLE review_events DROP COLUMN org_id;
+
+DROP VIEW IF EXISTS user_assignment_stats;
+DROP VIEW IF EXISTS pr_stats;
+
+CREATE TABLE teams_old (
+    team_id TEXT PRIMARY KEY,
+    team_name VARCHAR(255) UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+INSERT INTO teams_old (team_id, team_name, created_at)
+SELECT team_id, team_name, created_at FROM teams;
+
+CREATE TABLE users_old (
+    user_id TEXT PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    team_id TEXT NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    FOREIGN KEY (team_id) REFERENCES teams_old(team_id) ON DELETE CASCADE
+);
+
+INSERT INTO users_old (user_id, username, team_id, is_active, created_at, updated_at)
+SELECT user_id, username, team_id, is_active, created_at, updated_at FROM users;
+
+CREATE TABLE pull_requests_old (
+    pull_request_id TEXT PRIMARY KEY,
+    pull_request_name VARCHAR(255) NOT NULL,
+    author_id TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    merged_at TIMESTAMP,
+    FOREIGN KEY (author_id) REFERENCES users_old(user_id)
+);
+
+INSERT INTO pull_requests_old (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at FROM pull_requests;
+
+CREATE TABLE pr_reviewers_old (
+    pull_request_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    assigned_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    PRIMARY KEY (pull_request_id, user_id),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests_old(pull_request_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users_old(user_id)
+);
+
+INSERT INTO pr_reviewers_old (pull_request_id, user_id, assigned_at)
+SELECT pull_request_id, user_id, assigned_at FROM pr_reviewers;
+
+DROP TABLE pr_reviewers;
+DROP TABLE pull_requests;
+DROP TABLE users;
+DROP TABLE teams;
+
+ALTER TABLE teams_old RENAME TO teams;
+ALTER TABLE users_old RENAME TO users;
+ALTER TABLE pull_requests_old RENAME TO pull_requests;
+ALTER TABLE pr_reviewers_old RENAME TO pr_reviewers;
+
+CREATE INDEX idx_teams_name ON teams(team_name);
+CREATE INDEX idx_users_team ON users(team_id);
+CREATE INDEX idx_users_username ON users(username);
+CREATE INDEX idx_pr_author ON pull_requests(author_id);
+CREATE INDEX idx_pr_status ON pull_requests(status);
+CREATE INDEX idx_pr_created ON pull_requests(created_at DESC);
+CREATE INDEX idx_pr_reviewers_user ON pr_reviewers(user_id);
+CREATE INDEX idx_pr_reviewers_pr ON pr_reviewers(pull_request_id);
+
+CREATE VIEW IF NOT EXISTS user_assignment_stats AS
+SELECT
+    u.user_id,
+    u.username,
+    t.team_name,
+    COUNT(pr.pull_request_id) as total_assignments,
+    COUNT(CASE WHEN pr_main.status = 'open' THEN 1 END) as open_assignments,
+    COUNT(CASE WHEN pr_main.status = 'merged' THEN 1 END) as merged_assignments
+FROM users u
+JOIN teams t ON u.team_id = t.team_id
+LEFT JOIN pr_reviewers pr ON u.user_id = pr.user_id
+LEFT JOIN pull_requests pr_main ON pr.pull_request_id = pr_main.pull_request_id
+GROUP BY u.user_id, u.username, t.team_name;
+
+CREATE VIEW IF NOT EXISTS pr_stats AS
+SELECT
+    COUNT(*) FILTER (WHERE status = 'open') as total_open,
+    COUNT(*) FILTER (WHERE status = 'merged') as total_merged,
+    COUNT(*) as total_prs,
+    AVG((julianday(merged_at) - julianday(created_at)) * 24) FILTER (WHERE merged_at IS NOT NULL) as avg_merge_time_hours
+FROM pull_requests;
+
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,2 @@
+-- Удалённые пользователи, повторяет migrations/000009_user_removal.up.sql.
+ALTER TABLE users ADD COLUMN removed_at TIMESTAMP;
@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN seniority_count;
+ALTER TABLE teams DROP COLUMN seniority_level;
+ALTER TABLE users DROP COLUMN seniority;
@@ -0,0 +1,6 @@
+-- Уровни и требование команды, повторяет migrations/000010_seniority.up.sql.
+ALTER TABLE users ADD COLUMN seniority VARCHAR(16) NOT NULL DEFAULT ''
+    CHECK (seniority IN ('', 'junior', 'middle', 'senior'));
+ALTER TABLE teams ADD COLUMN seniority_level VARCHAR(16)
+    CHECK (seniority_level IN ('junior', 'middle', 'senior'));
+ALTER TABLE teams ADD COLUMN seniority_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,143 @@
+-- Откат migrations/sqlite/000010_org_scoped_ids.up.sql тем же
+-- пересозданием таблиц. Не применится, если ID пользователей, PR или
+-- доставок в разных организациях совпадают.
+DROP VIEW IF EXISTS user_assignment_stats;
+DROP VIEW IF EXISTS pr_stats;
+
+CREATE TABLE pull_request_links_old (
+    pull_request_id TEXT PRIMARY KEY,
+    provider VARCHAR(32) NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    number BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+INSERT INTO pull_request_links_old (pull_request_id, provider, repository, number, created_at)
+SELECT pull_request_id, provider, repository, number, created_at FROM pull_request_links;
+
+DROP TABLE pull_request_links;
+ALTER TABLE pull_request_links_old RENAME TO pull_request_links;
+
+CREATE TABLE webhook_deliveries_old (
+    provider VARCHAR(32) NOT NULL,
+    delivery_id VARCHAR(255) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    PRIMARY KEY (provider, delivery_id)
+);
+
+INSERT INTO webhook_deliveries_old (provider, delivery_id, received_at)
+SELECT provider, delivery_id, received_at FROM webhook_deliveries;
+
+DROP TABLE webhook_deliveries;
+ALTER TABLE webhook_deliveries_old RENAME TO webhook_deliveries;
+CREATE INDEX idx_webhook_deliveries_received ON webhook_deliveries(received_at);
+
+CREATE TABLE user_absences_old (
+    user_id TEXT PRIMARY KEY,
+    away_until TIMESTAMP NOT NULL
+);
+
+INSERT INTO user_absences_old (user_id, away_until)
+SELECT user_id, away_until FROM user_absences;
+
+DROP TABLE user_absences;
+ALTER TABLE user_absences_old RENAME TO user_absences;
+CREATE INDEX idx_user_absences_until ON user_absences(away_until);
+
+CREATE TABLE notification_preferences_old (
+    user_id TEXT PRIMARY KEY,
+    email TEXT NOT NULL DEFAULT '',
+    assignments BOOLEAN NOT NULL DEFAULT 1,
+    digest BOOLEAN NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    org_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001'
+);
+
+INSERT INTO notification_preferences_old (user_id, email, assignments, digest, updated_at, org_id)
+SELECT user_id, email, assignments, digest, updated_at, org_id FROM notification_preferences;
+
+DROP TABLE notification_preferences;
+ALTER TABLE notification_preferences_old RENAME TO notification_preferences;
+
+CREATE TABLE users_old (
+    user_id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL REFERENCES organizations(org_id),
+    username VARCHAR(255) NOT NULL,
+    team_id TEXT NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    removed_at TIMESTAMP,
+    seniority VARCHAR(16) NOT NULL DEFAULT ''
+        CHECK (seniority IN ('', 'junior', 'middle', 'senior')),
+    FOREIGN KEY (team_id) REFERENCES teams(team_id) ON DELETE CASCADE
+);
+
+INSERT INTO users_old (user_id, org_id, username, team_id, is_active, created_at, updated_at, removed_at, seniority)
+SELECT user_id, org_id, username, team_id, is_active, created_at, updated_at, removed_at, seniority FROM users;
+
+CREATE TABLE pull_requests_old (
+    pull_request_id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL REFERENCES organizations(org_id),
+    pull_request_name VARCHAR(255) NOT NULL,
+    author_id TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    merged_at TIMESTAMP,
+    FOREIGN KEY (author_id) REFERENCES users_old(user_id)
+);
+
+INSERT INTO pull_requests_old (pull_request_id, org_id, pull_request_name, author_id, status, created_at, merged_at)
+SELECT pull_request_id, org_id, pull_request_name, author_id, status, created_at, merged_at FROM pull_requests;
+
+CREATE TABLE pr_reviewers_old (
+    pull_request_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    assigned_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    PRIMARY KEY (pull_request_id, user_id),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests_old(pull_request_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users_old(user_id)
+);
+
+INSERT INTO pr_reviewers_old (pull_request_id, user_id, assigned_at)
+SELECT pull_request_id, user_id, assigned_at FROM pr_reviewers;
+
+DROP TABLE pr_reviewers;
+DROP TABLE pull_requests;
+DROP TABLE users;
+
+ALTER TABLE users_old RENAME TO users;
+ALTER TABLE pull_requests_old RENAME TO pull_requests;
+ALTER TABLE pr_reviewers_old RENAME TO pr_reviewers;
+
+CREATE INDEX idx_users_team ON users(team_id);
+CREATE INDEX idx_users_username ON users(username);
+CREATE INDEX idx_users_org ON users(org_id);
+CREATE INDEX idx_pr_author ON pull_requests(author_id);
+CREATE INDEX idx_pr_status ON pull_requests(status);
+CREATE INDEX idx_pr_created ON pull_requests(created_at DESC);
+CREATE INDEX idx_pr_org ON pull_requests(org_id);
+CREATE INDEX idx_pr_reviewers_user ON pr_reviewers(user_id);
+CREATE INDEX idx_pr_reviewers_pr ON pr_reviewers(pull_request_id);
+
+CREATE VIEW IF NOT EXISTS user_assignment_stats AS
+SELECT
+    u.user_id,
+    u.username,
+    t.team_name,
+    COUNT(pr.pull_request_id) as total_assignments,
+    COUNT(CASE WHEN pr_main.status = 'open' THEN 1 END) as open_assignments,
+    COUNT(CASE WHEN pr_main.status = 'merged' THEN 1 END) as merged_assignments
+FROM users u
+JOIN teams t ON u.team_id = t.team_id
+LEFT JOIN pr_reviewers pr ON u.user_id = pr.user_id
+LEFT JOIN pull_requests pr_main ON pr.pull_request_id = pr_main.pull_request_id
+GROUP BY u.user_id, u.username, t.team_name;
+
+CREATE VIEW IF NOT EXISTS pr_stats AS
+SELECT
+    COUNT(*) FILTER (WHERE status = 'open') as total_open,
+    COUNT(*) FILTER (WHERE status = 'merged') as total_merged,
+    COUNT(*) as total_prs,
+    AVG((julianday(merged_at) - julianday(created_at)) * 24) FILTER (WHERE merged_at IS NOT NULL) as avg_merge_time_hours
+FROM pull_requests;
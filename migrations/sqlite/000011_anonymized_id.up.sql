@@ -0,0 +1,2 @@
+-- ID будущей анонимизации, повторяет migrations/000012_anonymized_id.up.sql.
+ALTER TABLE users ADD COLUMN anonymized_id TEXT;
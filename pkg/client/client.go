@@ -0,0 +1,438 @@
+// Package client Go клиент HTTP API сервиса назначения ревьюеров.
+//
+// Методы повторяют service.ServiceInterface и используют те же модели
+// (алиасы на internal/domain), ошибки API декодируются в *APIError.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+)
+
+// Модели API.
+type (
+	Team                     = domain.Team
+	TeamMember               = domain.TeamMember
+	SeniorityRule            = domain.SeniorityRule
+	User                     = domain.User
+	PullRequestWithReviewers = domain.PullRequestWithReviewers
+	PullRequestShort         = domain.PullRequestShort
+	Statistics               = domain.Statistics
+	PRStats                  = domain.PRStats
+	UserAssignmentStats      = domain.UserAssignmentStats
+	UserRemoval              = domain.UserRemoval
+	ReviewerReplacement      = domain.ReviewerReplacement
+)
+
+// Заголовки запросов.
+const (
+	AdminTokenHeader     = "X-Admin-Token"
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// RetryPolicy настройки повторов. Повторяются сетевые ошибки и ответы
+// 429, 502, 503, 504. Все попытки одного вызова отправляются с одним
+// Idempotency-Key.
+type RetryPolicy struct {
+	// MaxAttempts общее число попыток, 1 отключает повторы.
+	MaxAttempts int
+	// BaseDelay задержка перед второй попыткой, дальше удваивается.
+	BaseDelay time.Duration
+	// MaxDelay верхняя граница задержки, в том числе из Retry-After.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy политика повторов по умолчанию.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// Client клиент HTTP API. Безопасен для конкурентного использования.
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	adminToken  string
+	bearerToken string
+	userAgent   string
+	retry       RetryPolicy
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+// Option настраивает Client.
+type Option func(*Client)
+
+// WithHTTPClient задаёт http.Client (таймауты, транспорт).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithAdminToken задаёт токен для admin методов (SetUserActive, RemoveUser,
+// SetUserSeniority, SetSeniorityRule).
+func WithAdminToken(token string) Option {
+	return func(c *Client) {
+		c.adminToken = token
+	}
+}
+
+// WithBearerToken задаёт токен клиента для Authorization: Bearer.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.bearerToken = token
+	}
+}
+
+// WithUserAgent задаёт User-Agent запросов.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithRetry задаёт политику повторов.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// New создаёт клиент для сервиса по адресу baseURL, например "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		userAgent:  "pr-reviewer-client",
+		retry:      DefaultRetryPolicy,
+		sleep:      sleepContext,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+	return c, nil
+}
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey задаёт Idempotency-Key для вызовов с этим контекстом.
+// По умолчанию клиент генерирует новый ключ на каждый вызов.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// ========================================
+// Team Methods
+// ========================================
+
+func (c *Client) CreateTeam(ctx context.Context, team *Team) error {
+	return c.do(ctx, http.MethodPost, "/team/add", nil, team, nil)
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*Team, error) {
+	var team Team
+	query := url.Values{"team_name": {teamName}}
+	if err := c.do(ctx, http.MethodGet, "/team/get", query, nil, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// SetSeniorityRule задаёт команде минимум ревьюверов нужного уровня на PR;
+// nil снимает требование. Требует admin токен.
+func (c *Client) SetSeniorityRule(ctx context.Context, teamName string, rule *SeniorityRule) (*Team, error) {
+	req := map[string]any{"team_name": teamName, "seniority_rule": rule}
+	var resp struct {
+		Team *Team `json:"team"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/team/setSeniorityRule", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Team, nil
+}
+
+// ========================================
+// User Methods
+// ========================================
+
+func (c *Client) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool) (*User, error) {
+	req := map[string]any{"user_id": userID, "is_active": isActive}
+	var resp struct {
+		User *User `json:"user"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/users/setIsActive", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// SetUserSeniority задаёт уровень пользователя: junior, middle или senior.
+// Требует admin токен.
+func (c *Client) SetUserSeniority(ctx context.Context, userID uuid.UUID, seniority string) (*User, error) {
+	req := map[string]any{"user_id": userID, "seniority": seniority}
+	var resp struct {
+		User *User `json:"user"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/users/setSeniority", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+func (c *Client) GetUserReviews(ctx context.Context, userID uuid.UUID) ([]PullRequestShort, error) {
+	var resp struct {
+		PullRequests []PullRequestShort `json:"pull_requests"`
+	}
+	query := url.Values{"user_id": {userID.String()}}
+	if err := c.do(ctx, http.MethodGet, "/users/getReview", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PullRequests, nil
+}
+
+// RemoveUser переназначает открытые ревью пользователя и помечает его
+// удалённым. Требует admin токен и включённого на сервере удаления.
+func (c *Client) RemoveUser(ctx context.Context, userID uuid.UUID) (*UserRemoval, error) {
+	req := map[string]any{"user_id": userID}
+	var removal UserRemoval
+	if err := c.do(ctx, http.MethodPost, "/users/remove", nil, req, &removal); err != nil {
+		return nil, err
+	}
+	return &removal, nil
+}
+
+// ========================================
+// PullRequest Methods
+// ========================================
+
+type prEnvelope struct {
+	PR         *PullRequestWithReviewers `json:"pr"`
+	ReplacedBy uuid.UUID                 `json:"replaced_by"`
+}
+
+func (c *Client) CreatePR(ctx context.Context, prID uuid.UUID, prName string, authorID uuid.UUID) (*PullRequestWithReviewers, error) {
+	req := map[string]any{
+		"pull_request_id":   prID,
+		"pull_request_name": prName,
+		"author_id":         authorID,
+	}
+	var resp prEnvelope
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/create", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) MergePR(ctx context.Context, prID uuid.UUID) (*PullRequestWithReviewers, error) {
+	req := map[string]any{"pull_request_id": prID}
+	var resp prEnvelope
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/merge", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PR, nil
+}
+
+// ReassignReviewer возвращает обновлённый PR и ID нового ревьюера.
+func (c *Client) ReassignReviewer(ctx context.Context, prID, oldUserID uuid.UUID) (*PullRequestWithReviewers, uuid.UUID, error) {
+	req := map[string]any{"pull_request_id": prID, "old_user_id": oldUserID}
+	var resp prEnvelope
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", nil, req, &resp); err != nil {
+		return nil, uuid.Nil, err
+	}
+	return resp.PR, resp.ReplacedBy, nil
+}
+
+// ========================================
+// Stats Methods
+// ========================================
+
+func (c *Client) GetStatistics(ctx context.Context) (*Statistics, error) {
+	var stats Statistics
+	if err := c.do(ctx, http.MethodGet, "/stats", nil, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ========================================
+// Transport
+// ========================================
+
+// do выполняет запрос с повторами и декодирует ответ в out.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	key, _ := ctx.Value(idempotencyKeyCtx{}).(string)
+	if key == "" {
+		key = uuid.NewString()
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		retryAfter, err := c.attempt(ctx, method, target, key, body, out)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if attempt >= c.retry.MaxAttempts || !retryable(err) || ctx.Err() != nil {
+			return lastErr
+		}
+
+		if err := c.sleep(ctx, c.backoff(attempt, retryAfter)); err != nil {
+			return lastErr
+		}
+	}
+}
+
+// attempt выполняет одну попытку. Возвращает Retry-After ответа, если он был.
+func (c *Client) attempt(ctx context.Context, method, target, key string, body []byte, out any) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if method != http.MethodGet {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if c.adminToken != "" {
+		req.Header.Set(AdminTokenHeader, c.adminToken)
+	}
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, &transportError{err: err}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, &transportError{err: fmt.Errorf("failed to read response: %w", err)}
+	}
+
+	if resp.StatusCode >= 400 {
+		return parseRetryAfter(resp.Header.Get("Retry-After")), decodeError(resp.StatusCode, data)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return 0, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return 0, nil
+}
+
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	delay := retryAfter
+	if delay <= 0 {
+		delay = c.retry.BaseDelay << (attempt - 1)
+	}
+	if c.retry.MaxDelay > 0 && delay > c.retry.MaxDelay {
+		delay = c.retry.MaxDelay
+	}
+	return delay
+}
+
+// transportError ошибка сети: запрос мог не дойти до сервиса.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string { return e.err.Error() }
+func (e *transportError) Unwrap() error { return e.err }
+
+func retryable(err error) bool {
+	var te *transportError
+	if errors.As(err, &te) {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+func decodeError(status int, data []byte) error {
+	var resp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil || resp.Error.Code == "" {
+		return &APIError{StatusCode: status, Message: strings.TrimSpace(string(data))}
+	}
+	return &APIError{StatusCode: status, Code: resp.Error.Code, Message: resp.Error.Message}
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,269 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/T1mof/pr-reviewer-service/internal/domain"
+	"github.com/T1mof/pr-reviewer-service/internal/handler"
+	"github.com/T1mof/pr-reviewer-service/internal/repository"
+	"github.com/T1mof/pr-reviewer-service/internal/service"
+)
+
+// Клиент должен покрывать весь ServiceInterface.
+var _ service.ServiceInterface = (*Client)(nil)
+
+func newTestClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+
+	svc := service.NewReviewerService(repository.NewMemoryRepository())
+	srv := httptest.NewServer(handler.NewHandler(svc, "test-token", handler.WithUserRemoval(svc), handler.WithSeniority(svc)).SetupRouter())
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, opts...)
+	require.NoError(t, err)
+	return c
+}
+
+func createTeam(t *testing.T, c *Client, name string, size int) []uuid.UUID {
+	t.Helper()
+
+	team := &Team{TeamName: name}
+	ids := make([]uuid.UUID, size)
+	for i := range ids {
+		ids[i] = uuid.New()
+		team.Members = append(team.Members, TeamMember{UserID: ids[i], Username: name + "-user", IsActive: true})
+	}
+	require.NoError(t, c.CreateTeam(context.Background(), team))
+	return ids
+}
+
+func TestClient_Workflow(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, WithAdminToken("test-token"))
+
+	ids := createTeam(t, c, "backend", 4)
+
+	team, err := c.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, team.Members, 4)
+
+	prID := uuid.New()
+	pr, err := c.CreatePR(ctx, prID, "Add feature", ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, prID, pr.PullRequestID)
+	assert.Equal(t, domain.StatusOpen, pr.Status)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	reviews, err := c.GetUserReviews(ctx, pr.AssignedReviewers[0])
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, prID, reviews[0].PullRequestID)
+
+	updated, newReviewer, err := c.ReassignReviewer(ctx, prID, pr.AssignedReviewers[0])
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, newReviewer)
+	assert.Contains(t, updated.AssignedReviewers, newReviewer)
+
+	user, err := c.SetUserActive(ctx, ids[3], false)
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	merged, err := c.MergePR(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusMerged, merged.Status)
+	assert.NotNil(t, merged.MergedAt)
+
+	stats, err := c.GetStatistics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.PRStats.TotalMerged)
+	assert.Equal(t, 4, stats.TotalUsers)
+
+	removal, err := c.RemoveUser(ctx, ids[3])
+	require.NoError(t, err)
+	assert.Equal(t, ids[3], removal.User.UserID)
+	assert.Empty(t, removal.Reassigned)
+
+	team, err = c.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, team.Members, 3)
+
+	_, err = c.RemoveUser(ctx, ids[3])
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClient_Seniority(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, WithAdminToken("test-token"))
+
+	ids := createTeam(t, c, "backend", 4)
+
+	team, err := c.SetSeniorityRule(ctx, "backend", &SeniorityRule{Level: domain.SenioritySenior, Count: 1})
+	require.NoError(t, err)
+	assert.Equal(t, &SeniorityRule{Level: domain.SenioritySenior, Count: 1}, team.SeniorityRule)
+
+	_, err = c.CreatePR(ctx, uuid.New(), "Fix", ids[0])
+	assert.ErrorIs(t, err, ErrNoSeniorCandidate)
+
+	user, err := c.SetUserSeniority(ctx, ids[1], domain.SenioritySenior)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SenioritySenior, user.Seniority)
+
+	pr, err := c.CreatePR(ctx, uuid.New(), "Fix", ids[0])
+	require.NoError(t, err)
+	assert.Contains(t, pr.AssignedReviewers, ids[1])
+
+	_, _, err = c.ReassignReviewer(ctx, pr.PullRequestID, ids[1])
+	assert.ErrorIs(t, err, ErrNoSeniorCandidate)
+
+	_, err = c.SetUserSeniority(ctx, ids[1], "principal")
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+
+	team, err = c.SetSeniorityRule(ctx, "backend", nil)
+	require.NoError(t, err)
+	assert.Nil(t, team.SeniorityRule)
+
+	_, err = c.SetSeniorityRule(ctx, "ghost", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClient_TypedErrors(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	ids := createTeam(t, c, "trio", 3)
+
+	err := c.CreateTeam(ctx, &Team{TeamName: "trio", Members: []TeamMember{{UserID: uuid.New(), Username: "x"}}})
+	assert.ErrorIs(t, err, ErrTeamExists)
+
+	_, err = c.GetTeam(ctx, "ghost")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Без admin токена.
+	_, err = c.SetUserActive(ctx, ids[0], false)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = c.RemoveUser(ctx, ids[0])
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	prID := uuid.New()
+	pr, err := c.CreatePR(ctx, prID, "Fix", ids[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	_, err = c.CreatePR(ctx, prID, "Fix", ids[0])
+	assert.ErrorIs(t, err, ErrPRExists)
+
+	// Кроме автора и второго ревьюера в команде никого нет.
+	_, _, err = c.ReassignReviewer(ctx, prID, pr.AssignedReviewers[0])
+	assert.ErrorIs(t, err, ErrNoCandidate)
+
+	_, err = c.MergePR(ctx, prID)
+	require.NoError(t, err)
+
+	_, _, err = c.ReassignReviewer(ctx, prID, pr.AssignedReviewers[0])
+	assert.ErrorIs(t, err, ErrPRMerged)
+	assert.NotErrorIs(t, err, ErrNoCandidate)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+}
+
+func TestClient_RetriesWithSameIdempotencyKey(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		attempt := len(keys)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if attempt < 3 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":{"code":"RATE_LIMITED","message":"too many requests"}}`))
+			return
+		}
+		w.Write([]byte(`{"pr":{"pull_request_id":"` + uuid.NewString() + `","status":"merged"}}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}))
+	require.NoError(t, err)
+
+	var delays []time.Duration
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	pr, err := c.MergePR(context.Background(), uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusMerged, pr.Status)
+
+	require.Len(t, keys, 3)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+	assert.Equal(t, keys[0], keys[2])
+	assert.Equal(t, []time.Duration{time.Second, time.Second}, delays)
+}
+
+func TestClient_NoRetryOnBusinessError(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error":{"code":"PR_MERGED","message":"cannot reassign on merged PR"}}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := WithIdempotencyKey(context.Background(), "fixed-key")
+	_, _, err = c.ReassignReviewer(ctx, uuid.New(), uuid.New())
+
+	assert.ErrorIs(t, err, ErrPRMerged)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestClient_NonAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, WithRetry(RetryPolicy{MaxAttempts: 2}))
+	require.NoError(t, err)
+	c.sleep = func(context.Context, time.Duration) error { return nil }
+
+	_, err = c.GetStatistics(context.Background())
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Empty(t, apiErr.Code)
+	assert.Equal(t, "HTTP 502: bad gateway", err.Error())
+}
+
+func TestClient_ContextCanceled(t *testing.T) {
+	c := newTestClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.GetStatistics(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Коды ошибок API. Сравниваются через errors.Is:
+//
+//	if errors.Is(err, client.ErrNoCandidate) { ... }
+var (
+	ErrInvalidRequest     = &APIError{Code: "INVALID_REQUEST"}
+	ErrUnauthorized       = &APIError{Code: "UNAUTHORIZED"}
+	ErrNotFound           = &APIError{Code: "NOT_FOUND"}
+	ErrTeamExists         = &APIError{Code: "TEAM_EXISTS"}
+	ErrPRExists           = &APIError{Code: "PR_EXISTS"}
+	ErrUserRemoved        = &APIError{Code: "USER_REMOVED"}
+	ErrPRMerged           = &APIError{Code: "PR_MERGED"}
+	ErrNotAssigned        = &APIError{Code: "NOT_ASSIGNED"}
+	ErrNoCandidate        = &APIError{Code: "NO_CANDIDATE"}
+	ErrNoSeniorCandidate  = &APIError{Code: "NO_SENIOR_CANDIDATE"}
+	ErrUserHasOpenReviews = &APIError{Code: "USER_HAS_OPEN_REVIEWS"}
+	ErrRateLimited        = &APIError{Code: "RATE_LIMITED"}
+	ErrInternal           = &APIError{Code: "INTERNAL_ERROR"}
+)
+
+// APIError ошибка, возвращённая сервисом в формате ErrorResponse.
+// Для ответов не от API (прокси, балансировщик) Code пустой.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+	}
+	if e.Message == "" {
+		return e.Code
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is сравнивает ошибки по коду, чтобы работал errors.Is с ErrXxx.
+func (e *APIError) Is(target error) bool {
+	var t *APIError
+	if !errors.As(target, &t) {
+		return false
+	}
+	return t.Code != "" && t.Code == e.Code
+}